@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -83,6 +84,13 @@ type ClusterSpec struct {
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
+	// maintenanceWindows specifies the time ranges during which the disruptive
+	// opsRequests, such as Restart, Upgrade, VerticalScaling, Stop and Switchover,
+	// are allowed to start. The opsRequests keep Pending until a window opens.
+	// If not specified, the opsRequests start immediately.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// !!!!! The following fields may be deprecated in subsequent versions, please DO NOT rely on them for new requirements.
 
 	// tenancy describes how pods are distributed across node.
@@ -464,6 +472,39 @@ type ClusterComponentVolumeClaimTemplate struct {
 	// spec defines the desired characteristics of a volume requested by a pod author.
 	// +optional
 	Spec PersistentVolumeClaimSpec `json:"spec,omitempty"`
+
+	// autoExpansion expands the volume by a VolumeExpansion OpsRequest automatically when its space usage
+	// is over the high watermark of the volume protection, which is defined by `ComponentDefinition.spec.volumes.highWatermark`.
+	// +optional
+	AutoExpansion *VolumeAutoExpansion `json:"autoExpansion,omitempty"`
+}
+
+// VolumeAutoExpansion defines how a volume is expanded automatically.
+type VolumeAutoExpansion struct {
+	// maxSize is the max storage size the volume can be expanded to.
+	// +kubebuilder:validation:Required
+	MaxSize resource.Quantity `json:"maxSize"`
+
+	// step is the storage size added to the volume by each expansion.
+	// +kubebuilder:validation:Required
+	Step resource.Quantity `json:"step"`
+}
+
+// NextSize returns the storage size to expand the volume of the current size to,
+// it returns false if the volume can not be expanded anymore.
+func (r *VolumeAutoExpansion) NextSize(current resource.Quantity) (resource.Quantity, bool) {
+	if r == nil || current.Cmp(r.MaxSize) >= 0 {
+		return current, false
+	}
+	next := current.DeepCopy()
+	next.Add(r.Step)
+	if next.Cmp(r.MaxSize) > 0 {
+		next = r.MaxSize.DeepCopy()
+	}
+	if next.Cmp(current) <= 0 {
+		return current, false
+	}
+	return next, true
 }
 
 func (r *ClusterComponentVolumeClaimTemplate) toVolumeClaimTemplate() corev1.PersistentVolumeClaimTemplate {
@@ -573,6 +614,24 @@ type Issuer struct {
 	// required when from is UserProvided
 	// +optional
 	SecretRef *TLSSecretRef `json:"secretRef,omitempty"`
+
+	// enableClientCert specifies whether to issue the client certificate for mTLS,
+	// it is saved as client.crt and client.key in the TLS certs Secret.
+	// only supported when the issuer is KubeBlocks.
+	// +optional
+	EnableClientCert bool `json:"enableClientCert,omitempty"`
+
+	// certificateDuration is the lifetime of the certificates issued by KubeBlocks,
+	// it defaults to 90 days and can not exceed 90 days.
+	// only supported when the issuer is KubeBlocks.
+	// +optional
+	CertificateDuration *metav1.Duration `json:"certificateDuration,omitempty"`
+
+	// renewBefore specifies how long before the expiry the certificates are renewed, it defaults to 30 days.
+	// the certificates issued by KubeBlocks are renewed automatically, and the renewed certificates are
+	// reloaded by the config manager sidecar if the tlsReloadOptions of the ConfigConstraint is specified.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 }
 
 // TLSSecretRef defines Secret contains Tls certs
@@ -902,6 +961,23 @@ func (r *ClusterComponentSpec) ToVolumeClaimTemplates() []corev1.PersistentVolum
 	return ts
 }
 
+// GetCertificateDuration returns the lifetime of the certificates issued by KubeBlocks.
+func (r *Issuer) GetCertificateDuration() time.Duration {
+	if r == nil || r.CertificateDuration == nil || r.CertificateDuration.Duration <= 0 ||
+		r.CertificateDuration.Duration > MaxTLSCertificateDuration {
+		return MaxTLSCertificateDuration
+	}
+	return r.CertificateDuration.Duration
+}
+
+// GetRenewBefore returns the duration before the expiry to renew the certificates.
+func (r *Issuer) GetRenewBefore() time.Duration {
+	if r == nil || r.RenewBefore == nil || r.RenewBefore.Duration <= 0 {
+		return DefaultTLSRenewBefore
+	}
+	return r.RenewBefore.Duration
+}
+
 // GetClusterUpRunningPhases returns Cluster running or partially running phases.
 func GetClusterUpRunningPhases() []ClusterPhase {
 	return []ClusterPhase{
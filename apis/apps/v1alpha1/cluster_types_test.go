@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
@@ -77,6 +78,30 @@ var _ = Describe("", func() {
 		Expect(r.getStorageClassName(preferSC)).Should(BeEquivalentTo(&scName))
 	})
 
+	It("test VolumeAutoExpansion NextSize", func() {
+		r := &VolumeAutoExpansion{
+			MaxSize: resource.MustParse("25Gi"),
+			Step:    resource.MustParse("10Gi"),
+		}
+		next, ok := r.NextSize(resource.MustParse("10Gi"))
+		Expect(ok).Should(BeTrue())
+		Expect(next.String()).Should(Equal("20Gi"))
+
+		By("the next size is bounded by the max size")
+		next, ok = r.NextSize(next)
+		Expect(ok).Should(BeTrue())
+		Expect(next.String()).Should(Equal("25Gi"))
+
+		By("the volume reaches the max size")
+		_, ok = r.NextSize(next)
+		Expect(ok).Should(BeFalse())
+
+		By("auto expansion is not enabled")
+		r = nil
+		_, ok = r.NextSize(resource.MustParse("10Gi"))
+		Expect(ok).Should(BeFalse())
+	})
+
 	It("test IsDeleting", func() {
 		r := Cluster{}
 		Expect(r.IsDeleting()).Should(Equal(false))
@@ -304,6 +329,25 @@ func TestSetObjectMessage(t *testing.T) {
 	}
 }
 
+func TestIssuerCertificateDuration(t *testing.T) {
+	var issuer *Issuer
+	if issuer.GetCertificateDuration() != MaxTLSCertificateDuration || issuer.GetRenewBefore() != DefaultTLSRenewBefore {
+		t.Error("Expected the default certificate duration and renewBefore for nil issuer")
+	}
+	issuer = &Issuer{
+		Name:                IssuerKubeBlocks,
+		CertificateDuration: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+		RenewBefore:         &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	if issuer.GetCertificateDuration() != 7*24*time.Hour || issuer.GetRenewBefore() != 24*time.Hour {
+		t.Error("Expected the certificate duration and renewBefore specified")
+	}
+	issuer.CertificateDuration = &metav1.Duration{Duration: 365 * 24 * time.Hour}
+	if issuer.GetCertificateDuration() != MaxTLSCertificateDuration {
+		t.Errorf("Expected the certificate duration is capped at %s", MaxTLSCertificateDuration)
+	}
+}
+
 func TestGetComponentOrName(t *testing.T) {
 	var (
 		componentDefName = "mysqlType"
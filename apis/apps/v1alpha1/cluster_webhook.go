@@ -216,5 +216,13 @@ func (r *Cluster) validateComponentTLSSettings(allErrs *field.ErrorList) {
 		if component.Issuer.Name == IssuerUserProvided && component.Issuer.SecretRef == nil {
 			*allErrs = append(*allErrs, field.Required(field.NewPath(fmt.Sprintf("spec.components[%d].issuer.secretRef", index)), "Secret must provide when issuer name is UserProvided"))
 		}
+		if duration := component.Issuer.CertificateDuration; duration != nil && duration.Duration > MaxTLSCertificateDuration {
+			*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.components[%d].issuer.certificateDuration", index)),
+				duration.Duration.String(), fmt.Sprintf("the certificate duration can not exceed %s", MaxTLSCertificateDuration)))
+		}
+		if renewBefore := component.Issuer.RenewBefore; renewBefore != nil && renewBefore.Duration >= component.Issuer.GetCertificateDuration() {
+			*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.components[%d].issuer.renewBefore", index)),
+				renewBefore.Duration.String(), "renewBefore must be less than the certificate duration"))
+		}
 	}
 }
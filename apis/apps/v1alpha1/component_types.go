@@ -143,6 +143,37 @@ type ComponentStatus struct {
 	// Keys are podName or deployName or statefulSetName. The format is `ObjectKind/Name`.
 	// +optional
 	Message ComponentMessageMap `json:"message,omitempty"`
+
+	// tlsCertificate records the TLS certificate currently used by the component.
+	// +optional
+	TLSCertificate *TLSCertificateStatus `json:"tlsCertificate,omitempty"`
+
+	// leaderHistory records the latest leader changes of the component, the oldest first.
+	// +optional
+	LeaderHistory []workloads.LeaderChange `json:"leaderHistory,omitempty"`
+}
+
+// TLSCertificateStatus defines the observed state of the TLS certificate of the component.
+type TLSCertificateStatus struct {
+	// secretName is the name of the Secret which contains the certificate.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// serialNumber is the serial number of the certificate.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// notBefore is the time that the certificate becomes valid.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// notAfter is the time that the certificate expires.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// renewalTime is the time that the certificate will be renewed, it is only set when the certificate is issued by KubeBlocks.
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 }
 
 // +genclient
@@ -299,6 +299,28 @@ type SystemAccount struct {
 	// Cannot be updated.
 	// +optional
 	SecretRef *ProvisionSecretRef `json:"secretRef,omitempty"`
+
+	// PasswordRotationPolicy defines the policy for rotating the account's password.
+	// If it is not specified, the password will never be rotated and the account secret is immutable.
+	// It is not supported for the init account, which is used by lorry.
+	// +optional
+	PasswordRotationPolicy *PasswordRotationPolicy `json:"passwordRotationPolicy,omitempty"`
+}
+
+// PasswordRotationPolicy defines the policy for rotating the password of a system account.
+type PasswordRotationPolicy struct {
+	// Period specifies the interval between two rotations, e.g. 2160h for 90 days.
+	// If it is not specified, the password is rotated only on demand by a RotatePassword OpsRequest.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+
+	// GracePeriod specifies how long the old password keeps valid after a rotation,
+	// so that clients have a chance to pick up the new password.
+	// If it is not specified, the old password is invalidated immediately.
+	// It is supported only by the builtin handlers of the engines that support dual passwords,
+	// which are mysql, wesql and redis.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 // RoleArbitrator defines how to arbitrate the role of replicas.
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +32,11 @@ type ConfigConstraintSpec struct {
 	// +optional
 	ReloadOptions *ReloadOptions `json:"reloadOptions,omitempty"`
 
+	// tlsReloadOptions indicates how the process reloads the TLS certificates without restart.
+	// if set, the config manager sidecar watches the TLS certificates, and performs the reload when they are renewed or replaced.
+	// +optional
+	TLSReloadOptions *TLSReloadOptions `json:"tlsReloadOptions,omitempty"`
+
 	// toolConfig used to config init container.
 	// +optional
 	ToolsImageSpec *ToolsImageSpec `json:"toolsImageSpec,omitempty"`
@@ -80,6 +87,11 @@ type ConfigConstraintSpec struct {
 	// 3. applies corresponding policies.
 	// +kubebuilder:validation:Required
 	FormatterConfig *FormatterConfig `json:"formatterConfig"`
+
+	// driftDetection enables the periodic check which compares the rendered configuration
+	// with the parameters running in the engine, and reports the drifted parameters in the configuration status.
+	// +optional
+	DriftDetection *ConfigDriftDetection `json:"driftDetection,omitempty"`
 }
 
 // ConfigConstraintStatus defines the observed state of ConfigConstraint.
@@ -133,6 +145,25 @@ type ReloadOptions struct {
 	// autoTrigger performs the reload command.
 	// +optional
 	AutoTrigger *AutoTrigger `json:"autoTrigger,omitempty"`
+
+	// httpTrigger reloads the configuration by sending a request to the admin endpoint of the engine.
+	// +optional
+	HTTPTrigger *HTTPTrigger `json:"httpTrigger,omitempty"`
+
+	// sqlTrigger reloads the configuration by executing statements for the updated parameters through the engine client.
+	// +optional
+	SQLTrigger *SQLTrigger `json:"sqlTrigger,omitempty"`
+}
+
+// TLSReloadOptions defines the options to reload the TLS certificates, only one of the triggers can be specified.
+type TLSReloadOptions struct {
+	// unixSignalTrigger used to reload by sending a signal.
+	// +optional
+	UnixSignalTrigger *UnixSignalTrigger `json:"unixSignalTrigger,omitempty"`
+
+	// shellTrigger performs the reload command.
+	// +optional
+	ShellTrigger *ShellTrigger `json:"shellTrigger,omitempty"`
 }
 
 type UnixSignalTrigger struct {
@@ -240,6 +271,75 @@ type AutoTrigger struct {
 	ProcessName string `json:"processName,omitempty"`
 }
 
+type HTTPTrigger struct {
+	// url is the admin endpoint of the engine, e.g. http://127.0.0.1:8080/admin/config.
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// method is the HTTP method of the request.
+	// +kubebuilder:validation:Enum={GET,POST,PUT,PATCH}
+	// +kubebuilder:default="POST"
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// headers are the HTTP headers of the request, the environment variables in the values are expanded.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// bodyTemplate is a go template which renders the request body,
+	// the updated parameters are passed to the template as the map .Parameters.
+	// If not specified, the updated parameters are sent as a JSON object.
+	// +optional
+	BodyTemplate string `json:"bodyTemplate,omitempty"`
+
+	// Specify synchronize updates parameters to the config manager.
+	// +optional
+	Sync *bool `json:"sync,omitempty"`
+}
+
+type SQLTrigger struct {
+	// driver is the client used to connect to the engine, such as mysql, postgresql, redis and mongodb.
+	// +kubebuilder:validation:Required
+	Driver string `json:"driver"`
+
+	// dsn is the data source name used to connect to the engine, the environment variables in it are expanded.
+	// If not specified, the environment variable DATA_SOURCE_NAME is used.
+	// +optional
+	DSN string `json:"dsn,omitempty"`
+
+	// statementTemplate is a go template which renders the statement for each updated parameter,
+	// the parameter is passed to the template as .Name and .Value.
+	// If not specified, the default statement of the driver is used, e.g. SET GLOBAL for mysql,
+	// and postgresql only reloads the updated configuration files by pg_reload_conf(),
+	// since ALTER SYSTEM writes to postgresql.auto.conf which overrides the rendered configuration files.
+	// +optional
+	StatementTemplate string `json:"statementTemplate,omitempty"`
+
+	// Specify synchronize updates parameters to the config manager.
+	// +optional
+	Sync *bool `json:"sync,omitempty"`
+}
+
+type ConfigDriftDetection struct {
+	// interval is the period of the drift detection.
+	// +kubebuilder:default="10m"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// autoReconcile specifies whether to re-apply the expected values of the drifted dynamic parameters to the engine.
+	// +kubebuilder:default=false
+	// +optional
+	AutoReconcile bool `json:"autoReconcile,omitempty"`
+}
+
+// GetInterval returns the interval of the drift detection, the default is 10 minutes.
+func (d *ConfigDriftDetection) GetInterval() time.Duration {
+	if d.Interval.Duration <= 0 {
+		return 10 * time.Minute
+	}
+	return d.Interval.Duration
+}
+
 type FormatterConfig struct {
 	// The FormatterOptions represents the special options of configuration file.
 	// This is optional for now. If not specified.
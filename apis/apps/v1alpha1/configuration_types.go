@@ -145,6 +145,46 @@ type ConfigurationItemDetailStatus struct {
 	// reconcileDetail describes the details of the configuration change execution.
 	// +optional
 	ReconcileDetail *ReconcileDetail `json:"reconcileDetail,omitempty"`
+
+	// driftStatus describes the result of the last drift detection between the rendered configuration and the engine.
+	// +optional
+	DriftStatus *ConfigDriftStatus `json:"driftStatus,omitempty"`
+}
+
+type ConfigDriftStatus struct {
+	// lastCheckTime is the time of the last drift detection.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// driftedParameters lists the parameters whose running values differ from the rendered configuration.
+	// +optional
+	DriftedParameters []DriftedParameter `json:"driftedParameters,omitempty"`
+
+	// message field describes the errors of the last drift detection.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type DriftedParameter struct {
+	// pod is the name of the pod on which the parameter drifts.
+	// +kubebuilder:validation:Required
+	Pod string `json:"pod"`
+
+	// name is the parameter name.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// expected is the value in the rendered configuration.
+	// +optional
+	Expected string `json:"expected,omitempty"`
+
+	// actual is the value running in the engine.
+	// +optional
+	Actual string `json:"actual,omitempty"`
+
+	// reconciled indicates whether the expected value has been re-applied to the engine.
+	// +optional
+	Reconciled bool `json:"reconciled,omitempty"`
 }
 
 // ConfigurationStatus defines the observed state of Configuration
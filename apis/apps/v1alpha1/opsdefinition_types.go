@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package v1alpha1
 
 import (
+	"fmt"
+
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -47,15 +49,110 @@ type OpsDefinitionSpec struct {
 	// +optional
 	ParametersSchema *ParametersSchema `json:"parametersSchema,omitempty"`
 
-	// jobSpec describes the job spec for the operation.
-	// +kubebuilder:validation:Required
-	JobSpec batchv1.JobSpec `json:"jobSpec"`
+	// jobSpec describes the job spec for the operation, it is ignored if steps are specified.
+	// +optional
+	JobSpec batchv1.JobSpec `json:"jobSpec,omitempty"`
+
+	// steps describes a multi-step workflow of the operation, jobSpec is ignored if it is specified.
+	// the steps form a DAG by "dependsOn", a step starts when all the steps it depends on are succeed,
+	// and the operation is succeed when all the steps are succeed.
+	// +kubebuilder:validation:MaxItems=32
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Steps []OpsStep `json:"steps,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 
 	// preCondition if it meets the requirements to run the job for the operation.
 	// +optional
 	PreConditions []PreCondition `json:"preConditions,omitempty"`
 }
 
+// OpsStep defines a step of the operation workflow, exactly one of job, exec, waitFor and ops should be specified.
+// +kubebuilder:validation:XValidation:rule="[has(self.job), has(self.exec), has(self.waitFor), has(self.ops)].filter(x, x).size() == 1",message="exactly one of job, exec, waitFor and ops must be specified."
+type OpsStep struct {
+	// name of the step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=24
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// dependsOn specifies the names of the steps that must be succeed before the step starts.
+	// the step starts at the beginning of the operation if it is empty.
+	// +listType=set
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// job runs the jobs with the container, like jobSpec, one job is created for each params of the opsRequest.
+	// +optional
+	Job *OpsStepJob `json:"job,omitempty"`
+
+	// exec executes the statement in the database of the target component pod via lorry.
+	// +optional
+	Exec *OpsStepExec `json:"exec,omitempty"`
+
+	// waitFor waits until the expression returns "true", the message is reported while waiting.
+	// +optional
+	WaitFor *Rule `json:"waitFor,omitempty"`
+
+	// ops creates an opsRequest of the built-in operation for the component and waits for it to complete.
+	// +optional
+	Ops *OpsStepOps `json:"ops,omitempty"`
+
+	// retries specifies the number of retries before the step is marked as failed.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// timeout specifies the max duration of each attempt of the step, e.g. "10m".
+	// the attempt never times out if it is not specified.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type OpsStepJob struct {
+	// image of the job container.
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// command of the job container, the entrypoint of the image is used if it is not specified.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// env of the job container, the built-in envs, vars and params are injected as well.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// resources of the job container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type OpsStepExec struct {
+	// statement to execute, e.g. a SQL statement.
+	// +kubebuilder:validation:Required
+	Statement string `json:"statement"`
+
+	// targetPodRole specifies the role of the pod to execute the statement.
+	// if not specified, an available pod of the component will be selected.
+	// +optional
+	TargetPodRole string `json:"targetPodRole,omitempty"`
+}
+
+type OpsStepOps struct {
+	// type of the built-in operation, supported types: Restart, Switchover, Start and Stop.
+	// +kubebuilder:validation:Required
+	Type OpsType `json:"type"`
+
+	// instanceName is the candidate instance of the Switchover operation.
+	// "*" means to select a new primary automatically.
+	// +kubebuilder:default="*"
+	// +optional
+	InstanceName string `json:"instanceName,omitempty"`
+}
+
 type ComponentDefinitionRef struct {
 
 	// refer to componentDefinition name.
@@ -228,6 +325,60 @@ func init() {
 	SchemeBuilder.Register(&OpsDefinition{}, &OpsDefinitionList{})
 }
 
+// ValidateSteps validates the steps reference the existing steps and do not form a cycle.
+func (r *OpsDefinitionSpec) ValidateSteps() error {
+	if len(r.Steps) == 0 && len(r.JobSpec.Template.Spec.Containers) == 0 {
+		return fmt.Errorf("either the containers of jobSpec or steps must be specified")
+	}
+	steps := map[string]*OpsStep{}
+	for i := range r.Steps {
+		steps[r.Steps[i].Name] = &r.Steps[i]
+	}
+	for _, step := range r.Steps {
+		if step.Ops != nil {
+			switch step.Ops.Type {
+			case RestartType, SwitchoverType, StartType, StopType:
+			default:
+				return fmt.Errorf(`the ops type "%s" of step "%s" is not supported`, step.Ops.Type, step.Name)
+			}
+		}
+		for _, dep := range step.DependsOn {
+			if _, ok := steps[dep]; !ok {
+				return fmt.Errorf(`step "%s" depends on the step "%s" which does not exist`, step.Name, dep)
+			}
+		}
+	}
+	// visit the steps by depth-first search to detect the cycle.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := map[string]int{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch states[name] {
+		case visiting:
+			return fmt.Errorf(`the steps have a cycle dependency on step "%s"`, name)
+		case visited:
+			return nil
+		}
+		states[name] = visiting
+		for _, dep := range steps[name].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		states[name] = visited
+		return nil
+	}
+	for _, step := range r.Steps {
+		if err := visit(step.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o *OpsDefinition) GetComponentDefRef(compDefName string) *ComponentDefinitionRef {
 	if o == nil {
 		return nil
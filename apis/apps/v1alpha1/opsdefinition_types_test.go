@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSteps(t *testing.T) {
+	waitFor := &Rule{Expression: "true", Message: "waiting"}
+	tests := []struct {
+		name  string
+		steps []OpsStep
+		err   string
+	}{
+		{
+			name: "valid DAG",
+			steps: []OpsStep{
+				{Name: "a", WaitFor: waitFor},
+				{Name: "b", DependsOn: []string{"a"}, WaitFor: waitFor},
+				{Name: "c", DependsOn: []string{"a"}, Ops: &OpsStepOps{Type: RestartType}},
+				{Name: "d", DependsOn: []string{"b", "c"}, WaitFor: waitFor},
+			},
+		},
+		{
+			name: "dependency not found",
+			steps: []OpsStep{
+				{Name: "a", DependsOn: []string{"b"}, WaitFor: waitFor},
+			},
+			err: "does not exist",
+		},
+		{
+			name: "cycle dependency",
+			steps: []OpsStep{
+				{Name: "a", DependsOn: []string{"c"}, WaitFor: waitFor},
+				{Name: "b", DependsOn: []string{"a"}, WaitFor: waitFor},
+				{Name: "c", DependsOn: []string{"b"}, WaitFor: waitFor},
+			},
+			err: "cycle dependency",
+		},
+		{
+			name: "unsupported ops type",
+			steps: []OpsStep{
+				{Name: "a", Ops: &OpsStepOps{Type: HorizontalScalingType}},
+			},
+			err: "is not supported",
+		},
+		{
+			name: "neither jobSpec nor steps",
+			err:  "must be specified",
+		},
+	}
+	for _, tt := range tests {
+		spec := &OpsDefinitionSpec{Steps: tt.steps}
+		err := spec.ValidateSteps()
+		if tt.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
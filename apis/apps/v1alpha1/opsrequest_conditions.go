@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +44,10 @@ const (
 	ConditionTypeDataScript         = "ExecuteDataScript"
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypeMaintenanceWindow  = "WaitForMaintenanceWindow"
+	ConditionTypeRollback           = "Rollback"
+	ConditionTypeCanary             = "Canary"
+	ConditionTypeRotatePassword     = "RotatingPassword"
 
 	// condition and event reasons
 
@@ -63,6 +68,13 @@ const (
 	ReasonOpsCancelFailed          = "CancelFailed"
 	ReasonOpsCancelSucceed         = "CancelSucceed"
 	ReasonOpsCancelByController    = "CancelByController"
+	ReasonMaintenanceWindowClosed  = "MaintenanceWindowClosed"
+	ReasonMaintenanceWindowOpened  = "MaintenanceWindowOpened"
+	ReasonRollbackStarted          = "RollbackStarted"
+	ReasonRollbackSkipped          = "RollbackSkipped"
+	ReasonCanaryPaused             = "CanaryPaused"
+	ReasonCanaryPromoting          = "CanaryPromoting"
+	ReasonCanaryHalted             = "CanaryHalted"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -81,6 +93,73 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewWaitForMaintenanceWindowCondition waits for the next maintenance window to start the disruptive opsRequest.
+func NewWaitForMaintenanceWindowCondition(ops *OpsRequest, nextStartTime time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonMaintenanceWindowClosed,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("wait for the maintenance window opened at %s to start the OpsRequest: %s in Cluster: %s",
+			nextStartTime.UTC().Format(time.RFC3339), ops.Name, ops.Spec.ClusterRef),
+	}
+}
+
+// NewMaintenanceWindowOpenedCondition records that the maintenance window is opened to start the opsRequest.
+func NewMaintenanceWindowOpenedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonMaintenanceWindowOpened,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("the maintenance window is opened to start the OpsRequest: %s in Cluster: %s",
+			ops.Name, ops.Spec.ClusterRef),
+	}
+}
+
+// NewRollbackCondition records the rollback of the failed OpsRequest.
+func NewRollbackCondition(ops *OpsRequest) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               ConditionTypeRollback,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonRollbackStarted,
+		LastTransitionTime: metav1.Now(),
+	}
+	rollback := ops.Status.Rollback
+	if rollback.OpsRequestName == "" {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonRollbackSkipped
+		condition.Message = rollback.Message
+		return condition
+	}
+	condition.Message = fmt.Sprintf(`Start to roll back the OpsRequest "%s" by OpsRequest "%s" in Cluster: "%s"`,
+		ops.Name, rollback.OpsRequestName, ops.Spec.ClusterRef)
+	return condition
+}
+
+// NewCanaryCondition creates a condition for the canary update of the OpsRequest.
+func NewCanaryCondition(ops *OpsRequest) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               ConditionTypeCanary,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonCanaryPromoting,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`Canary pods are healthy, start to update the rest pods of the OpsRequest "%s" in Cluster: "%s"`,
+			ops.Name, ops.Spec.ClusterRef),
+	}
+	switch ops.Status.Canary.Phase {
+	case CanaryPausedPhase:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonCanaryPaused
+		condition.Message = fmt.Sprintf(`Canary pods are healthy, the OpsRequest "%s" is paused until spec.canary.resume is set to true`, ops.Name)
+	case CanaryHaltedPhase:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonCanaryHalted
+		condition.Message = ops.Status.Canary.Message
+	}
+	return condition
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -284,6 +363,12 @@ func NewDataScriptCondition(ops *OpsRequest) *metav1.Condition {
 	return newOpsCondition(ops, ConditionTypeDataScript, "DataScriptStarted", fmt.Sprintf("Start to execute data script in Cluster: %s", ops.Spec.ClusterRef))
 }
 
+// NewRotatePasswordCondition creates a condition that the OpsRequest rotates the passwords of the system accounts.
+func NewRotatePasswordCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeRotatePassword, "RotatePasswordStarted",
+		fmt.Sprintf("Start to rotate the passwords of the system accounts in Cluster: %s", ops.Spec.ClusterRef))
+}
+
 func newOpsCondition(ops *OpsRequest, condType, reason, message string) *metav1.Condition {
 	return &metav1.Condition{
 		Type:               condType,
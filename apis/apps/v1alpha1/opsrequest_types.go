@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +29,8 @@ import (
 
 // OpsRequestSpec defines the desired state of OpsRequest
 // +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.rollbackPolicy) ? (self.type in ['Upgrade', 'VerticalScaling']) : true",message="rollbackPolicy is only supported for the opsRequest which type in ['Upgrade','VerticalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.canary) ? (self.type in ['Restart', 'Upgrade', 'Reconfiguring']) : true",message="canary is only supported for the opsRequest which type in ['Restart','Upgrade','Reconfiguring']"
 type OpsRequestSpec struct {
 	// clusterRef references cluster object.
 	// +kubebuilder:validation:Required
@@ -47,6 +51,28 @@ type OpsRequestSpec struct {
 	// +optional
 	TTLSecondsAfterSucceed int32 `json:"ttlSecondsAfterSucceed,omitempty"`
 
+	// maintenanceWindows specifies the time ranges during which the disruptive
+	// operations, such as Restart, Upgrade, VerticalScaling, Stop and Switchover,
+	// are allowed to start. The opsRequest keeps Pending until a window opens.
+	// It overrides the maintenanceWindows of the cluster, if neither of them is
+	// specified, the operation starts immediately.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// rollbackPolicy specifies to roll back the operation automatically when it is failed,
+	// the inverse opsRequest is built from the status.lastConfiguration.
+	// only supported for Upgrade and VerticalScaling.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rollbackPolicy"
+	// +optional
+	RollbackPolicy *RollbackPolicy `json:"rollbackPolicy,omitempty"`
+
+	// canary specifies to update a few pods of each component first, the rest pods are updated
+	// after the canary pods pass the health checks and the opsRequest is resumed.
+	// only supported for Restart, Upgrade and VerticalScaling of the components with the member update strategy,
+	// and the canary replicas must be less than the replicas of the components.
+	// +optional
+	Canary *CanaryPolicy `json:"canary,omitempty"`
+
 	// upgrade specifies the cluster version by specifying clusterVersionRef.
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.upgrade"
@@ -92,6 +118,15 @@ type OpsRequestSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.switchover"
 	SwitchoverList []Switchover `json:"switchover,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// rotatePassword rotates the passwords of the system accounts of the specified components.
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rotatePassword"
+	RotatePasswordList []RotatePassword `json:"rotatePassword,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Deprecate: replace by update cluster command.
 	// Note: Quantity struct can not do immutable check by CEL.
 
@@ -176,6 +211,16 @@ type Switchover struct {
 	InstanceName string `json:"instanceName"`
 }
 
+// RotatePassword defines the variables of rotate password operation.
+type RotatePassword struct {
+	ComponentOps `json:",inline"`
+
+	// accountNames specifies the system accounts whose passwords will be rotated.
+	// If it is empty, all the system accounts of the component will be rotated.
+	// +optional
+	AccountNames []string `json:"accountNames,omitempty"`
+}
+
 // Upgrade defines the variables of upgrade operation.
 type Upgrade struct {
 	// clusterVersionRef references ClusterVersion name.
@@ -275,6 +320,7 @@ type Reconfigure struct {
 	// Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.keys) != has(self.rollbackToRevision)",message="either keys or rollbackToRevision must be specified"
 type ConfigurationItem struct {
 	// name is a config template name.
 	// +kubebuilder:validation:Required
@@ -287,13 +333,19 @@ type ConfigurationItem struct {
 	Policy *UpgradePolicy `json:"policy,omitempty"`
 
 	// keys is used to set the parameters to be updated.
-	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	// +patchMergeKey=key
 	// +patchStrategy=merge,retainKeys
 	// +listType=map
 	// +listMapKey=key
-	Keys []ParameterConfig `json:"keys" patchStrategy:"merge,retainKeys" patchMergeKey:"key"`
+	// +optional
+	Keys []ParameterConfig `json:"keys,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"key"`
+
+	// rollbackToRevision restores the configuration to the specified revision in the revision history,
+	// the revisions are recorded in the ConfigMaps labeled with config.kubeblocks.io/revision.
+	// It is mutually exclusive with keys.
+	// +optional
+	RollbackToRevision string `json:"rollbackToRevision,omitempty"`
 }
 
 type CustomOpsSpec struct {
@@ -577,6 +629,58 @@ type ScriptFrom struct {
 	SecretRef []corev1.SecretKeySelector `json:"secretRef,omitempty"`
 }
 
+// RollbackPolicy defines how to roll back the failed operation.
+type RollbackPolicy struct {
+	// healthGateTimeout specifies the max duration for the operation to succeed after it starts, e.g. "30m".
+	// if the operation is not succeed within the duration, it is considered as failed and rolled back.
+	// +optional
+	HealthGateTimeout *metav1.Duration `json:"healthGateTimeout,omitempty"`
+}
+
+// CanaryPolicy defines how to update the pods in a canary phase.
+type CanaryPolicy struct {
+	// replicas specifies the number of pods of each component to be updated in the canary phase.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas"`
+
+	// autoPromote specifies to update the rest pods once the canary pods are healthy,
+	// otherwise the opsRequest is paused until resume is set to true.
+	// +optional
+	AutoPromote bool `json:"autoPromote,omitempty"`
+
+	// resume resumes the paused opsRequest to update the rest pods.
+	// it is the only field allowed to be updated after the opsRequest is created.
+	// +optional
+	Resume bool `json:"resume,omitempty"`
+
+	// failureThreshold specifies the number of consecutive failed health checks of the updated pods,
+	// the update is halted and the opsRequest is failed when it is reached.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// MaintenanceWindow defines a weekly recurring time range in UTC.
+type MaintenanceWindow struct {
+	// daysOfWeek specifies the days of the week on which the window opens.
+	// If not specified, the window opens every day.
+	// +listType=set
+	// +optional
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+
+	// startTime specifies the time of day at which the window opens, the format
+	// is "HH:MM" and the timezone is in UTC.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// duration specifies how long the window stays open, e.g. "2h".
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+}
+
 // OpsRequestStatus defines the observed state of OpsRequest
 type OpsRequestStatus struct {
 
@@ -595,6 +699,14 @@ type OpsRequestStatus struct {
 	// +optional
 	LastConfiguration LastConfiguration `json:"lastConfiguration,omitempty"`
 
+	// rollback records the rollback of the operation if the rollbackPolicy is specified and the operation is failed.
+	// +optional
+	Rollback *OpsRequestRollbackStatus `json:"rollback,omitempty"`
+
+	// canary records the canary update of the operation if the canary is specified.
+	// +optional
+	Canary *OpsRequestCanaryStatus `json:"canary,omitempty"`
+
 	// components defines the recorded the status information of changed components for operation request.
 	// +optional
 	Components map[string]OpsRequestComponentStatus `json:"components,omitempty"`
@@ -621,6 +733,14 @@ type OpsRequestStatus struct {
 	// +optional
 	ReconfiguringStatusAsComponent map[string]*ReconfiguringStatus `json:"reconfiguringStatusAsComponent,omitempty"`
 
+	// steps records the status of the steps defined in the OpsDefinition of the custom operation.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Steps []OpsStepStatus `json:"steps,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
 	// conditions describes opsRequest detail status.
 	// +optional
 	// +patchMergeKey=type
@@ -657,6 +777,69 @@ type ProgressStatusDetail struct {
 	EndTime metav1.Time `json:"endTime,omitempty"`
 }
 
+type OpsRequestRollbackStatus struct {
+	// opsRequestName is the name of the opsRequest created to roll back the operation.
+	// it is empty if there is nothing to roll back.
+	// +optional
+	OpsRequestName string `json:"opsRequestName,omitempty"`
+
+	// message describes the rollback.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// startTimestamp is the time when the rollback is triggered.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+}
+
+type OpsRequestCanaryStatus struct {
+	// phase describes the phase of the canary update.
+	// +kubebuilder:validation:Required
+	Phase CanaryPhase `json:"phase"`
+
+	// updatedPods records the pods updated by the operation, which are health checked.
+	// +optional
+	UpdatedPods []string `json:"updatedPods,omitempty"`
+
+	// failedChecks records the number of consecutive failed health checks.
+	// +optional
+	FailedChecks int32 `json:"failedChecks,omitempty"`
+
+	// message describes the result of the last health check.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// lastTransitionTime is the time when the phase is changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+type OpsStepStatus struct {
+	// name of the step.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// status describes the state of the step.
+	// +kubebuilder:validation:Required
+	Status ProgressStatus `json:"status"`
+
+	// attempts is the number of attempts of the step.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// message is a human readable message indicating details about the step.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// startTime is the start time of the current attempt of the step.
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// endTime is the completion time of the step.
+	// +optional
+	EndTime metav1.Time `json:"endTime,omitempty"`
+}
+
 type LastComponentConfiguration struct {
 	// replicas are the last replicas of the component.
 	// +optional
@@ -848,6 +1031,15 @@ func (r OpsRequestSpec) GetSwitchoverComponentNameSet() ComponentNameSet {
 	return set
 }
 
+// GetRotatePasswordComponentNameSet gets the component name map with rotate password operation.
+func (r OpsRequestSpec) GetRotatePasswordComponentNameSet() ComponentNameSet {
+	set := make(ComponentNameSet)
+	for _, v := range r.RotatePasswordList {
+		set[v.ComponentName] = struct{}{}
+	}
+	return set
+}
+
 // GetVerticalScalingComponentNameSet gets the component name map with vertical scaling operation.
 func (r OpsRequestSpec) GetVerticalScalingComponentNameSet() ComponentNameSet {
 	set := make(ComponentNameSet)
@@ -967,6 +1159,8 @@ func (r *OpsRequest) GetComponentNameSet() ComponentNameSet {
 		return r.Spec.GetExposeComponentNameSet()
 	case SwitchoverType:
 		return r.Spec.GetSwitchoverComponentNameSet()
+	case RotatePasswordType:
+		return r.Spec.GetRotatePasswordComponentNameSet()
 	case DataScriptType:
 		return r.Spec.GetDataScriptComponentNameSet()
 	default:
@@ -978,3 +1172,60 @@ func (p *ProgressStatusDetail) SetStatusAndMessage(status ProgressStatus, messag
 	p.Message = message
 	p.Status = status
 }
+
+// GetRange returns the time range of the window that contains t, or of the
+// next window that opens after t if t is not in any window. It returns false
+// if the window is invalid.
+func (r MaintenanceWindow) GetRange(t time.Time) (time.Time, time.Time, bool) {
+	startTime, err := time.Parse("15:04", r.StartTime)
+	if err != nil || r.Duration.Duration <= 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	t = t.UTC()
+	// the windows that open in the last week may still be open.
+	for i := -7; i <= 7; i++ {
+		day := t.AddDate(0, 0, i)
+		if !r.opensOn(day.Weekday()) {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(),
+			startTime.Hour(), startTime.Minute(), 0, 0, time.UTC)
+		end := start.Add(r.Duration.Duration)
+		if start.After(t) || end.After(t) {
+			return start, end, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+func (r MaintenanceWindow) opensOn(weekday time.Weekday) bool {
+	if len(r.DaysOfWeek) == 0 {
+		return true
+	}
+	for _, d := range r.DaysOfWeek {
+		if string(d) == weekday.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMaintenanceWindowRange returns the time range of the maintenance window
+// that contains t, or of the earliest window that opens after t if t is not in
+// any window. It returns false if no valid window is found.
+func GetMaintenanceWindowRange(windows []MaintenanceWindow, t time.Time) (time.Time, time.Time, bool) {
+	var (
+		start, end time.Time
+		found      bool
+	)
+	for _, w := range windows {
+		s, e, ok := w.GetRange(t)
+		if !ok {
+			continue
+		}
+		if !found || s.Before(start) {
+			start, end, found = s, e, true
+		}
+	}
+	return start, end, found
+}
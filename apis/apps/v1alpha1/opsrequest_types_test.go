@@ -18,6 +18,9 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var componentName = "mysql"
@@ -217,3 +220,45 @@ func TestSetStatusAndMessage(t *testing.T) {
 		t.Error("set progressDetail status and message failed")
 	}
 }
+
+func TestGetMaintenanceWindowRange(t *testing.T) {
+	// Sunday 02:00-04:00
+	sunday := MaintenanceWindow{
+		DaysOfWeek: []DayOfWeek{Sunday},
+		StartTime:  "02:00",
+		Duration:   metav1.Duration{Duration: 2 * time.Hour},
+	}
+	// every day 23:00-01:00
+	daily := MaintenanceWindow{
+		StartTime: "23:00",
+		Duration:  metav1.Duration{Duration: 2 * time.Hour},
+	}
+	// 2023-11-05 is Sunday
+	date := func(day, hour, minute int) time.Time {
+		return time.Date(2023, 11, day, hour, minute, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		windows []MaintenanceWindow
+		now     time.Time
+		start   time.Time
+		end     time.Time
+	}{
+		{[]MaintenanceWindow{sunday}, date(5, 3, 0), date(5, 2, 0), date(5, 4, 0)},
+		{[]MaintenanceWindow{sunday}, date(5, 4, 0), date(12, 2, 0), date(12, 4, 0)},
+		{[]MaintenanceWindow{sunday}, date(1, 12, 0), date(5, 2, 0), date(5, 4, 0)},
+		{[]MaintenanceWindow{daily}, date(2, 0, 30), date(1, 23, 0), date(2, 1, 0)},
+		{[]MaintenanceWindow{daily}, date(2, 12, 0), date(2, 23, 0), date(3, 1, 0)},
+		{[]MaintenanceWindow{sunday, daily}, date(4, 12, 0), date(4, 23, 0), date(5, 1, 0)},
+		{[]MaintenanceWindow{sunday, daily}, date(5, 2, 30), date(5, 2, 0), date(5, 4, 0)},
+	}
+	for i, tc := range testCases {
+		start, end, ok := GetMaintenanceWindowRange(tc.windows, tc.now)
+		if !ok || !start.Equal(tc.start) || !end.Equal(tc.end) {
+			t.Errorf("case %d: expected [%s, %s), got [%s, %s)", i, tc.start, tc.end, start, end)
+		}
+	}
+
+	if _, _, ok := GetMaintenanceWindowRange([]MaintenanceWindow{{StartTime: "02:00"}}, date(5, 3, 0)); ok {
+		t.Error("expected the window without duration to be invalid")
+	}
+}
@@ -84,10 +84,13 @@ func (r *OpsRequest) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		return nil, fmt.Errorf("update OpsRequest: %s is forbidden when status.Phase is %s", r.Name, r.Status.Phase)
 	}
 
-	// Keep the cancel consistent between the two opsRequest for comparing the diff.
+	// Keep the cancel and the canary resume consistent between the two opsRequest for comparing the diff.
 	lastOpsRequest.Spec.Cancel = r.Spec.Cancel
+	if lastOpsRequest.Spec.Canary != nil && r.Spec.Canary != nil {
+		lastOpsRequest.Spec.Canary.Resume = r.Spec.Canary.Resume
+	}
 	if !reflect.DeepEqual(lastOpsRequest.Spec, r.Spec) && r.Status.Phase != "" {
-		return nil, fmt.Errorf("update OpsRequest: %s is forbidden except for cancel when status.Phase is %s", r.Name, r.Status.Phase)
+		return nil, fmt.Errorf("update OpsRequest: %s is forbidden except for cancel and canary resume when status.Phase is %s", r.Name, r.Status.Phase)
 	}
 	return nil, r.validateEntry(false)
 }
@@ -218,6 +221,8 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 		return r.validateSwitchover(ctx, k8sClient, cluster)
 	case DataScriptType:
 		return r.validateDataScript(ctx, k8sClient, cluster)
+	case RotatePasswordType:
+		return r.validateRotatePassword(cluster)
 	}
 	return nil
 }
@@ -236,6 +241,20 @@ func (r *OpsRequest) validateRestart(cluster *Cluster) error {
 	return r.checkComponentExistence(cluster, compNames)
 }
 
+// validateRotatePassword validates spec.rotatePassword
+func (r *OpsRequest) validateRotatePassword(cluster *Cluster) error {
+	rotatePasswordList := r.Spec.RotatePasswordList
+	if len(rotatePasswordList) == 0 {
+		return notEmptyError("spec.rotatePassword")
+	}
+
+	compNames := make([]string, len(rotatePasswordList))
+	for i, v := range rotatePasswordList {
+		compNames[i] = v.ComponentName
+	}
+	return r.checkComponentExistence(cluster, compNames)
+}
+
 // validateUpgrade validates spec.clusterOps.upgrade
 func (r *OpsRequest) validateUpgrade(ctx context.Context,
 	k8sClient client.Client) error {
@@ -303,7 +322,17 @@ func (r *OpsRequest) validateReconfigureParams(ctx context.Context,
 		return fmt.Errorf("component %s not found", reconfigure.ComponentName)
 	}
 	for _, configuration := range reconfigure.Configurations {
-		cmObj, err := r.getConfigMap(ctx, k8sClient, fmt.Sprintf("%s-%s-%s", r.Spec.ClusterRef, reconfigure.ComponentName, configuration.Name))
+		cmName := fmt.Sprintf("%s-%s-%s", r.Spec.ClusterRef, reconfigure.ComponentName, configuration.Name)
+		if len(configuration.RollbackToRevision) > 0 {
+			if len(configuration.Keys) > 0 {
+				return errors.Errorf("keys and rollbackToRevision of config %s cannot be specified at the same time", configuration.Name)
+			}
+			if _, err := r.getConfigMap(ctx, k8sClient, constant.GenerateConfigRevisionName(cmName, configuration.RollbackToRevision)); err != nil {
+				return errors.Wrapf(err, "revision %s of config %s not found", configuration.RollbackToRevision, configuration.Name)
+			}
+			continue
+		}
+		cmObj, err := r.getConfigMap(ctx, k8sClient, cmName)
 		if err != nil {
 			return err
 		}
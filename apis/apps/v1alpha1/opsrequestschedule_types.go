@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsRequestScheduleSpec defines the desired state of OpsRequestSchedule.
+type OpsRequestScheduleSpec struct {
+	// schedule specifies when to create the opsRequest in the Cron format, e.g.
+	// "0 2 * * 0", the timezone is in UTC. The created opsRequest still waits
+	// for the maintenance windows of the template or the cluster to start.
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// suspend specifies whether to suspend creating the opsRequests, the
+	// opsRequests that have been created are not affected.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// historyLimit specifies the number of the finished opsRequests to keep,
+	// the older finished opsRequests created by the schedule are deleted.
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// opsRequestTemplate specifies the spec of the opsRequest to create.
+	// +kubebuilder:validation:Required
+	OpsRequestTemplate OpsRequestSpec `json:"opsRequestTemplate"`
+}
+
+// OpsRequestScheduleStatus defines the observed state of OpsRequestSchedule.
+type OpsRequestScheduleStatus struct {
+	// observedGeneration is the most recent generation observed for this
+	// OpsRequestSchedule.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// lastScheduleTime records the last time the opsRequest was created.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// lastOpsRequestName records the name of the last created opsRequest.
+	// +optional
+	LastOpsRequestName string `json:"lastOpsRequestName,omitempty"`
+
+	// nextScheduleTime records the next time the opsRequest will be created.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=opss
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.opsRequestTemplate.type",description="Operation request type."
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.opsRequestTemplate.clusterRef",description="Operand cluster."
+// +kubebuilder:printcolumn:name="SUSPEND",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="LAST-SCHEDULE",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsRequestSchedule is the Schema for the opsrequestschedules API, it
+// periodically creates OpsRequests from the template.
+type OpsRequestSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsRequestScheduleSpec   `json:"spec,omitempty"`
+	Status OpsRequestScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsRequestScheduleList contains a list of OpsRequestSchedule.
+type OpsRequestScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsRequestSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsRequestSchedule{}, &OpsRequestScheduleList{})
+}
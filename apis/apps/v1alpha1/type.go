@@ -19,6 +19,7 @@ package v1alpha1
 
 import (
 	"errors"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -214,6 +215,24 @@ const (
 	OpsFailedPhase     OpsPhase = "Failed"
 )
 
+// CanaryPhase defines the phase of the canary update of opsRequest.
+// +enum
+// +kubebuilder:validation:Enum={Progressing,Paused,Promoting,Halted,Completed}
+type CanaryPhase string
+
+const (
+	// CanaryProgressingPhase the canary pods are being updated and health checked.
+	CanaryProgressingPhase CanaryPhase = "Progressing"
+	// CanaryPausedPhase the canary pods are healthy, waiting for the opsRequest to be resumed.
+	CanaryPausedPhase CanaryPhase = "Paused"
+	// CanaryPromotingPhase the rest pods are being updated.
+	CanaryPromotingPhase CanaryPhase = "Promoting"
+	// CanaryHaltedPhase the health checks of the updated pods are failed, and the update is halted.
+	CanaryHaltedPhase CanaryPhase = "Halted"
+	// CanaryCompletedPhase all pods are updated.
+	CanaryCompletedPhase CanaryPhase = "Completed"
+)
+
 // PodSelectionStrategy pod selection strategy.
 // +enum
 // +kubebuilder:validation:Enum={Available,PreferredAvailable}
@@ -226,7 +245,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,RotatePassword,Custom}
 type OpsType string
 
 const (
@@ -243,7 +262,23 @@ const (
 	DataScriptType        OpsType = "DataScript" // DataScriptType the data script operation will execute the data script against the cluster.
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
-	CustomType            OpsType = "Custom" // use opsDefinition
+	RotatePasswordType    OpsType = "RotatePassword" // RotatePasswordType the rotate password operation will rotate the passwords of the system accounts.
+	CustomType            OpsType = "Custom"         // use opsDefinition
+)
+
+// DayOfWeek defines the day of the week.
+// +enum
+// +kubebuilder:validation:Enum={Sunday,Monday,Tuesday,Wednesday,Thursday,Friday,Saturday}
+type DayOfWeek string
+
+const (
+	Sunday    DayOfWeek = "Sunday"
+	Monday    DayOfWeek = "Monday"
+	Tuesday   DayOfWeek = "Tuesday"
+	Wednesday DayOfWeek = "Wednesday"
+	Thursday  DayOfWeek = "Thursday"
+	Friday    DayOfWeek = "Friday"
+	Saturday  DayOfWeek = "Saturday"
 )
 
 // ComponentResourceKey defines the resource key of component, such as pod/pvc.
@@ -550,6 +585,13 @@ const (
 	IssuerUserProvided IssuerName = "UserProvided"
 )
 
+const (
+	// MaxTLSCertificateDuration is the maximum lifetime of the certificates issued by KubeBlocks, it is also the default.
+	MaxTLSCertificateDuration = 90 * 24 * time.Hour
+	// DefaultTLSRenewBefore is the default duration before the expiry to renew the certificates.
+	DefaultTLSRenewBefore = 30 * 24 * time.Hour
+)
+
 // SwitchPolicyType defines switchPolicy type.
 // Currently, only Noop is supported. MaximumAvailability and MaximumDataProtection will be supported in the future.
 // +enum
@@ -352,6 +352,21 @@ func (in *CPUConstraint) DeepCopy() *CPUConstraint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryPolicy) DeepCopyInto(out *CanaryPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryPolicy.
+func (in *CanaryPolicy) DeepCopy() *CanaryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassDefRef) DeepCopyInto(out *ClassDefRef) {
 	*out = *in
@@ -736,6 +751,11 @@ func (in *ClusterComponentVersion) DeepCopy() *ClusterComponentVersion {
 func (in *ClusterComponentVolumeClaimTemplate) DeepCopyInto(out *ClusterComponentVolumeClaimTemplate) {
 	*out = *in
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.AutoExpansion != nil {
+		in, out := &in.AutoExpansion, &out.AutoExpansion
+		*out = new(VolumeAutoExpansion)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentVolumeClaimTemplate.
@@ -1097,6 +1117,13 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
@@ -2210,6 +2237,18 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.TLSCertificate != nil {
+		in, out := &in.TLSCertificate, &out.TLSCertificate
+		*out = new(TLSCertificateStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LeaderHistory != nil {
+		in, out := &in.LeaderHistory, &out.LeaderHistory
+		*out = make([]workloadsv1alpha1.LeaderChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -2369,6 +2408,11 @@ func (in *ConfigConstraintSpec) DeepCopyInto(out *ConfigConstraintSpec) {
 		*out = new(ReloadOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLSReloadOptions != nil {
+		in, out := &in.TLSReloadOptions, &out.TLSReloadOptions
+		*out = new(TLSReloadOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ToolsImageSpec != nil {
 		in, out := &in.ToolsImageSpec, &out.ToolsImageSpec
 		*out = new(ToolsImageSpec)
@@ -2416,6 +2460,11 @@ func (in *ConfigConstraintSpec) DeepCopyInto(out *ConfigConstraintSpec) {
 		*out = new(FormatterConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(ConfigDriftDetection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConstraintSpec.
@@ -2443,6 +2492,46 @@ func (in *ConfigConstraintStatus) DeepCopy() *ConfigConstraintStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDriftDetection) DeepCopyInto(out *ConfigDriftDetection) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDriftDetection.
+func (in *ConfigDriftDetection) DeepCopy() *ConfigDriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDriftStatus) DeepCopyInto(out *ConfigDriftStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DriftedParameters != nil {
+		in, out := &in.DriftedParameters, &out.DriftedParameters
+		*out = make([]DriftedParameter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDriftStatus.
+func (in *ConfigDriftStatus) DeepCopy() *ConfigDriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
 	*out = *in
@@ -2610,6 +2699,11 @@ func (in *ConfigurationItemDetailStatus) DeepCopyInto(out *ConfigurationItemDeta
 		*out = new(ReconcileDetail)
 		**out = **in
 	}
+	if in.DriftStatus != nil {
+		in, out := &in.DriftStatus, &out.DriftStatus
+		*out = new(ConfigDriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationItemDetailStatus.
@@ -2995,6 +3089,21 @@ func (in *DownwardAPIOption) DeepCopy() *DownwardAPIOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftedParameter) DeepCopyInto(out *DriftedParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftedParameter.
+func (in *DriftedParameter) DeepCopy() *DriftedParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftedParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvMappingVar) DeepCopyInto(out *EnvMappingVar) {
 	*out = *in
@@ -3189,6 +3298,33 @@ func (in *HTTPAction) DeepCopy() *HTTPAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTrigger) DeepCopyInto(out *HTTPTrigger) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTrigger.
+func (in *HTTPTrigger) DeepCopy() *HTTPTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizontalScalePolicy) DeepCopyInto(out *HorizontalScalePolicy) {
 	*out = *in
@@ -3253,6 +3389,16 @@ func (in *Issuer) DeepCopyInto(out *Issuer) {
 		*out = new(TLSSecretRef)
 		**out = **in
 	}
+	if in.CertificateDuration != nil {
+		in, out := &in.CertificateDuration, &out.CertificateDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Issuer.
@@ -3398,6 +3544,27 @@ func (in *LogConfig) DeepCopy() *LogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]DayOfWeek, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemoryConstraint) DeepCopyInto(out *MemoryConstraint) {
 	*out = *in
@@ -3548,6 +3715,13 @@ func (in *OpsDefinitionSpec) DeepCopyInto(out *OpsDefinitionSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.JobSpec.DeepCopyInto(&out.JobSpec)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]OpsStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PreConditions != nil {
 		in, out := &in.PreConditions, &out.PreConditions
 		*out = make([]PreCondition, len(*in))
@@ -3664,6 +3838,27 @@ func (in *OpsRequestBehaviour) DeepCopy() *OpsRequestBehaviour {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestCanaryStatus) DeepCopyInto(out *OpsRequestCanaryStatus) {
+	*out = *in
+	if in.UpdatedPods != nil {
+		in, out := &in.UpdatedPods, &out.UpdatedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestCanaryStatus.
+func (in *OpsRequestCanaryStatus) DeepCopy() *OpsRequestCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestComponentStatus) DeepCopyInto(out *OpsRequestComponentStatus) {
 	*out = *in
@@ -3719,9 +3914,145 @@ func (in *OpsRequestList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestRollbackStatus) DeepCopyInto(out *OpsRequestRollbackStatus) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestRollbackStatus.
+func (in *OpsRequestRollbackStatus) DeepCopy() *OpsRequestRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestSchedule) DeepCopyInto(out *OpsRequestSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestSchedule.
+func (in *OpsRequestSchedule) DeepCopy() *OpsRequestSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsRequestSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestScheduleList) DeepCopyInto(out *OpsRequestScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsRequestSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestScheduleList.
+func (in *OpsRequestScheduleList) DeepCopy() *OpsRequestScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsRequestScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestScheduleSpec) DeepCopyInto(out *OpsRequestScheduleSpec) {
+	*out = *in
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.OpsRequestTemplate.DeepCopyInto(&out.OpsRequestTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestScheduleSpec.
+func (in *OpsRequestScheduleSpec) DeepCopy() *OpsRequestScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestScheduleStatus) DeepCopyInto(out *OpsRequestScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestScheduleStatus.
+func (in *OpsRequestScheduleStatus) DeepCopy() *OpsRequestScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(RollbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryPolicy)
+		**out = **in
+	}
 	if in.Upgrade != nil {
 		in, out := &in.Upgrade, &out.Upgrade
 		*out = new(Upgrade)
@@ -3751,6 +4082,13 @@ func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 		*out = make([]Switchover, len(*in))
 		copy(*out, *in)
 	}
+	if in.RotatePasswordList != nil {
+		in, out := &in.RotatePasswordList, &out.RotatePasswordList
+		*out = make([]RotatePassword, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.VerticalScalingList != nil {
 		in, out := &in.VerticalScalingList, &out.VerticalScalingList
 		*out = make([]VerticalScaling, len(*in))
@@ -3823,6 +4161,16 @@ func (in *OpsRequestSpec) DeepCopy() *OpsRequestSpec {
 func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 	*out = *in
 	in.LastConfiguration.DeepCopyInto(&out.LastConfiguration)
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(OpsRequestRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(OpsRequestCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Components != nil {
 		in, out := &in.Components, &out.Components
 		*out = make(map[string]OpsRequestComponentStatus, len(*in))
@@ -3853,6 +4201,13 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]OpsStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -3924,6 +4279,126 @@ func (in *OpsService) DeepCopy() *OpsService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsStep) DeepCopyInto(out *OpsStep) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(OpsStepJob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(OpsStepExec)
+		**out = **in
+	}
+	if in.WaitFor != nil {
+		in, out := &in.WaitFor, &out.WaitFor
+		*out = new(Rule)
+		**out = **in
+	}
+	if in.Ops != nil {
+		in, out := &in.Ops, &out.Ops
+		*out = new(OpsStepOps)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsStep.
+func (in *OpsStep) DeepCopy() *OpsStep {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsStepExec) DeepCopyInto(out *OpsStepExec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsStepExec.
+func (in *OpsStepExec) DeepCopy() *OpsStepExec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsStepExec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsStepJob) DeepCopyInto(out *OpsStepJob) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsStepJob.
+func (in *OpsStepJob) DeepCopy() *OpsStepJob {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsStepJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsStepOps) DeepCopyInto(out *OpsStepOps) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsStepOps.
+func (in *OpsStepOps) DeepCopy() *OpsStepOps {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsStepOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsStepStatus) DeepCopyInto(out *OpsStepStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsStepStatus.
+func (in *OpsStepStatus) DeepCopy() *OpsStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsVarSource) DeepCopyInto(out *OpsVarSource) {
 	*out = *in
@@ -4020,6 +4495,31 @@ func (in *PasswordConfig) DeepCopy() *PasswordConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationPolicy) DeepCopyInto(out *PasswordRotationPolicy) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationPolicy.
+func (in *PasswordRotationPolicy) DeepCopy() *PasswordRotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Payload.
 func (in *Payload) DeepCopy() *Payload {
 	if in == nil {
@@ -4391,6 +4891,16 @@ func (in *ReloadOptions) DeepCopyInto(out *ReloadOptions) {
 		*out = new(AutoTrigger)
 		**out = **in
 	}
+	if in.HTTPTrigger != nil {
+		in, out := &in.HTTPTrigger, &out.HTTPTrigger
+		*out = new(HTTPTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQLTrigger != nil {
+		in, out := &in.SQLTrigger, &out.SQLTrigger
+		*out = new(SQLTrigger)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadOptions.
@@ -4563,6 +5073,47 @@ func (in *RoleProbe) DeepCopy() *RoleProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackPolicy) DeepCopyInto(out *RollbackPolicy) {
+	*out = *in
+	if in.HealthGateTimeout != nil {
+		in, out := &in.HealthGateTimeout, &out.HealthGateTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackPolicy.
+func (in *RollbackPolicy) DeepCopy() *RollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotatePassword) DeepCopyInto(out *RotatePassword) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.AccountNames != nil {
+		in, out := &in.AccountNames, &out.AccountNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotatePassword.
+func (in *RotatePassword) DeepCopy() *RotatePassword {
+	if in == nil {
+		return nil
+	}
+	out := new(RotatePassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
@@ -4578,6 +5129,26 @@ func (in *Rule) DeepCopy() *Rule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLTrigger) DeepCopyInto(out *SQLTrigger) {
+	*out = *in
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLTrigger.
+func (in *SQLTrigger) DeepCopy() *SQLTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulePolicy) DeepCopyInto(out *SchedulePolicy) {
 	*out = *in
@@ -5216,6 +5787,11 @@ func (in *SystemAccount) DeepCopyInto(out *SystemAccount) {
 		*out = new(ProvisionSecretRef)
 		**out = **in
 	}
+	if in.PasswordRotationPolicy != nil {
+		in, out := &in.PasswordRotationPolicy, &out.PasswordRotationPolicy
+		*out = new(PasswordRotationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemAccount.
@@ -5292,6 +5868,33 @@ func (in *SystemAccountSpec) DeepCopy() *SystemAccountSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSCertificateStatus) DeepCopyInto(out *TLSCertificateStatus) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSCertificateStatus.
+func (in *TLSCertificateStatus) DeepCopy() *TLSCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -5312,6 +5915,31 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSReloadOptions) DeepCopyInto(out *TLSReloadOptions) {
+	*out = *in
+	if in.UnixSignalTrigger != nil {
+		in, out := &in.UnixSignalTrigger, &out.UnixSignalTrigger
+		*out = new(UnixSignalTrigger)
+		**out = **in
+	}
+	if in.ShellTrigger != nil {
+		in, out := &in.ShellTrigger, &out.ShellTrigger
+		*out = new(ShellTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSReloadOptions.
+func (in *TLSReloadOptions) DeepCopy() *TLSReloadOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSReloadOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSSecretRef) DeepCopyInto(out *TLSSecretRef) {
 	*out = *in
@@ -5663,6 +6291,23 @@ func (in *VerticalScaling) DeepCopy() *VerticalScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoExpansion) DeepCopyInto(out *VolumeAutoExpansion) {
+	*out = *in
+	out.MaxSize = in.MaxSize.DeepCopy()
+	out.Step = in.Step.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoExpansion.
+func (in *VolumeAutoExpansion) DeepCopy() *VolumeAutoExpansion {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoExpansion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeExpansion) DeepCopyInto(out *VolumeExpansion) {
 	*out = *in
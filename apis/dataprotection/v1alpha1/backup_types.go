@@ -141,6 +141,23 @@ type BackupStatus struct {
 	// +optional
 	BackupMethod *BackupMethod `json:"backupMethod,omitempty"`
 
+	// parentBackupName records the name of the parent backup that this backup
+	// is based on. For incremental or differential backups, it is resolved from
+	// spec.parentBackupName, or selected automatically by the controller if not
+	// specified. Empty means the backup is the base of a backup chain.
+	// +optional
+	ParentBackupName string `json:"parentBackupName,omitempty"`
+
+	// baseBackupName records the name of the base backup of the backup chain
+	// that this backup belongs to.
+	// +optional
+	BaseBackupName string `json:"baseBackupName,omitempty"`
+
+	// encryption records the encryption of the backup data, the key ID is used
+	// to find the key to decrypt the backup data when restoring.
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+
 	// actions records the actions information for this backup.
 	// +optional
 	Actions []ActionStatus `json:"actions,omitempty"`
@@ -151,6 +168,74 @@ type BackupStatus struct {
 
 	// extra records the extra info for the backup.
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// conditions describe the current state of the backup, such as the
+	// retention decision made by the garbage collector.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// verification records the status of the latest verification of the
+	// backup, which restores the backup into a sandbox and checks the
+	// restored data.
+	// +optional
+	Verification *BackupVerificationStatus `json:"verification,omitempty"`
+
+	// replicas records the replica backups of this backup, which are created
+	// by the replications of the backup policy.
+	// +optional
+	Replicas []BackupReplica `json:"replicas,omitempty"`
+}
+
+// BackupReplica records a replica backup in another backup repo.
+type BackupReplica struct {
+	// replicationName is the name of the replication that creates the replica.
+	ReplicationName string `json:"replicationName"`
+
+	// backupName is the name of the replica backup.
+	BackupName string `json:"backupName"`
+
+	// backupRepoName is the name of BackupRepo that the replica is stored in.
+	BackupRepoName string `json:"backupRepoName"`
+}
+
+// BackupEncryption records the encryption of the backup data.
+type BackupEncryption struct {
+	// algorithm is the algorithm used to encrypt the backup data.
+	// +optional
+	Algorithm EncryptionAlgorithm `json:"algorithm,omitempty"`
+
+	// keyID is the ID of the key used to encrypt the backup data.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// BackupVerificationStatus records the status of a backup verification.
+type BackupVerificationStatus struct {
+	// phase is the current state of the verification.
+	// +optional
+	Phase BackupVerificationPhase `json:"phase,omitempty"`
+
+	// requestID identifies the verification request, it is the value of the
+	// verification request annotation of the backup.
+	// +optional
+	RequestID string `json:"requestID,omitempty"`
+
+	// restoreName is the name of the restore that restores the backup into
+	// the sandbox.
+	// +optional
+	RestoreName string `json:"restoreName,omitempty"`
+
+	// startTimestamp records the time the verification was started.
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// completionTimestamp records the time the verification was completed.
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// failureReason is an error that caused the verification to fail.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
 }
 
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
@@ -182,7 +267,7 @@ const (
 
 // BackupPhase is a string representation of the lifecycle phase of a Backup.
 // +enum
-// +kubebuilder:validation:Enum={New,InProgress,Running,Completed,Failed,Deleting}
+// +kubebuilder:validation:Enum={New,Pending,InProgress,Running,Completed,Failed,Deleting}
 type BackupPhase string
 
 const (
@@ -190,6 +275,11 @@ const (
 	// the BackupController.
 	BackupPhaseNew BackupPhase = "New"
 
+	// BackupPhasePending means the backup is queued because the max concurrent
+	// backups of the backup repo is reached, the reason is recorded in the
+	// conditions of the backup.
+	BackupPhasePending BackupPhase = "Pending"
+
 	// BackupPhaseRunning means the backup is currently executing.
 	BackupPhaseRunning BackupPhase = "Running"
 
@@ -204,6 +294,25 @@ const (
 	BackupPhaseDeleting BackupPhase = "Deleting"
 )
 
+// BackupVerificationPhase is the phase of a backup verification.
+// +enum
+// +kubebuilder:validation:Enum={Running,Passed,Failed}
+type BackupVerificationPhase string
+
+const (
+	// BackupVerificationPhaseRunning means the backup is being restored into
+	// the sandbox or the check action is being executed.
+	BackupVerificationPhaseRunning BackupVerificationPhase = "Running"
+
+	// BackupVerificationPhasePassed means the backup has been restored and the
+	// check action has passed.
+	BackupVerificationPhasePassed BackupVerificationPhase = "Passed"
+
+	// BackupVerificationPhaseFailed means the backup failed to be restored or
+	// the check action failed.
+	BackupVerificationPhaseFailed BackupVerificationPhase = "Failed"
+)
+
 type ActionStatus struct {
 	// name is the name of the action.
 	Name string `json:"name,omitempty"`
@@ -59,6 +59,45 @@ type BackupPolicySpec struct {
 	// +optional
 	// +kubebuilder:default=false
 	UseKopia bool `json:"useKopia"`
+
+	// replications specifies the backup repos that the completed backups are
+	// replicated to. For each replication, a linked backup object is created
+	// for the completed backup, and the backup data is copied to the backup
+	// repo of the replication. The linked backup can be used to restore the
+	// cluster, and it is deleted according to its own retention period.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	Replications []BackupReplication `json:"replications,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// BackupReplication defines how to replicate the backups to another backup repo.
+type BackupReplication struct {
+	// name is the name of the replication, it is used as the suffix of the
+	// replica backup name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=16
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// backupRepoName is the name of BackupRepo that the backups are replicated to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	BackupRepoName string `json:"backupRepoName"`
+
+	// backupMethods specifies the names of the backup methods whose backups
+	// are replicated. If not set, the backups of all backup methods that
+	// store data in the backup repo are replicated.
+	// +optional
+	BackupMethods []string `json:"backupMethods,omitempty"`
+
+	// retentionPeriod determines a duration up to which the replica backup
+	// should be kept. If not set, the retention period of the source backup
+	// is used. The format is the same as the retention period of the backup.
+	// +optional
+	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
 }
 
 type BackupTarget struct {
@@ -63,6 +63,70 @@ type BackupRepoSpec struct {
 	// A secret that contains the credentials needed by the storage provider.
 	// +optional
 	Credential *corev1.SecretReference `json:"credential,omitempty"`
+
+	// Specifies the client-side encryption of the backup data. If set, the
+	// backup data is encrypted by the backup jobs before it is written into
+	// the storage, and decrypted by the restore jobs.
+	// +optional
+	Encryption *BackupRepoEncryption `json:"encryption,omitempty"`
+
+	// Specifies the throttling of the backup workloads that access this backup repo.
+	// +optional
+	Throttling *BackupRepoThrottling `json:"throttling,omitempty"`
+}
+
+// BackupRepoThrottling defines the throttling of the backup workloads that
+// access the backup repo.
+type BackupRepoThrottling struct {
+	// Specifies the max number of backups that run concurrently in this backup
+	// repo. The backups exceeding the limit are queued in the Pending phase, and
+	// started in the order of their creation time.
+	// If not set, the number of concurrent backups is unlimited.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentBackups *int32 `json:"maxConcurrentBackups,omitempty"`
+
+	// Specifies the max bandwidth in bytes per second to upload data into this
+	// backup repo, it is applied to each backup workload, e.g. "100Mi".
+	// +optional
+	MaxUploadBandwidth *resource.Quantity `json:"maxUploadBandwidth,omitempty"`
+
+	// Specifies the max bandwidth in bytes per second to download data from
+	// this backup repo, it is applied to each restore workload, e.g. "100Mi".
+	// +optional
+	MaxDownloadBandwidth *resource.Quantity `json:"maxDownloadBandwidth,omitempty"`
+}
+
+// EncryptionAlgorithm is the algorithm used to encrypt the backup data.
+// +enum
+// +kubebuilder:validation:Enum={AES-128-CFB,AES-192-CFB,AES-256-CFB}
+type EncryptionAlgorithm string
+
+const (
+	EncryptionAlgorithmAES128CFB EncryptionAlgorithm = "AES-128-CFB"
+	EncryptionAlgorithmAES192CFB EncryptionAlgorithm = "AES-192-CFB"
+	EncryptionAlgorithmAES256CFB EncryptionAlgorithm = "AES-256-CFB"
+)
+
+// BackupRepoEncryption defines the client-side encryption of the backup repo.
+type BackupRepoEncryption struct {
+	// Specifies the encryption algorithm.
+	// +kubebuilder:default=AES-256-CFB
+	// +optional
+	Algorithm EncryptionAlgorithm `json:"algorithm,omitempty"`
+
+	// A secret that contains the encryption keys. Each key of the secret data
+	// is a key ID, and the value is the encryption key. The keys used by the
+	// existing backups must be kept in the secret, otherwise these backups
+	// can not be restored.
+	// +kubebuilder:validation:Required
+	KeySecretRef corev1.SecretReference `json:"keySecretRef"`
+
+	// Specifies the ID of the key used to encrypt new backups. To rotate the
+	// key, add a new key to the secret and update this field, the existing
+	// backups are still decrypted by the keys recorded in their status.
+	// +kubebuilder:validation:Required
+	CurrentKeyID string `json:"currentKeyID"`
 }
 
 // BackupRepoStatus defines the observed state of BackupRepo
@@ -64,9 +64,117 @@ type SchedulePolicy struct {
 	// - hours: 	12h
 	// - minutes: 	30m
 	// You can also combine the above durations. For example: 30d12h30m
+	// It is ignored if retentionPolicy is set.
 	// +optional
 	// +kubebuilder:default="7d"
 	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
+
+	// retentionPolicy determines which backups should be kept by the count of
+	// the latest backups and the backups of each day, week, month and year.
+	// If set, the backups created by this schedule are not deleted by the
+	// retentionPeriod, but by the retention policy instead.
+	// +optional
+	RetentionPolicy *BackupRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// maxChainLength specifies the max number of backups in a backup chain,
+	// including the base backup. It only works for incremental or differential
+	// backup methods. The scheduled backup automatically selects the latest
+	// completed backup as its parent, and when the chain of the parent reaches
+	// this length, a new chain is started by taking the backup without a parent,
+	// that means a full backup is taken every maxChainLength backups.
+	// If not set, the length of the backup chain is unlimited.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxChainLength *int32 `json:"maxChainLength,omitempty"`
+
+	// verification specifies how to periodically verify that the backups
+	// created by this schedule are restorable.
+	// +optional
+	Verification *BackupVerification `json:"verification,omitempty"`
+}
+
+// BackupRetentionPolicy defines which backups are kept, in the manner of the
+// grandfather-father-son backup rotation. The policy is evaluated by the
+// garbage collection across all completed backups of the backup policy and
+// backup method, which are not replicated and will not expire by their
+// retention period. The backups are sorted by their completion time, the
+// latest backup of each day, week, month and year is taken as the backup of
+// the period, and a backup is kept if it is selected by any of the rules.
+// The time is in UTC.
+type BackupRetentionPolicy struct {
+	// keepLast specifies the number of the latest backups to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// keepDaily specifies the number of the latest days to keep a backup for each.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// keepWeekly specifies the number of the latest weeks to keep a backup for each.
+	// The week is the ISO 8601 week, which starts on Monday.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+
+	// keepMonthly specifies the number of the latest months to keep a backup for each.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepMonthly *int32 `json:"keepMonthly,omitempty"`
+
+	// keepYearly specifies the number of the latest years to keep a backup for each.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepYearly *int32 `json:"keepYearly,omitempty"`
+
+	// dryRun specifies whether to only report the backups that are not kept
+	// by the retention policy in `status.schedules[backupMethod].backupsToDelete`
+	// of the BackupSchedule rather than deleting them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// BackupVerification defines how to verify the backups are restorable. The
+// controller periodically picks the latest completed backup, restores it into
+// throwaway persistent volume claims, executes the check action against the
+// restored data and records the result in the backup status. The throwaway
+// persistent volume claims are deleted after the verification.
+// +kubebuilder:validation:XValidation:rule="!has(self.check.exec) || has(self.sandboxImage)",message="sandboxImage is required when check.exec is set."
+type BackupVerification struct {
+	// enabled specifies whether the backup verification is enabled or not.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// the cron expression for verification schedule, the timezone is in UTC.
+	// see https://en.wikipedia.org/wiki/Cron.
+	// +kubebuilder:validation:Required
+	CronExpression string `json:"cronExpression"`
+
+	// volumeClaims defines the throwaway persistent volume claims that the
+	// backup is restored into, the name of the created claim is
+	// "<verification-name>-<claim-name>".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	VolumeClaims []RestoreVolumeClaim `json:"volumeClaims"`
+
+	// sandboxImage specifies the image of the sandbox pod that mounts the
+	// restored volumes, the check action is executed in this pod by the pod
+	// exec API. It is required if check.exec is set, and the image must
+	// contain the shell to keep the pod alive.
+	// +optional
+	SandboxImage string `json:"sandboxImage,omitempty"`
+
+	// timeout specifies the max duration of the verification, including
+	// restoring the backup and checking the restored data. The verification
+	// fails if it is not finished in time. Defaults to 2h.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// check specifies the action to check the restored data. If check.job is
+	// set, the job mounts the restored volumes at their mount paths.
+	// +kubebuilder:validation:Required
+	Check ActionSpec `json:"check"`
 }
 
 // BackupScheduleStatus defines the observed state of BackupSchedule.
@@ -118,6 +226,11 @@ type ScheduleStatus struct {
 	// lastSuccessfulTime records the last time the backup was successfully completed.
 	// +optional
 	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// backupsToDelete lists the backups that are not kept by the retention
+	// policy in dry-run mode, they will be deleted once dry-run is disabled.
+	// +optional
+	BackupsToDelete []string `json:"backupsToDelete,omitempty"`
 }
 
 // SchedulePhase defines the phase of schedule
@@ -193,6 +193,10 @@ type ReplicatedStateMachineStatus struct {
 	// members' status.
 	// +optional
 	MembersStatus []MemberStatus `json:"membersStatus,omitempty"`
+
+	// LeaderHistory records the latest leader changes, the oldest first.
+	// +optional
+	LeaderHistory []LeaderChange `json:"leaderHistory,omitempty"`
 }
 
 // +genclient
@@ -431,6 +435,49 @@ type MemberStatus struct {
 	ReadyWithoutPrimary bool `json:"readyWithoutPrimary"`
 }
 
+// LeaderChangeReason defines the reason of a leader change.
+// +enum
+// +kubebuilder:validation:Enum={Failover,Switchover,Manual}
+type LeaderChangeReason string
+
+const (
+	// FailoverLeaderChangeReason means the previous leader became unavailable.
+	FailoverLeaderChangeReason LeaderChangeReason = "Failover"
+	// SwitchoverLeaderChangeReason means the leader was switched over by the system, e.g. before updating the leader pod.
+	SwitchoverLeaderChangeReason LeaderChangeReason = "Switchover"
+	// ManualLeaderChangeReason means the leader was switched over on the request of a user, e.g. a Switchover OpsRequest.
+	ManualLeaderChangeReason LeaderChangeReason = "Manual"
+)
+
+// LeaderChange records a change of the leader.
+type LeaderChange struct {
+	// OldLeader is the pod name of the previous leader, it is empty if there was no leader.
+	// +optional
+	OldLeader string `json:"oldLeader,omitempty"`
+
+	// NewLeader is the pod name of the new leader.
+	// +kubebuilder:validation:Required
+	NewLeader string `json:"newLeader"`
+
+	// Reason is the reason of the leader change.
+	// +kubebuilder:validation:Required
+	Reason LeaderChangeReason `json:"reason"`
+
+	// Time is the time when the new leader was observed.
+	// +kubebuilder:validation:Required
+	Time metav1.Time `json:"time"`
+
+	// ReplicationLag is the replication lag of the new leader behind the previous leader when it was promoted,
+	// the unit depends on the database engine. It is reported by the role probe if available.
+	// +optional
+	ReplicationLag *int64 `json:"replicationLag,omitempty"`
+
+	// UnavailableDuration is how long the component had no available leader during the change.
+	// It is reported by the role probe if available.
+	// +optional
+	UnavailableDuration *metav1.Duration `json:"unavailableDuration,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&ReplicatedStateMachine{}, &ReplicatedStateMachineList{})
 }
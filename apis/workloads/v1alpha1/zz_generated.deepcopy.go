@@ -92,6 +92,32 @@ func (in *CredentialVar) DeepCopy() *CredentialVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderChange) DeepCopyInto(out *LeaderChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.ReplicationLag != nil {
+		in, out := &in.ReplicationLag, &out.ReplicationLag
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UnavailableDuration != nil {
+		in, out := &in.UnavailableDuration, &out.UnavailableDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderChange.
+func (in *LeaderChange) DeepCopy() *LeaderChange {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemberStatus) DeepCopyInto(out *MemberStatus) {
 	*out = *in
@@ -338,6 +364,13 @@ func (in *ReplicatedStateMachineStatus) DeepCopyInto(out *ReplicatedStateMachine
 		*out = make([]MemberStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.LeaderHistory != nil {
+		in, out := &in.LeaderHistory, &out.LeaderHistory
+		*out = make([]LeaderChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicatedStateMachineStatus.
@@ -26,6 +26,7 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
@@ -52,6 +53,16 @@ func init() {
 	viper.AutomaticEnv()
 	pflag.StringVar(&configDir, "config-path", "/config/lorry/components/", "Lorry default config directory for builtin type")
 	pflag.BoolVar(&disableDNSChecker, "disable-dns-checker", false, "disable dns checker, for test&dev")
+	pflag.String(dcs.TypeFlag, dcs.KubernetesStoreType, "The DCS type to store the HA states, one of kubernetes, lease and etcd")
+	pflag.StringSlice(dcs.EtcdEndpointsFlag, nil, "The etcd endpoints for etcd DCS")
+	pflag.String(dcs.EtcdPrefixFlag, "/kubeblocks", "The key prefix for etcd DCS")
+	pflag.String(dcs.EtcdCAFileFlag, "", "The CA file to verify the etcd server certificates for etcd DCS")
+	pflag.String(dcs.EtcdCertFileFlag, "", "The client certificate file for etcd DCS")
+	pflag.String(dcs.EtcdKeyFileFlag, "", "The client private key file for etcd DCS")
+	pflag.String(highavailability.FencingActionFlag, highavailability.NoneFencingAction,
+		"The action to fence the previous leader before promotion, one of none, label and command")
+	pflag.String(highavailability.FencingCommandFlag, "", "The command to fence the previous leader for the command fencing action")
+	pflag.Duration(highavailability.FencingTimeoutFlag, 10*time.Second, "The timeout of the fencing action")
 }
 
 func main() {
@@ -375,6 +375,15 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appscontrollers.OpsRequestScheduleReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ops-request-schedule-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpsRequestSchedule")
+			os.Exit(1)
+		}
+
 		if err = (&configuration.ConfigConstraintReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
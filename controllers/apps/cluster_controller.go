@@ -139,6 +139,8 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&clusterComponentStatusTransformer{},
 			// create default cluster connection credential secret object
 			&clusterConnCredentialTransformer{},
+			// create the cluster CA secret to sign the component TLS certificates
+			&clusterTLSTransformer{},
 			// build backuppolicy and backupschedule from backupPolicyTemplate
 			&clusterBackupPolicyTransformer{},
 			// add our finalizer to all objects
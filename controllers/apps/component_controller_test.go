@@ -1485,6 +1485,30 @@ var _ = Describe("Component Controller", func() {
 			g.Expect(secret.Data).Should(HaveKey(constant.KeyName))
 		})).Should(Succeed())
 
+		By("check the TLS certificate is signed by the cluster CA and covers the component")
+		caSecret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: compObj.Namespace,
+			Name: plan.GenerateTLSCASecretName(clusterObj.Name)}, caSecret)).Should(Succeed())
+		Expect(caSecret.Data).Should(HaveKey(constant.CAKeyName))
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, secretKey, secret)).Should(Succeed())
+		Expect(secret.Data[constant.CAName]).Should(Equal(caSecret.Data[constant.CAName]))
+		dnsNames := []string{
+			fmt.Sprintf("%s.%s.svc", constant.GenerateDefaultComponentServiceName(clusterObj.Name, compName), compObj.Namespace),
+			fmt.Sprintf("%s.%s.%s.svc", constant.GeneratePodName(clusterObj.Name, compName, 0),
+				constant.GenerateDefaultComponentHeadlessServiceName(clusterObj.Name, compName), compObj.Namespace),
+		}
+		Expect(plan.IsTLSSecretValid(secret, caSecret, dnsNames, &appsv1alpha1.Issuer{Name: appsv1alpha1.IssuerKubeBlocks})).Should(BeTrue())
+
+		By("check the TLS certificate is tracked in the component status")
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(compObj), func(g Gomega, comp *appsv1alpha1.Component) {
+			g.Expect(comp.Status.TLSCertificate).ShouldNot(BeNil())
+			g.Expect(comp.Status.TLSCertificate.SecretName).Should(Equal(secretKey.Name))
+			g.Expect(comp.Status.TLSCertificate.NotAfter).ShouldNot(BeNil())
+			g.Expect(comp.Status.TLSCertificate.RenewalTime).ShouldNot(BeNil())
+			g.Expect(comp.Status.TLSCertificate.RenewalTime.Before(comp.Status.TLSCertificate.NotAfter)).Should(BeTrue())
+		})).Should(Succeed())
+
 		By("check pod's volumes and mounts")
 		targetVolume := corev1.Volume{
 			Name: constant.VolumeName,
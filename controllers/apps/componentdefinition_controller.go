@@ -35,6 +35,7 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -273,6 +274,27 @@ func (r *ComponentDefinitionReconciler) validateSystemAccounts(cli client.Client
 			return fmt.Errorf("the Statement or SecretRef must be provided to create system account: %s", account.Name)
 		}
 	}
+	return r.validatePasswordRotationPolicies(cmpd)
+}
+
+// validatePasswordRotationPolicies validates the password rotation policies of the system accounts.
+func (r *ComponentDefinitionReconciler) validatePasswordRotationPolicies(cmpd *appsv1alpha1.ComponentDefinition) error {
+	builtinHandler := component.GetBuiltinActionHandler(cmpd.Spec.LifecycleActions)
+	for _, account := range cmpd.Spec.SystemAccounts {
+		policy := account.PasswordRotationPolicy
+		if policy == nil {
+			continue
+		}
+		// lorry reads the password of the init account from the env variables, which are not refreshed
+		// until the container is restarted.
+		if account.InitAccount {
+			return fmt.Errorf("the password rotation of system init account %s is not supported", account.Name)
+		}
+		if policy.GracePeriod != nil && !slices.Contains(getDualPasswordBuiltinActionHandlers(), builtinHandler) {
+			return fmt.Errorf("the grace period of the password rotation of system account %s is not supported by the builtin handler %s",
+				account.Name, builtinHandler)
+		}
+	}
 	return nil
 }
 
@@ -376,6 +398,16 @@ func checkUniqueItemWithValue(slice any, fieldName string, val any) bool {
 	return true
 }
 
+// getDualPasswordBuiltinActionHandlers returns the builtin handlers which are able to keep the old password
+// valid after the password is updated.
+func getDualPasswordBuiltinActionHandlers() []appsv1alpha1.BuiltinActionHandlerType {
+	return []appsv1alpha1.BuiltinActionHandlerType{
+		appsv1alpha1.MySQLBuiltinActionHandler,
+		appsv1alpha1.WeSQLBuiltinActionHandler,
+		appsv1alpha1.RedisBuiltinActionHandler,
+	}
+}
+
 func getBuiltinActionHandlers() []appsv1alpha1.BuiltinActionHandlerType {
 	return []appsv1alpha1.BuiltinActionHandlerType{
 		appsv1alpha1.MySQLBuiltinActionHandler,
@@ -22,11 +22,13 @@ package apps
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -317,6 +319,37 @@ var _ = Describe("ComponentDefinition Controller", func() {
 
 			checkObjectStatus(componentDefObj, appsv1alpha1.AvailablePhase)
 		})
+
+		It("rotate the password of init account", func() {
+			By("create a ComponentDefinition obj")
+			componentDefObj := testapps.NewComponentDefinitionFactory(componentDefName).
+				SetRuntime(nil).
+				AddSystemAccount(string(appsv1alpha1.AdminAccount), true, "create user").
+				SetLifecycleAction("AccountProvision", defaultActionHandler).
+				Apply(func(cmpd *appsv1alpha1.ComponentDefinition) {
+					cmpd.Spec.SystemAccounts[0].PasswordRotationPolicy = &appsv1alpha1.PasswordRotationPolicy{}
+				}).
+				Create(&testCtx).GetObject()
+
+			checkObjectStatus(componentDefObj, appsv1alpha1.UnavailablePhase)
+		})
+
+		It("rotate the password with grace period", func() {
+			By("create a ComponentDefinition obj w/o the builtin handler supporting dual passwords")
+			componentDefObj := testapps.NewComponentDefinitionFactory(componentDefName).
+				SetRuntime(nil).
+				AddSystemAccount(string(appsv1alpha1.AdminAccount), true, "create user").
+				AddSystemAccount(string(appsv1alpha1.ProbeAccount), false, "create user").
+				SetLifecycleAction("AccountProvision", defaultActionHandler).
+				Apply(func(cmpd *appsv1alpha1.ComponentDefinition) {
+					cmpd.Spec.SystemAccounts[1].PasswordRotationPolicy = &appsv1alpha1.PasswordRotationPolicy{
+						GracePeriod: &metav1.Duration{Duration: time.Hour},
+					}
+				}).
+				Create(&testCtx).GetObject()
+
+			checkObjectStatus(componentDefObj, appsv1alpha1.UnavailablePhase)
+		})
 	})
 
 	Context("replica roles", func() {
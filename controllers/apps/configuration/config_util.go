@@ -356,6 +356,11 @@ func validateConfigTemplate(cli client.Client, ctx intctrlutil.RequestCtx, confi
 		if err := cfgcm.ValidateReloadOptions(configConstraint.Spec.ReloadOptions, cli, ctx.Ctx); err != nil {
 			return false, err
 		}
+		if tlsReloadOptions := configConstraint.Spec.TLSReloadOptions; tlsReloadOptions != nil {
+			if err := cfgcm.ValidateTLSReloadOptions(tlsReloadOptions); err != nil {
+				return false, err
+			}
+		}
 		if !validateConfigConstraintStatus(configConstraint.Status) {
 			errMsg := fmt.Sprintf("Configuration template CR[%s] status not ready! current status: %s", configConstraint.Name, configConstraint.Status.Phase)
 			logger.V(1).Info(errMsg)
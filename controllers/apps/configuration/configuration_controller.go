@@ -111,9 +111,19 @@ func (r *ConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	if err := r.runTasks(TaskContext{configuration, reqCtx, fetcherTask}, tasks); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to run configuration reconcile task.")
 	}
+	if err := r.syncRevisionHistory(TaskContext{configuration, reqCtx, fetcherTask}); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to sync configuration revision history.")
+	}
 	if !isAllReady(configuration) {
 		return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log, "")
 	}
+	requeueAfter, err := r.checkConfigurationDrift(TaskContext{configuration, reqCtx, fetcherTask})
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to check configuration drift.")
+	}
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "")
+	}
 	return intctrlutil.Reconciled()
 }
 
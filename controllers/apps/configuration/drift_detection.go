@@ -0,0 +1,322 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const reasonConfigurationDrifted = "ConfigurationDrifted"
+
+var sizeValueRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([kmgt]?)b?$`)
+
+// checkConfigurationDrift compares the rendered configuration with the parameters running in the engine
+// for the finished config items whose ConfigConstraint enables the drift detection,
+// it returns the duration to the next detection, zero means no detection is required.
+func (r *ConfigurationReconciler) checkConfigurationDrift(taskCtx TaskContext) (time.Duration, error) {
+	var (
+		errs         []error
+		requeueAfter time.Duration
+		updated      bool
+
+		now           = time.Now()
+		ctx           = taskCtx.reqCtx.Ctx
+		configuration = taskCtx.configuration
+	)
+
+	nextCheck := func(d time.Duration) {
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+
+	patch := client.MergeFrom(configuration.DeepCopy())
+	for _, item := range configuration.Spec.ConfigItemDetails {
+		if item.ConfigSpec == nil || item.ConfigSpec.ConfigConstraintRef == "" {
+			continue
+		}
+		status := configuration.Status.GetItemStatus(item.Name)
+		if status == nil || status.Phase != appsv1alpha1.CFinishedPhase {
+			continue
+		}
+		cc := &appsv1alpha1.ConfigConstraint{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: item.ConfigSpec.ConfigConstraintRef}, cc); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if cc.Spec.DriftDetection == nil {
+			continue
+		}
+		interval := cc.Spec.DriftDetection.GetInterval()
+		if status.DriftStatus != nil && status.DriftStatus.LastCheckTime != nil {
+			if next := status.DriftStatus.LastCheckTime.Add(interval); now.Before(next) {
+				nextCheck(next.Sub(now))
+				continue
+			}
+		}
+		status.DriftStatus = r.detectItemDrift(taskCtx, item, cc)
+		status.DriftStatus.LastCheckTime = &metav1.Time{Time: now}
+		updated = true
+		nextCheck(interval)
+	}
+
+	if updated {
+		if err := r.Client.Status().Patch(ctx, configuration, patch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return requeueAfter, utilerrors.NewAggregate(errs)
+}
+
+// detectItemDrift queries the running parameters through lorry on each ready pod, and compares them with the rendered ones.
+func (r *ConfigurationReconciler) detectItemDrift(taskCtx TaskContext, item appsv1alpha1.ConfigurationItemDetail, cc *appsv1alpha1.ConfigConstraint) *appsv1alpha1.ConfigDriftStatus {
+	var (
+		errs []error
+
+		ctx           = taskCtx.reqCtx.Ctx
+		configuration = taskCtx.configuration
+		driftStatus   = &appsv1alpha1.ConfigDriftStatus{}
+	)
+
+	expected, err := r.getRenderedParameters(taskCtx, item, cc)
+	if err != nil {
+		driftStatus.Message = err.Error()
+		return driftStatus
+	}
+	expected = filterDriftCandidates(expected, &cc.Spec)
+	if len(expected) == 0 {
+		return driftStatus
+	}
+	pods, err := r.getDriftTargetPods(taskCtx, cc)
+	if err != nil {
+		driftStatus.Message = err.Error()
+		return driftStatus
+	}
+
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i := range pods {
+		pod := &pods[i]
+		lorryCli, err := lorry.NewClient(*pod)
+		if err != nil {
+			errs = append(errs, core.WrapError(err, "failed to create lorry client for pod %s", pod.Name))
+			continue
+		}
+		if intctrlutil.IsNil(lorryCli) {
+			continue
+		}
+		actual, err := lorryCli.GetParameters(ctx, names)
+		if err != nil {
+			errs = append(errs, core.WrapError(err, "failed to get parameters from pod %s", pod.Name))
+			continue
+		}
+		drifted := diffParameters(pod.Name, expected, actual)
+		if len(drifted) == 0 {
+			continue
+		}
+		if cc.Spec.DriftDetection.AutoReconcile {
+			errs = append(errs, reconcileDriftedParameters(taskCtx, pod, item.Name, drifted)...)
+		}
+		driftStatus.DriftedParameters = append(driftStatus.DriftedParameters, drifted...)
+	}
+
+	if len(driftStatus.DriftedParameters) > 0 {
+		r.Recorder.Eventf(configuration, corev1.EventTypeWarning, reasonConfigurationDrifted,
+			"the running parameters of config template %s drift from the rendered configuration: %s",
+			item.Name, formatDriftedParameters(driftStatus.DriftedParameters))
+	}
+	if len(errs) > 0 {
+		driftStatus.Message = utilerrors.NewAggregate(errs).Error()
+	}
+	return driftStatus
+}
+
+// getRenderedParameters flattens the rendered configuration files to the parameters.
+func (r *ConfigurationReconciler) getRenderedParameters(taskCtx TaskContext, item appsv1alpha1.ConfigurationItemDetail, cc *appsv1alpha1.ConfigConstraint) (map[string]string, error) {
+	if cc.Spec.FormatterConfig == nil {
+		return nil, core.MakeError("the formatterConfig of configConstraint %s is not specified", cc.Name)
+	}
+	configuration := taskCtx.configuration
+	cmKey := client.ObjectKey{
+		Namespace: configuration.Namespace,
+		Name:      core.GetComponentCfgName(configuration.Spec.ClusterRef, configuration.Spec.ComponentName, item.Name),
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(taskCtx.reqCtx.Ctx, cmKey, configMap); err != nil {
+		return nil, err
+	}
+
+	keys := core.FromCMKeysSelector(item.ConfigSpec.Keys)
+	params := make(map[string]string)
+	for file, data := range configMap.Data {
+		if keys != nil && !keys.InArray(file) {
+			continue
+		}
+		kvs, err := core.TransformConfigFileToKeyValueMap(file, cc.Spec.FormatterConfig, []byte(data))
+		if err != nil {
+			return nil, core.WrapError(err, "failed to parse the config file %s", file)
+		}
+		for k, v := range kvs {
+			params[k] = v
+		}
+	}
+	return params, nil
+}
+
+// getDriftTargetPods returns the ready pods of the component which match the selector of the ConfigConstraint.
+func (r *ConfigurationReconciler) getDriftTargetPods(taskCtx TaskContext, cc *appsv1alpha1.ConfigConstraint) ([]corev1.Pod, error) {
+	podList, err := component.GetComponentPodList(taskCtx.reqCtx.Ctx, r.Client, *taskCtx.fetcher.ClusterObj, taskCtx.configuration.Spec.ComponentName)
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		if intctrlutil.PodIsReady(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+	if cc.Spec.Selector != nil {
+		return matchLabel(pods, cc.Spec.Selector)
+	}
+	return pods, nil
+}
+
+// reconcileDriftedParameters re-applies the expected values of the drifted parameters to the pod through the config manager.
+func reconcileDriftedParameters(taskCtx TaskContext, pod *corev1.Pod, configSpec string, drifted []appsv1alpha1.DriftedParameter) []error {
+	params := make(map[string]string, len(drifted))
+	for _, param := range drifted {
+		params[param.Name] = param.Expected
+	}
+	if err := commonOnlineUpdateWithPod(pod, taskCtx.reqCtx.Ctx, GetClientFactory(), configSpec, params); err != nil {
+		return []error{core.WrapError(err, "failed to reconcile the drifted parameters on pod %s", pod.Name)}
+	}
+	for i := range drifted {
+		drifted[i].Reconciled = true
+	}
+	return nil
+}
+
+// filterDriftCandidates keeps the parameters which can be changed at runtime,
+// the static and immutable parameters are only effective after restart and never drift.
+func filterDriftCandidates(params map[string]string, ccSpec *appsv1alpha1.ConfigConstraintSpec) map[string]string {
+	result := make(map[string]string, len(params))
+	if len(ccSpec.DynamicParameters) > 0 {
+		for _, name := range ccSpec.DynamicParameters {
+			if v, ok := params[name]; ok {
+				result[name] = v
+			}
+		}
+		return result
+	}
+	excluded := make(map[string]bool)
+	for _, name := range ccSpec.StaticParameters {
+		excluded[name] = true
+	}
+	for _, name := range ccSpec.ImmutableParameters {
+		excluded[name] = true
+	}
+	for name, v := range params {
+		if !excluded[name] {
+			result[name] = v
+		}
+	}
+	return result
+}
+
+// diffParameters returns the parameters whose running values differ from the expected ones,
+// the parameters unknown to the engine are ignored.
+func diffParameters(podName string, expected, actual map[string]string) []appsv1alpha1.DriftedParameter {
+	var drifted []appsv1alpha1.DriftedParameter
+	for name, value := range expected {
+		running, ok := actual[name]
+		if !ok || isParameterValueEqual(value, running) {
+			continue
+		}
+		drifted = append(drifted, appsv1alpha1.DriftedParameter{
+			Pod:      podName,
+			Name:     name,
+			Expected: value,
+			Actual:   running,
+		})
+	}
+	sort.Slice(drifted, func(i, j int) bool {
+		return drifted[i].Name < drifted[j].Name
+	})
+	return drifted
+}
+
+// isParameterValueEqual compares the parameter values in the different representations of the configuration file and the engine,
+// e.g. ON and 1, 128M and 134217728.
+func isParameterValueEqual(expected, actual string) bool {
+	return normalizeParameterValue(expected) == normalizeParameterValue(actual)
+}
+
+func normalizeParameterValue(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.TrimSpace(strings.Trim(value, `"'`))
+	switch value {
+	case "on", "true", "yes":
+		return "1"
+	case "off", "false", "no":
+		return "0"
+	}
+	match := sizeValueRegex.FindStringSubmatch(value)
+	if match == nil {
+		return value
+	}
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return value
+	}
+	for _, unit := range []string{"", "k", "m", "g", "t"} {
+		if unit == match[2] {
+			break
+		}
+		number *= 1024
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+func formatDriftedParameters(drifted []appsv1alpha1.DriftedParameter) string {
+	items := make([]string, 0, len(drifted))
+	for _, param := range drifted {
+		items = append(items, fmt.Sprintf("%s/%s[expected: %s, actual: %s]", param.Pod, param.Name, param.Expected, param.Actual))
+	}
+	return strings.Join(items, ", ")
+}
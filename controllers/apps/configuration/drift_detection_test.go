@@ -0,0 +1,89 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestIsParameterValueEqual(t *testing.T) {
+	tests := []struct {
+		expected string
+		actual   string
+		want     bool
+	}{
+		{"ON", "1", true},
+		{"off", "0", true},
+		{"true", "ON", true},
+		{`"utf8mb4"`, "UTF8MB4", true},
+		{"128M", "134217728", true},
+		{"1GB", "1024MB", true},
+		{"8kB", "8192", true},
+		{"1.0", "1", true},
+		{"100", "200", false},
+		{"ROW", "STATEMENT", false},
+		{"128M", "256M", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isParameterValueEqual(tt.expected, tt.actual), "%s vs %s", tt.expected, tt.actual)
+	}
+}
+
+func TestFilterDriftCandidates(t *testing.T) {
+	params := map[string]string{
+		"max_connections":      "1000",
+		"innodb_log_file_size": "1G",
+		"server_id":            "1",
+	}
+
+	candidates := filterDriftCandidates(params, &appsv1alpha1.ConfigConstraintSpec{
+		DynamicParameters: []string{"max_connections", "not_exist"},
+	})
+	assert.Equal(t, map[string]string{"max_connections": "1000"}, candidates)
+
+	candidates = filterDriftCandidates(params, &appsv1alpha1.ConfigConstraintSpec{
+		StaticParameters:    []string{"innodb_log_file_size"},
+		ImmutableParameters: []string{"server_id"},
+	})
+	assert.Equal(t, map[string]string{"max_connections": "1000"}, candidates)
+}
+
+func TestDiffParameters(t *testing.T) {
+	expected := map[string]string{
+		"max_connections":         "1000",
+		"binlog_format":           "ROW",
+		"sql_mode":                "",
+		"innodb_buffer_pool_size": "128M",
+	}
+	actual := map[string]string{
+		"max_connections":         "500",
+		"binlog_format":           "MIXED",
+		"innodb_buffer_pool_size": "134217728",
+	}
+	assert.Equal(t, []appsv1alpha1.DriftedParameter{
+		{Pod: "pod-0", Name: "binlog_format", Expected: "ROW", Actual: "MIXED"},
+		{Pod: "pod-0", Name: "max_connections", Expected: "1000", Actual: "500"},
+	}, diffParameters("pod-0", expected, actual))
+	assert.Empty(t, diffParameters("pod-0", expected, expected))
+}
@@ -247,6 +247,14 @@ func enableSyncTrigger(options *appsv1alpha1.ReloadOptions) bool {
 	if options.ShellTrigger != nil {
 		return !core.IsWatchModuleForShellTrigger(options.ShellTrigger)
 	}
+
+	if options.HTTPTrigger != nil {
+		return !core.IsWatchModuleForHTTPTrigger(options.HTTPTrigger)
+	}
+
+	if options.SQLTrigger != nil {
+		return !core.IsWatchModuleForSQLTrigger(options.SQLTrigger)
+	}
 	return false
 }
 
@@ -0,0 +1,135 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/configuration"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// syncRevisionHistory records each revision applied to the config ConfigMaps as an immutable ConfigMap,
+// keeps the phase of the revision up to date, and removes the oldest revisions beyond the history limit.
+func (r *ConfigurationReconciler) syncRevisionHistory(taskCtx TaskContext) error {
+	var errs []error
+	for _, item := range taskCtx.configuration.Spec.ConfigItemDetails {
+		status := taskCtx.configuration.Status.GetItemStatus(item.Name)
+		if item.ConfigSpec == nil || status == nil || !isReconcileStatus(status.Phase) {
+			continue
+		}
+		if err := r.syncItemRevisionHistory(taskCtx, item, status); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (r *ConfigurationReconciler) syncItemRevisionHistory(taskCtx TaskContext,
+	item appsv1alpha1.ConfigurationItemDetail,
+	status *appsv1alpha1.ConfigurationItemDetailStatus) error {
+	var (
+		ctx       = taskCtx.reqCtx.Ctx
+		config    = taskCtx.configuration
+		configMap = &corev1.ConfigMap{}
+	)
+
+	cmKey := client.ObjectKey{
+		Namespace: config.Namespace,
+		Name:      core.GetComponentCfgName(config.Spec.ClusterRef, config.Spec.ComponentName, item.Name),
+	}
+	if err := r.Client.Get(ctx, cmKey, configMap); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	revision := GetCurrentRevision(configMap.GetAnnotations())
+	if revision == "" {
+		return nil
+	}
+	revisions, err := configuration.ListConfigRevisions(ctx, r.Client, configMap)
+	if err != nil {
+		return err
+	}
+
+	var current *corev1.ConfigMap
+	for i := range revisions {
+		if revisions[i].Labels[constant.ConfigRevisionLabelKey] == revision {
+			current = &revisions[i]
+		}
+	}
+	if current == nil {
+		if current, err = r.createRevision(taskCtx, configMap, revision, revisions); err != nil {
+			return err
+		}
+		revisions = append(revisions, *current)
+	}
+
+	// the phase of the item status is the phase of the update revision.
+	if status.UpdateRevision == revision && current.Annotations[constant.ConfigRevisionPhaseAnnotationKey] != string(status.Phase) {
+		patch := client.MergeFrom(current.DeepCopy())
+		if current.Annotations == nil {
+			current.Annotations = map[string]string{}
+		}
+		current.Annotations[constant.ConfigRevisionPhaseAnnotationKey] = string(status.Phase)
+		if err = r.Client.Patch(ctx, current, patch); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(revisions)-revisionHistoryLimit; i++ {
+		if err = r.Client.Delete(ctx, &revisions[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ConfigurationReconciler) createRevision(taskCtx TaskContext, configMap *corev1.ConfigMap, revision string, revisions []corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	var (
+		opsName  string
+		previous *corev1.ConfigMap
+		config   = taskCtx.configuration
+	)
+
+	if len(revisions) > 0 {
+		previous = &revisions[len(revisions)-1]
+	}
+	// the OpsRequest which generates the revision is recorded in the annotations of the configuration.
+	if config.Annotations[constant.LastAppliedOpsRevisionAnnotationKey] == revision {
+		opsName = config.Annotations[constant.LastAppliedOpsCRAnnotationKey]
+	}
+	revisionCM, err := configuration.BuildConfigRevision(configMap, opsName, previous)
+	if err != nil {
+		return nil, err
+	}
+	if err = intctrlutil.SetOwnerReference(config, revisionCM); err != nil {
+		return nil, err
+	}
+	if err = r.Client.Create(taskCtx.reqCtx.Ctx, revisionCM); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	taskCtx.reqCtx.Log.V(1).Info("configuration revision recorded", "configmap", revisionCM.Name, "revision", revision)
+	return revisionCM, nil
+}
@@ -38,6 +38,8 @@ const (
 	reasonOpsCancelActionFailed       = "CancelActionFailed"
 	reasonOpsReconcileStatusFailed    = "ReconcileStatusFailed"
 	reasonOpsDoActionFailed           = "DoActionFailed"
+	reasonOpsRequestScheduled         = "OpsRequestScheduled"
+	reasonOpsRollbackFailed           = "RollbackFailed"
 )
 
 const (
@@ -0,0 +1,381 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+// the updated pods are checked periodically, since the health checks by lorry
+// do not trigger the reconciliation of the opsRequest.
+const canaryCheckInterval = 10 * time.Second
+
+// rollingOpsTypes are the operations which roll the pods of the workloads,
+// they take over the canary limit of the workloads left by the previous opsRequest.
+var rollingOpsTypes = []appsv1alpha1.OpsType{
+	appsv1alpha1.RestartType,
+	appsv1alpha1.UpgradeType,
+	appsv1alpha1.ReconfiguringType,
+	appsv1alpha1.VerticalScalingType,
+}
+
+// canaryOpsTypes are the operations which support the canary, the pods reconfigured in place by
+// the Reconfiguring opsRequest are not limited by the update plan of the RSM.
+var canaryOpsTypes = []appsv1alpha1.OpsType{
+	appsv1alpha1.RestartType,
+	appsv1alpha1.UpgradeType,
+	appsv1alpha1.VerticalScalingType,
+}
+
+// canaryCheckResult is the result of the health checks of the updated pods.
+type canaryCheckResult struct {
+	// updatedPods are the pods updated by the opsRequest.
+	updatedPods []string
+	// canaryReady indicates all canary pods are updated and ready.
+	canaryReady bool
+	// healthErr is the error of the failed health check.
+	healthErr error
+}
+
+// getCanaryComponentNames returns the names of the components rolled by the opsRequest.
+func getCanaryComponentNames(opsRes *OpsResource) []string {
+	var compNames []string
+	if opsRes.OpsRequest.Spec.Type == appsv1alpha1.UpgradeType {
+		for _, compSpec := range opsRes.Cluster.Spec.ComponentSpecs {
+			compNames = append(compNames, compSpec.Name)
+		}
+		return compNames
+	}
+	for compName := range opsRes.OpsRequest.GetComponentNameSet() {
+		compNames = append(compNames, compName)
+	}
+	sort.Strings(compNames)
+	return compNames
+}
+
+// getCanaryRSMs returns the RSMs of the components rolled by the opsRequest,
+// the components which are not managed by RSM are ignored.
+func getCanaryRSMs(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) ([]*workloads.ReplicatedStateMachine, error) {
+	var rsms []*workloads.ReplicatedStateMachine
+	for _, compName := range getCanaryComponentNames(opsRes) {
+		rsm := &workloads.ReplicatedStateMachine{}
+		rsmKey := client.ObjectKey{
+			Namespace: opsRes.Cluster.Namespace,
+			Name:      constant.GenerateRSMNamePattern(opsRes.Cluster.Name, compName),
+		}
+		if err := cli.Get(reqCtx.Ctx, rsmKey, rsm); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		rsms = append(rsms, rsm)
+	}
+	return rsms, nil
+}
+
+// validateCanary checks if the canary of the opsRequest can be enforced, the number of updated pods is limited
+// by the update plan of the RSM, which is built only if the member update strategy of the RSM is specified.
+func validateCanary(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.Canary == nil {
+		return nil
+	}
+	if !slices.Contains(canaryOpsTypes, opsRequest.Spec.Type) {
+		return intctrlutil.NewFatalError(fmt.Sprintf("canary is not supported by the %s opsRequest", opsRequest.Spec.Type))
+	}
+	canaryReplicas := opsRequest.Spec.Canary.Replicas
+	for _, compName := range getCanaryComponentNames(opsRes) {
+		rsm := &workloads.ReplicatedStateMachine{}
+		rsmKey := client.ObjectKey{
+			Namespace: opsRes.Cluster.Namespace,
+			Name:      constant.GenerateRSMNamePattern(opsRes.Cluster.Name, compName),
+		}
+		if err := cli.Get(reqCtx.Ctx, rsmKey, rsm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`canary is not supported by component "%s", which is not managed by RSM`, compName))
+			}
+			return err
+		}
+		if rsm.Spec.MemberUpdateStrategy == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`canary is not supported by component "%s", which has no member update strategy`, compName))
+		}
+		// all the pods are updated in the canary phase otherwise, and the canary pods can not be told apart after the update.
+		if rsm.Spec.Replicas != nil && canaryReplicas >= *rsm.Spec.Replicas {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the canary replicas %d must be less than the replicas %d of component "%s"`,
+				canaryReplicas, *rsm.Spec.Replicas, compName))
+		}
+	}
+	return nil
+}
+
+// setMaxUpdatedReplicas sets the max number of the updated pods of the RSM, the limit is removed if maxUpdatedReplicas is nil.
+func setMaxUpdatedReplicas(reqCtx intctrlutil.RequestCtx, cli client.Client, rsm *workloads.ReplicatedStateMachine, maxUpdatedReplicas *int32) error {
+	value, ok := rsm.Annotations[constant.MaxUpdatedReplicasAnnotationKey]
+	if maxUpdatedReplicas == nil && !ok ||
+		maxUpdatedReplicas != nil && value == strconv.Itoa(int(*maxUpdatedReplicas)) {
+		return nil
+	}
+	patch := client.MergeFrom(rsm.DeepCopy())
+	if maxUpdatedReplicas == nil {
+		delete(rsm.Annotations, constant.MaxUpdatedReplicasAnnotationKey)
+	} else {
+		if rsm.Annotations == nil {
+			rsm.Annotations = map[string]string{}
+		}
+		rsm.Annotations[constant.MaxUpdatedReplicasAnnotationKey] = strconv.Itoa(int(*maxUpdatedReplicas))
+	}
+	return cli.Patch(reqCtx.Ctx, rsm, patch)
+}
+
+// setupCanary limits the number of updated pods of the RSMs to the canary replicas before the action is performed,
+// and removes the limit left by the previous opsRequest if the canary is not specified.
+func setupCanary(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if !slices.Contains(rollingOpsTypes, opsRequest.Spec.Type) {
+		return nil
+	}
+	rsms, err := getCanaryRSMs(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	var maxUpdatedReplicas *int32
+	if opsRequest.Spec.Canary != nil {
+		maxUpdatedReplicas = &opsRequest.Spec.Canary.Replicas
+	}
+	for _, rsm := range rsms {
+		if err = setMaxUpdatedReplicas(reqCtx, cli, rsm, maxUpdatedReplicas); err != nil {
+			return err
+		}
+	}
+	if opsRequest.Spec.Canary != nil && opsRequest.Status.Canary == nil {
+		opsRequest.Status.Canary = &appsv1alpha1.OpsRequestCanaryStatus{
+			Phase:              appsv1alpha1.CanaryProgressingPhase,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	return nil
+}
+
+// cleanupCanary removes the limit of the updated pods of the RSMs after the opsRequest is failed or cancelled,
+// otherwise the following updates of the RSMs are blocked by the limit.
+func cleanupCanary(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.OpsRequest.Spec.Canary == nil {
+		return nil
+	}
+	rsms, err := getCanaryRSMs(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	for _, rsm := range rsms {
+		if err = setMaxUpdatedReplicas(reqCtx, cli, rsm, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isUpdatedPod checks if the pod is rolled to the update revision of the RSM by the opsRequest.
+func isUpdatedPod(rsm *workloads.ReplicatedStateMachine, pod *corev1.Pod) bool {
+	revision := intctrlutil.GetPodRevision(pod)
+	return rsm.Status.UpdateRevision != "" && revision == rsm.Status.UpdateRevision && revision != rsm.Status.CurrentRevision
+}
+
+// checkUpdatedPods checks the health of the pods updated by the opsRequest via lorry.
+func checkUpdatedPods(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	rsms []*workloads.ReplicatedStateMachine) (*canaryCheckResult, error) {
+	var (
+		opsRequest = opsRes.OpsRequest
+		result     = &canaryCheckResult{canaryReady: true}
+	)
+	for _, rsm := range rsms {
+		compName := rsm.Labels[constant.KBAppComponentLabelKey]
+		podList, err := component.GetComponentPodList(reqCtx.Ctx, cli, *opsRes.Cluster, compName)
+		if err != nil {
+			return nil, err
+		}
+		canaryReplicas := opsRequest.Spec.Canary.Replicas
+		if rsm.Spec.Replicas != nil && *rsm.Spec.Replicas < canaryReplicas {
+			canaryReplicas = *rsm.Spec.Replicas
+		}
+		var readyReplicas int32
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if !isUpdatedPod(rsm, pod) {
+				continue
+			}
+			result.updatedPods = append(result.updatedPods, pod.Name)
+			ready, err := checkUpdatedPodHealth(reqCtx, rsm, pod)
+			if err != nil {
+				if result.healthErr == nil {
+					result.healthErr = err
+				}
+				continue
+			}
+			if ready {
+				readyReplicas++
+			}
+		}
+		if readyReplicas < canaryReplicas {
+			result.canaryReady = false
+		}
+	}
+	sort.Strings(result.updatedPods)
+	return result, nil
+}
+
+// checkUpdatedPodHealth checks the health of the updated pod by lorry's checkstatus and getrole operations,
+// it returns false if the pod is not ready yet.
+func checkUpdatedPodHealth(reqCtx intctrlutil.RequestCtx, rsm *workloads.ReplicatedStateMachine, pod *corev1.Pod) (bool, error) {
+	if !intctrlutil.PodIsReady(pod) {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.RestartCount > 0 {
+				return false, fmt.Errorf(`pod "%s" is not ready and the container "%s" has restarted %d times`,
+					pod.Name, containerStatus.Name, containerStatus.RestartCount)
+			}
+		}
+		return false, nil
+	}
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil {
+		return false, err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return true, nil
+	}
+	if err = lorryCli.CheckStatus(reqCtx.Ctx); err != nil && !errors.Is(err, lorry.NotImplemented) {
+		return false, fmt.Errorf(`pod "%s" is unhealthy: %s`, pod.Name, err.Error())
+	}
+	if len(rsm.Spec.Roles) == 0 {
+		return true, nil
+	}
+	// getrole does not change the state of the role probe, unlike checkrole.
+	role, err := lorryCli.GetRole(reqCtx.Ctx)
+	if err != nil && !errors.Is(err, lorry.NotImplemented) {
+		return false, fmt.Errorf(`failed to get the role of pod "%s": %s`, pod.Name, err.Error())
+	}
+	if role == "" {
+		role = pod.Labels[constant.RoleLabelKey]
+	}
+	if role == "" {
+		return false, fmt.Errorf(`pod "%s" has no role`, pod.Name)
+	}
+	return true, nil
+}
+
+// reconcileCanary checks the health of the updated pods periodically, pauses the update after the canary pods are healthy,
+// promotes the update after the opsRequest is resumed, and halts the update if the health checks are failed.
+func reconcileCanary(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	canaryPolicy := opsRequest.Spec.Canary
+	if canaryPolicy == nil || opsRequest.Status.Canary == nil ||
+		slices.Contains([]appsv1alpha1.CanaryPhase{appsv1alpha1.CanaryHaltedPhase, appsv1alpha1.CanaryCompletedPhase}, opsRequest.Status.Canary.Phase) {
+		return 0, nil
+	}
+	rsms, err := getCanaryRSMs(reqCtx, cli, opsRes)
+	if err != nil {
+		return 0, err
+	}
+	result, err := checkUpdatedPods(reqCtx, cli, opsRes, rsms)
+	if err != nil {
+		return 0, err
+	}
+	opsDeepCopy := opsRequest.DeepCopy()
+	canary := opsRequest.Status.Canary
+	canary.UpdatedPods = result.updatedPods
+	if result.healthErr != nil {
+		canary.FailedChecks++
+		canary.Message = result.healthErr.Error()
+	} else {
+		canary.FailedChecks = 0
+		canary.Message = ""
+	}
+	failureThreshold := canaryPolicy.FailureThreshold
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	promote := false
+	switch {
+	case canary.FailedChecks >= failureThreshold:
+		// the opsRequest is failed after the update is halted, and the limit is removed then.
+		canary.Phase = appsv1alpha1.CanaryHaltedPhase
+		canary.Message = fmt.Sprintf("the update is halted since the health checks of the updated pods failed %d times: %s",
+			canary.FailedChecks, canary.Message)
+	case canary.Phase == appsv1alpha1.CanaryProgressingPhase && result.canaryReady && result.healthErr == nil:
+		if canaryPolicy.AutoPromote || canaryPolicy.Resume {
+			promote = true
+		} else {
+			canary.Phase = appsv1alpha1.CanaryPausedPhase
+		}
+	case canary.Phase == appsv1alpha1.CanaryPausedPhase && canaryPolicy.Resume && result.healthErr == nil:
+		promote = true
+	}
+	if promote {
+		for _, rsm := range rsms {
+			if err = setMaxUpdatedReplicas(reqCtx, cli, rsm, nil); err != nil {
+				return 0, err
+			}
+		}
+		canary.Phase = appsv1alpha1.CanaryPromotingPhase
+	}
+	if canary.Phase != opsDeepCopy.Status.Canary.Phase {
+		canary.LastTransitionTime = metav1.Now()
+		condition := appsv1alpha1.NewCanaryCondition(opsRequest)
+		opsRequest.SetStatusCondition(*condition)
+		if opsRes.Recorder != nil {
+			eventType := corev1.EventTypeNormal
+			if canary.Phase == appsv1alpha1.CanaryHaltedPhase {
+				eventType = corev1.EventTypeWarning
+			}
+			opsRes.Recorder.Event(opsRequest, eventType, condition.Reason, condition.Message)
+		}
+	}
+	if !reflect.DeepEqual(opsRequest.Status, opsDeepCopy.Status) {
+		if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy)); err != nil {
+			return 0, err
+		}
+	}
+	return canaryCheckInterval, nil
+}
+
+// isCanaryHalted checks if the update of the opsRequest is halted by the failed health checks.
+func isCanaryHalted(opsRequest *appsv1alpha1.OpsRequest) bool {
+	return opsRequest.Status.Canary != nil && opsRequest.Status.Canary.Phase == appsv1alpha1.CanaryHaltedPhase
+}
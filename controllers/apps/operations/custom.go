@@ -91,13 +91,15 @@ func (c CustomOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clien
 			}
 		}
 	}
-	// 2. do job action
-	params := customSpec.Params
-	if len(params) == 0 {
-		params = []map[string]string{nil}
+	// 2. init the steps status, the steps are executed in ReconcileAction.
+	if len(opsRes.OpsDef.Spec.Steps) > 0 {
+		c.initStepsStatus(opsRes)
+		return nil
 	}
-	for i := range params {
-		job, err := c.buildJob(reqCtx, cli, opsRes, params[i], i)
+	// 3. do job action
+	jobNamePrefix := c.getJobNamePrefix(opsRes.OpsRequest.Name, customSpec.ComponentName)
+	for i, param := range c.getParams(opsRes) {
+		job, err := c.buildJob(reqCtx, cli, opsRes, &opsRes.OpsDef.Spec.JobSpec, param, fmt.Sprintf("%s-%d", jobNamePrefix, i))
 		if err != nil {
 			return err
 		}
@@ -108,16 +110,41 @@ func (c CustomOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clien
 	return nil
 }
 
+// getParams returns the params of the opsRequest, a job is created for each params.
+func (c CustomOpsHandler) getParams(opsRes *OpsResource) []map[string]string {
+	params := opsRes.OpsRequest.Spec.CustomSpec.Params
+	if len(params) == 0 {
+		params = []map[string]string{nil}
+	}
+	return params
+}
+
 func (c CustomOpsHandler) checkExpression(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
 	rule *appsv1alpha1.Rule,
 	compName string) error {
+	matched, err := c.evaluateExpression(reqCtx, cli, opsRes, rule, compName)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return fmt.Errorf(rule.Message)
+	}
+	return nil
+}
+
+// evaluateExpression renders the expression of the rule and returns whether it does not return "false".
+func (c CustomOpsHandler) evaluateExpression(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	rule *appsv1alpha1.Rule,
+	compName string) (bool, error) {
 	opsSpec := opsRes.OpsRequest.Spec
 	componentObjName := constant.GenerateClusterComponentName(opsSpec.ClusterRef, compName)
 	comp := &appsv1alpha1.Component{}
 	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: componentObjName, Namespace: opsRes.OpsRequest.Namespace}, comp); err != nil {
-		return err
+		return false, err
 	}
 	// get the built-in objects and covert the json tag
 	getBuiltInObjs := func() (map[string]interface{}, error) {
@@ -138,20 +165,17 @@ func (c CustomOpsHandler) checkExpression(reqCtx intctrlutil.RequestCtx,
 
 	data, err := getBuiltInObjs()
 	if err != nil {
-		return err
+		return false, err
 	}
 	tmpl, err := template.New("opsDefTemplate").Parse(rule.Expression)
 	if err != nil {
-		return err
+		return false, err
 	}
 	var buf strings.Builder
 	if err = tmpl.Execute(&buf, data); err != nil {
-		return err
-	}
-	if buf.String() == "false" {
-		return fmt.Errorf(rule.Message)
+		return false, err
 	}
-	return nil
+	return buf.String() != "false", nil
 }
 
 func (c CustomOpsHandler) checkExecAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, exec *appsv1alpha1.PreConditionExec) error {
@@ -171,8 +195,9 @@ func (c CustomOpsHandler) getJobNamePrefix(opsName, compName string) string {
 func (c CustomOpsHandler) buildJob(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
+	jobSpecTpl *batchv1.JobSpec,
 	param map[string]string,
-	index int) (*batchv1.Job, error) {
+	jobName string) (*batchv1.Job, error) {
 	var (
 		customSpec  = opsRes.OpsRequest.Spec.CustomSpec
 		compName    = customSpec.ComponentName
@@ -181,7 +206,7 @@ func (c CustomOpsHandler) buildJob(reqCtx intctrlutil.RequestCtx,
 	)
 
 	buildJobSpec := func() (*batchv1.JobSpec, error) {
-		jobSpec := opsRes.OpsDef.Spec.JobSpec
+		jobSpec := jobSpecTpl.DeepCopy()
 		if jobSpec.BackoffLimit == nil {
 			jobSpec.BackoffLimit = pointer.Int32(2)
 		}
@@ -236,7 +261,7 @@ func (c CustomOpsHandler) buildJob(reqCtx intctrlutil.RequestCtx,
 				jobSpec.Template.Spec.ServiceAccountName = saKey.Name
 			}
 		}
-		return &jobSpec, nil
+		return jobSpec, nil
 	}
 
 	jobSpec, err := buildJobSpec()
@@ -250,7 +275,7 @@ func (c CustomOpsHandler) buildJob(reqCtx intctrlutil.RequestCtx,
 				constant.KBAppComponentLabelKey: compName,
 				constant.AppManagedByLabelKey:   constant.AppName,
 			},
-			Name:      fmt.Sprintf("%s-%d", c.getJobNamePrefix(opsName, compName), index),
+			Name:      jobName,
 			Namespace: opsRes.OpsRequest.Namespace,
 		},
 		Spec: *jobSpec,
@@ -266,6 +291,16 @@ func (c CustomOpsHandler) buildJob(reqCtx intctrlutil.RequestCtx,
 // ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
 // the Reconcile function for stop opsRequest.
 func (c CustomOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	if opsRes.OpsDef == nil {
+		opsDef := &appsv1alpha1.OpsDefinition{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRes.OpsRequest.Spec.CustomSpec.OpsDefinitionRef}, opsDef); err != nil {
+			return opsRes.OpsRequest.Status.Phase, 0, err
+		}
+		opsRes.OpsDef = opsDef
+	}
+	if len(opsRes.OpsDef.Spec.Steps) > 0 {
+		return c.reconcileSteps(reqCtx, cli, opsRes)
+	}
 	compName := opsRes.OpsRequest.Spec.CustomSpec.ComponentName
 	opsRequestPhase := opsRes.OpsRequest.Status.Phase
 	jobList := &batchv1.JobList{}
@@ -0,0 +1,438 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+// the steps are checked periodically, since the exec and waitFor steps and the created opsRequests
+// do not trigger the reconciliation of the opsRequest.
+const stepRequeueDuration = 5 * time.Second
+
+// initStepsStatus adds the pending status for the steps which have not been recorded.
+func (c CustomOpsHandler) initStepsStatus(opsRes *OpsResource) {
+	opsRequest := opsRes.OpsRequest
+	recorded := map[string]struct{}{}
+	for _, v := range opsRequest.Status.Steps {
+		recorded[v.Name] = struct{}{}
+	}
+	for _, step := range opsRes.OpsDef.Spec.Steps {
+		if _, ok := recorded[step.Name]; ok {
+			continue
+		}
+		opsRequest.Status.Steps = append(opsRequest.Status.Steps, appsv1alpha1.OpsStepStatus{
+			Name:   step.Name,
+			Status: appsv1alpha1.PendingProgressStatus,
+		})
+	}
+}
+
+// reconcileSteps runs the steps of the OpsDefinition in the order of their dependencies,
+// and returns the Succeed/Failed phase when all the steps are completed.
+func (c CustomOpsHandler) reconcileSteps(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest      = opsRes.OpsRequest
+		opsRequestPhase = opsRequest.Status.Phase
+		oldOpsRequest   = opsRequest.DeepCopy()
+		patch           = client.MergeFrom(oldOpsRequest)
+		steps           = opsRes.OpsDef.Spec.Steps
+		stepStatuses    = map[string]*appsv1alpha1.OpsStepStatus{}
+		completeCount   int
+		failedCount     int
+		err             error
+	)
+	c.initStepsStatus(opsRes)
+	for i := range opsRequest.Status.Steps {
+		stepStatuses[opsRequest.Status.Steps[i].Name] = &opsRequest.Status.Steps[i]
+	}
+	for i := range steps {
+		step := &steps[i]
+		stepStatus := stepStatuses[step.Name]
+		started := false
+		if stepStatus.Status == appsv1alpha1.PendingProgressStatus {
+			if !c.checkStepDependencies(step, stepStatus, stepStatuses) {
+				continue
+			}
+			c.startStep(stepStatus)
+			started = true
+			if step.Exec != nil {
+				// record the attempt before executing the statement, so the statement is executed
+				// only once in an attempt even if the status fails to be patched later.
+				if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+					break
+				}
+				oldOpsRequest = opsRequest.DeepCopy()
+				patch = client.MergeFrom(oldOpsRequest)
+			}
+		}
+		if stepStatus.Status != appsv1alpha1.ProcessingProgressStatus {
+			continue
+		}
+		if step.Exec != nil && !started {
+			// the attempt was recorded but its result was lost, do not execute the statement again.
+			c.failStepAttempt(step, stepStatus, "the result of the statement is unknown since the attempt was interrupted")
+			continue
+		}
+		if err = c.checkStep(reqCtx, cli, opsRes, step, stepStatus); err != nil {
+			break
+		}
+	}
+	for _, v := range opsRequest.Status.Steps {
+		if isCompletedProgressStatus(v.Status) {
+			completeCount += 1
+		}
+		if v.Status == appsv1alpha1.FailedProgressStatus {
+			failedCount += 1
+		}
+	}
+	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completeCount, len(steps))
+	if !reflect.DeepEqual(opsRequest.Status, oldOpsRequest.Status) {
+		if patchErr := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); patchErr != nil {
+			return opsRequestPhase, 0, patchErr
+		}
+	}
+	if err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if completeCount == len(steps) {
+		if failedCount == 0 {
+			return appsv1alpha1.OpsSucceedPhase, 0, nil
+		}
+		return appsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	return opsRequestPhase, stepRequeueDuration, nil
+}
+
+// checkStepDependencies checks if all the steps that the step depends on are succeed.
+// the step will be failed if any of them is failed.
+func (c CustomOpsHandler) checkStepDependencies(step *appsv1alpha1.OpsStep,
+	stepStatus *appsv1alpha1.OpsStepStatus,
+	stepStatuses map[string]*appsv1alpha1.OpsStepStatus) bool {
+	ready := true
+	for _, dep := range step.DependsOn {
+		depStatus, ok := stepStatuses[dep]
+		if !ok {
+			ready = false
+			continue
+		}
+		switch depStatus.Status {
+		case appsv1alpha1.FailedProgressStatus:
+			c.completeStep(stepStatus, appsv1alpha1.FailedProgressStatus, fmt.Sprintf(`skipped since the step "%s" is failed`, dep))
+			return false
+		case appsv1alpha1.SucceedProgressStatus:
+		default:
+			ready = false
+		}
+	}
+	return ready
+}
+
+// startStep starts a new attempt of the step.
+func (c CustomOpsHandler) startStep(stepStatus *appsv1alpha1.OpsStepStatus) {
+	stepStatus.Attempts += 1
+	stepStatus.Status = appsv1alpha1.ProcessingProgressStatus
+	stepStatus.StartTime = metav1.Now()
+}
+
+// completeStep marks the step as completed with the status.
+func (c CustomOpsHandler) completeStep(stepStatus *appsv1alpha1.OpsStepStatus, status appsv1alpha1.ProgressStatus, message string) {
+	stepStatus.Status = status
+	stepStatus.Message = message
+	stepStatus.EndTime = metav1.Now()
+}
+
+// failStepAttempt fails the current attempt of the step, the step will be retried in the next
+// reconciliation if the retries are not exhausted.
+func (c CustomOpsHandler) failStepAttempt(step *appsv1alpha1.OpsStep, stepStatus *appsv1alpha1.OpsStepStatus, message string) {
+	if stepStatus.Attempts <= step.Retries {
+		stepStatus.Status = appsv1alpha1.PendingProgressStatus
+		stepStatus.Message = fmt.Sprintf("attempt %d failed: %s", stepStatus.Attempts, message)
+		return
+	}
+	c.completeStep(stepStatus, appsv1alpha1.FailedProgressStatus, message)
+}
+
+// checkStep executes the action of the step and checks if the current attempt is completed.
+func (c CustomOpsHandler) checkStep(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	step *appsv1alpha1.OpsStep,
+	stepStatus *appsv1alpha1.OpsStepStatus) error {
+	if step.Timeout != nil && time.Since(stepStatus.StartTime.Time) > step.Timeout.Duration {
+		c.failStepAttempt(step, stepStatus, fmt.Sprintf("timed out after %s", step.Timeout.Duration))
+		return nil
+	}
+	var (
+		completed bool
+		message   string
+		err       error
+	)
+	switch {
+	case step.Job != nil:
+		completed, message, err = c.checkJobStep(reqCtx, cli, opsRes, step, stepStatus)
+	case step.Exec != nil:
+		completed, err = true, c.checkExecStep(reqCtx, cli, opsRes, step.Exec)
+	case step.WaitFor != nil:
+		completed, err = c.evaluateExpression(reqCtx, cli, opsRes, step.WaitFor, opsRes.OpsRequest.Spec.CustomSpec.ComponentName)
+		if !completed {
+			message = step.WaitFor.Message
+		}
+	case step.Ops != nil:
+		completed, message, err = c.checkOpsStep(reqCtx, cli, opsRes, step, stepStatus)
+	}
+	switch {
+	case intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal):
+		c.failStepAttempt(step, stepStatus, err.Error())
+	case err != nil:
+		// the errors of the template and lorry are treated as the failure of the attempt,
+		// and the api errors are returned to retry the reconciliation.
+		if _, ok := err.(apierrors.APIStatus); ok {
+			return err
+		}
+		c.failStepAttempt(step, stepStatus, err.Error())
+	case completed:
+		c.completeStep(stepStatus, appsv1alpha1.SucceedProgressStatus, message)
+	default:
+		stepStatus.Message = message
+	}
+	return nil
+}
+
+// getStepObjectName returns the name of the object created by the step, the prefix is truncated to keep
+// the name within 63 characters, and the trailing dashes of the truncated prefix are trimmed.
+func (c CustomOpsHandler) getStepObjectName(prefix, suffix string) string {
+	if len(prefix)+len(suffix) > 63 {
+		prefix = strings.TrimRight(prefix[:63-len(suffix)], "-")
+	}
+	return prefix + suffix
+}
+
+// hasDisruptiveOpsSteps checks if any step of the custom opsRequest runs a disruptive built-in operation.
+func hasDisruptiveOpsSteps(opsRes *OpsResource) bool {
+	if opsRes.OpsDef == nil {
+		return false
+	}
+	for _, step := range opsRes.OpsDef.Spec.Steps {
+		if step.Ops == nil {
+			continue
+		}
+		if behaviour, ok := GetOpsManager().OpsMap[step.Ops.Type]; ok && behaviour.IsDisruptive {
+			return true
+		}
+	}
+	return false
+}
+
+// isStepOpsRequest checks if the opsRequest is created by a step of a custom opsRequest.
+func isStepOpsRequest(opsRequest *appsv1alpha1.OpsRequest) bool {
+	owner := metav1.GetControllerOf(opsRequest)
+	return owner != nil && owner.Kind == constant.OpsRequestKind
+}
+
+// checkJobStep creates a job for each params in the current attempt and checks if all the jobs are completed.
+// message is returned if a job is failed.
+func (c CustomOpsHandler) checkJobStep(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	step *appsv1alpha1.OpsStep,
+	stepStatus *appsv1alpha1.OpsStepStatus) (bool, string, error) {
+	var (
+		opsRequest    = opsRes.OpsRequest
+		jobNamePrefix = c.getJobNamePrefix(opsRequest.Name, opsRequest.Spec.CustomSpec.ComponentName)
+		completed     = true
+	)
+	for i, param := range c.getParams(opsRes) {
+		jobName := c.getStepObjectName(jobNamePrefix, fmt.Sprintf("-%s-%d-%d", step.Name, stepStatus.Attempts, i))
+		job := &batchv1.Job{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: jobName, Namespace: opsRequest.Namespace}, job); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return false, "", err
+			}
+			if job, err = c.buildJob(reqCtx, cli, opsRes, c.buildStepJobSpec(step), param, jobName); err != nil {
+				return false, "", err
+			}
+			if err = cli.Create(reqCtx.Ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+				return false, "", err
+			}
+		}
+		jobCompleted := false
+		for _, v := range job.Status.Conditions {
+			if v.Status != corev1.ConditionTrue {
+				continue
+			}
+			if v.Type == batchv1.JobFailed {
+				return false, "", intctrlutil.NewFatalError(fmt.Sprintf(`job "%s" is failed: %s: %s`, jobName, v.Reason, v.Message))
+			}
+			if v.Type == batchv1.JobComplete {
+				jobCompleted = true
+			}
+		}
+		if !jobCompleted {
+			completed = false
+		}
+	}
+	if !completed {
+		return false, "waiting for the jobs to complete", nil
+	}
+	return true, "", nil
+}
+
+// buildStepJobSpec builds the job spec template with the container of the job step.
+func (c CustomOpsHandler) buildStepJobSpec(step *appsv1alpha1.OpsStep) *batchv1.JobSpec {
+	return &batchv1.JobSpec{
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:      step.Name,
+						Image:     step.Job.Image,
+						Command:   step.Job.Command,
+						Env:       step.Job.Env,
+						Resources: step.Job.Resources,
+					},
+				},
+				RestartPolicy: corev1.RestartPolicyNever,
+			},
+		},
+	}
+}
+
+// checkExecStep executes the statement in the target pod by lorry.
+func (c CustomOpsHandler) checkExecStep(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	exec *appsv1alpha1.OpsStepExec) error {
+	var (
+		compName = opsRes.OpsRequest.Spec.CustomSpec.ComponentName
+		podList  *corev1.PodList
+		err      error
+	)
+	if exec.TargetPodRole != "" {
+		podList, err = component.GetComponentPodListWithRole(reqCtx.Ctx, cli, *opsRes.Cluster, compName, exec.TargetPodRole)
+	} else {
+		podList, err = component.GetComponentPodList(reqCtx.Ctx, cli, *opsRes.Cluster, compName)
+	}
+	if err != nil {
+		return err
+	}
+	var targetPod *corev1.Pod
+	for i := range podList.Items {
+		if intctrlutil.IsAvailable(&podList.Items[i], 0) {
+			targetPod = &podList.Items[i]
+			break
+		}
+	}
+	if targetPod == nil {
+		return intctrlutil.NewFatalError("can not find any available pod for the component " + compName)
+	}
+	lorryCli, err := lorry.NewClient(*targetPod)
+	if err != nil {
+		return err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return intctrlutil.NewFatalError("lorry is not available in the pod " + targetPod.Name)
+	}
+	return lorryCli.Exec(reqCtx.Ctx, exec.Statement)
+}
+
+// checkOpsStep creates the opsRequest of the built-in operation in the current attempt and checks if it is completed.
+func (c CustomOpsHandler) checkOpsStep(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	step *appsv1alpha1.OpsStep,
+	stepStatus *appsv1alpha1.OpsStepStatus) (bool, string, error) {
+	opsRequest := opsRes.OpsRequest
+	opsName := c.getStepObjectName(opsRequest.Name, fmt.Sprintf("-%s-%d", step.Name, stepStatus.Attempts))
+	stepOps := &appsv1alpha1.OpsRequest{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsName, Namespace: opsRequest.Namespace}, stepOps); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, "", err
+		}
+		if stepOps, err = c.buildStepOpsRequest(cli, opsRes, step.Ops, opsName); err != nil {
+			return false, "", err
+		}
+		if err = cli.Create(reqCtx.Ctx, stepOps); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, "", err
+		}
+	}
+	switch stepOps.Status.Phase {
+	case appsv1alpha1.OpsSucceedPhase:
+		return true, "", nil
+	case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+		return false, "", intctrlutil.NewFatalError(fmt.Sprintf(`opsRequest "%s" is %s`, opsName, stepOps.Status.Phase))
+	default:
+		return false, fmt.Sprintf(`waiting for opsRequest "%s" to complete`, opsName), nil
+	}
+}
+
+func (c CustomOpsHandler) buildStepOpsRequest(cli client.Client, opsRes *OpsResource, stepOps *appsv1alpha1.OpsStepOps, opsName string) (*appsv1alpha1.OpsRequest, error) {
+	var (
+		opsRequest = opsRes.OpsRequest
+		compOps    = appsv1alpha1.ComponentOps{ComponentName: opsRequest.Spec.CustomSpec.ComponentName}
+	)
+	ops := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opsName,
+			Namespace: opsRequest.Namespace,
+			Labels: map[string]string{
+				constant.OpsRequestNameLabelKey: opsRequest.Name,
+				constant.AppManagedByLabelKey:   constant.AppName,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterRef: opsRequest.Spec.ClusterRef,
+			Type:       stepOps.Type,
+		},
+	}
+	switch stepOps.Type {
+	case appsv1alpha1.RestartType:
+		ops.Spec.RestartList = []appsv1alpha1.ComponentOps{compOps}
+	case appsv1alpha1.SwitchoverType:
+		instanceName := stepOps.InstanceName
+		if instanceName == "" {
+			instanceName = "*"
+		}
+		ops.Spec.SwitchoverList = []appsv1alpha1.Switchover{{ComponentOps: compOps, InstanceName: instanceName}}
+	case appsv1alpha1.StartType, appsv1alpha1.StopType:
+	default:
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the ops type "%s" is not supported in the steps`, stepOps.Type))
+	}
+	if err := utils.SetControllerReference(opsRequest, ops, cli.Scheme()); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
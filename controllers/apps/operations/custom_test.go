@@ -20,10 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"strings"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -205,5 +209,83 @@ var _ = Describe("CustomOps", func() {
 			Expect(opsResource.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
 		})
 
+		It("Test custom ops with steps", func() {
+			By("change the OpsDefinition to run a job step and a waitFor step which depends on it")
+			Expect(testapps.ChangeObj(&testCtx, opsDef, func(obj *appsv1alpha1.OpsDefinition) {
+				obj.Spec.Steps = []appsv1alpha1.OpsStep{
+					{
+						Name:      "wait",
+						WaitFor:   &appsv1alpha1.Rule{Expression: `{{ eq .component.status.phase "Running" }}`, Message: "waiting for component"},
+						DependsOn: []string{"sql"},
+					},
+					{
+						Name: "sql",
+						Job: &appsv1alpha1.OpsStepJob{
+							Image:   obj.Spec.JobSpec.Template.Spec.Containers[0].Image,
+							Command: obj.Spec.JobSpec.Template.Spec.Containers[0].Command,
+						},
+					},
+				}
+			})).Should(Succeed())
+			opsResource.OpsDef = opsDef
+
+			ops := createCustomOps(consensusComp, []map[string]string{{"sql": "select 1"}})
+			opsResource.OpsRequest = ops
+			_, _ = GetOpsManager().Do(reqCtx, k8sClient, opsResource)
+			Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+
+			By("do ops action, expect the steps to be pending")
+			patchComponentPhase(appsv1alpha1.RunningClusterCompPhase)
+			_, _ = GetOpsManager().Do(reqCtx, k8sClient, opsResource)
+			Expect(ops.Status.Steps).Should(HaveLen(2))
+			ops.Status.Phase = appsv1alpha1.OpsRunningPhase
+
+			By("reconcile the ops, expect the job of the step without dependencies to be created")
+			_, _ = GetOpsManager().Reconcile(reqCtx, k8sClient, opsResource)
+			jobList := &batchv1.JobList{}
+			Expect(k8sClient.List(ctx, jobList, client.MatchingLabels{constant.OpsRequestNameLabelKey: ops.Name},
+				client.InNamespace(ops.Namespace))).Should(Succeed())
+			Expect(jobList.Items).Should(HaveLen(1))
+			Expect(ops.Status.Steps[0].Status).Should(Equal(appsv1alpha1.PendingProgressStatus))
+			Expect(ops.Status.Steps[1].Status).Should(Equal(appsv1alpha1.ProcessingProgressStatus))
+
+			By("mock the job is completed, expect the waitFor step is done and the ops phase is Succeed")
+			patchJobPhase(&jobList.Items[0], batchv1.JobComplete)
+			_, _ = GetOpsManager().Reconcile(reqCtx, k8sClient, opsResource)
+			Expect(ops.Status.Progress).Should(Equal("2/2"))
+			Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+		})
+
+		It("Test custom ops with a disruptive ops step", func() {
+			By("change the OpsDefinition to run a Restart step")
+			Expect(testapps.ChangeObj(&testCtx, opsDef, func(obj *appsv1alpha1.OpsDefinition) {
+				obj.Spec.Steps = []appsv1alpha1.OpsStep{
+					{
+						Name: "restart",
+						Ops:  &appsv1alpha1.OpsStepOps{Type: appsv1alpha1.RestartType},
+					},
+				}
+			})).Should(Succeed())
+			opsResource.OpsDef = opsDef
+			Expect(hasDisruptiveOpsSteps(opsResource)).Should(BeTrue())
+
+			By("expect the name of the step opsRequest to be truncated without a trailing dash")
+			handler := CustomOpsHandler{}
+			opsName := handler.getStepObjectName(strings.Repeat("a", 51)+"-"+strings.Repeat("b", 10), "-restart-0")
+			Expect(len(opsName)).Should(BeNumerically("<=", 63))
+			Expect(opsName).Should(Equal(strings.Repeat("a", 51) + "-restart-0"))
+
+			By("expect the step opsRequest not to wait for the maintenance windows of the cluster")
+			cluster.Spec.MaintenanceWindows = []appsv1alpha1.MaintenanceWindow{
+				{StartTime: "00:00", Duration: metav1.Duration{Duration: time.Minute}},
+			}
+			ops := createCustomOps(consensusComp, []map[string]string{{"sql": "select 1"}})
+			opsResource.OpsRequest = ops
+			Expect(getMaintenanceWindows(opsResource)).Should(HaveLen(1))
+			stepOps, err := handler.buildStepOpsRequest(k8sClient, opsResource, opsDef.Spec.Steps[0].Ops, opsName)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(isStepOpsRequest(stepOps)).Should(BeTrue())
+			Expect(getMaintenanceWindows(&OpsResource{Cluster: cluster, OpsRequest: stepOps})).Should(BeEmpty())
+		})
 	})
 })
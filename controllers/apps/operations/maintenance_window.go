@@ -0,0 +1,86 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// getMaintenanceWindows gets the maintenance windows of the opsRequest,
+// the maintenance windows of the cluster are used if the opsRequest does not specify.
+func getMaintenanceWindows(opsRes *OpsResource) []appsv1alpha1.MaintenanceWindow {
+	// the rollback opsRequest starts immediately to recover the cluster.
+	if isRollbackOpsRequest(opsRes.OpsRequest) {
+		return nil
+	}
+	// the opsRequest of a step starts once the step runs, the custom opsRequest has waited for
+	// the maintenance windows before it started.
+	if isStepOpsRequest(opsRes.OpsRequest) {
+		return nil
+	}
+	if len(opsRes.OpsRequest.Spec.MaintenanceWindows) > 0 {
+		return opsRes.OpsRequest.Spec.MaintenanceWindows
+	}
+	if opsRes.Cluster == nil {
+		return nil
+	}
+	return opsRes.Cluster.Spec.MaintenanceWindows
+}
+
+// waitForMaintenanceWindow checks if the opsRequest is in the maintenance windows. If not, it patches the
+// WaitForMaintenanceWindow condition to the opsRequest and returns the duration until the next window opens.
+// Once the window opens, the condition is set to False.
+func waitForMaintenanceWindow(ctx context.Context,
+	cli client.Client,
+	opsRes *OpsResource,
+	now time.Time) (time.Duration, error) {
+	windows := getMaintenanceWindows(opsRes)
+	if len(windows) == 0 {
+		return 0, nil
+	}
+	start, _, ok := appsv1alpha1.GetMaintenanceWindowRange(windows, now)
+	if !ok {
+		return 0, intctrlutil.NewFatalError("no valid maintenance window is found")
+	}
+	opsRequest := opsRes.OpsRequest
+	if !start.After(now) {
+		if !meta.IsStatusConditionTrue(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypeMaintenanceWindow) {
+			return 0, nil
+		}
+		condition := appsv1alpha1.NewMaintenanceWindowOpenedCondition(opsRequest)
+		return 0, PatchOpsStatus(ctx, cli, opsRes, opsRequest.Status.Phase, condition)
+	}
+	condition := appsv1alpha1.NewWaitForMaintenanceWindowCondition(opsRequest, start)
+	// only patch the condition when the next window changes to avoid repeated events.
+	oldCondition := meta.FindStatusCondition(opsRequest.Status.Conditions, condition.Type)
+	if oldCondition == nil || oldCondition.Message != condition.Message {
+		if err := PatchOpsStatus(ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase, condition); err != nil {
+			return 0, err
+		}
+	}
+	return start.Sub(now), nil
+}
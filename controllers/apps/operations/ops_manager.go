@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -73,6 +76,19 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		if opsRequest.Spec.Cancel {
 			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase)
 		}
+		// disruptive operations can only start in the maintenance windows, so do the custom operations
+		// with the disruptive steps.
+		if opsBehaviour.IsDisruptive || hasDisruptiveOpsSteps(opsRes) {
+			requeueAfter, err := waitForMaintenanceWindow(reqCtx.Ctx, cli, opsRes, time.Now())
+			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+			} else if err != nil {
+				return nil, err
+			}
+			if requeueAfter > 0 {
+				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the maintenance window"))
+			}
+		}
 		// validate entry condition for OpsRequest, check if the cluster is in the right phase
 		if err = validateOpsWaitingPhase(opsRes.Cluster, opsRequest, opsBehaviour); err != nil {
 			// check if the error is caused by WaitForClusterPhaseErr  error
@@ -81,6 +97,13 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 			}
 			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		}
+		// the canary is rejected if it can not be enforced for the workloads.
+		if err = validateCanary(reqCtx, cli, opsRes); err != nil {
+			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+			}
+			return nil, err
+		}
 		if opsBehaviour.ToClusterPhase != "" {
 			// if ToClusterPhase is not empty, enqueue OpsRequest to the cluster Annotation.
 			opsRecordeSlice, err := enqueueOpsRequestToClusterAnnotation(reqCtx.Ctx, cli, opsRes, opsBehaviour)
@@ -104,9 +127,16 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		return &ctrl.Result{}, patchOpsRequestToCreating(reqCtx, cli, opsRes, opsDeepCopy, opsBehaviour.OpsHandler)
 	}
 
+	// limit the number of updated pods to the canary replicas before rolling the workloads.
+	if err = setupCanary(reqCtx, cli, opsRes); err != nil {
+		return nil, err
+	}
 	if err = opsBehaviour.OpsHandler.Action(reqCtx, cli, opsRes); err != nil {
 		// patch the status.phase to Failed when the error is Fatal, which means the operation is failed and there is no need to retry
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			if cleanupErr := cleanupCanary(reqCtx, cli, opsRes); cleanupErr != nil {
+				return nil, cleanupErr
+			}
 			return &ctrl.Result{}, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
 		}
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeNeedWaiting) {
@@ -133,18 +163,53 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 		return 0, PatchOpsHandlerNotSupported(reqCtx.Ctx, cli, opsRes)
 	}
 	opsRes.ToClusterPhase = opsBehaviour.ToClusterPhase
+	canaryRequeueAfter, err := reconcileCanary(reqCtx, cli, opsRes)
+	if err != nil {
+		return 0, err
+	}
+	if isCanaryHalted(opsRequest) {
+		if err = cleanupCanary(reqCtx, cli, opsRes); err != nil {
+			return 0, err
+		}
+		return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsFailedPhase,
+			appsv1alpha1.NewFailedCondition(opsRequest, errors.New(opsRequest.Status.Canary.Message)))
+	}
 	if opsRequestPhase, requeueAfter, err = opsBehaviour.OpsHandler.ReconcileAction(reqCtx, cli, opsRes); err != nil &&
 		!isOpsRequestFailedPhase(opsRequestPhase) {
 		// if the opsRequest phase is not failed, skipped
 		return requeueAfter, err
 	}
+	if canaryRequeueAfter > 0 && (requeueAfter == 0 || canaryRequeueAfter < requeueAfter) {
+		requeueAfter = canaryRequeueAfter
+	}
+	// the opsRequest is considered as failed if it is not completed before the health gate times out.
+	if !opsRequest.IsComplete(opsRequestPhase) && opsRequest.Status.Phase != appsv1alpha1.OpsCancellingPhase {
+		if remaining, ok := getHealthGateRemaining(opsRequest, time.Now()); ok && remaining <= 0 {
+			opsRequestPhase = appsv1alpha1.OpsFailedPhase
+			err = fmt.Errorf("the OpsRequest is not completed within the health gate timeout %s",
+				opsRequest.Spec.RollbackPolicy.HealthGateTimeout.Duration)
+		} else if ok && (requeueAfter == 0 || remaining < requeueAfter) {
+			requeueAfter = remaining
+		}
+	}
 	switch opsRequestPhase {
 	case appsv1alpha1.OpsSucceedPhase:
 		if opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+			if err = cleanupCanary(reqCtx, cli, opsRes); err != nil {
+				return 0, err
+			}
 			return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase, appsv1alpha1.NewCancelSucceedCondition(opsRequest.Name))
 		}
-		return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequestPhase, appsv1alpha1.NewSucceedCondition(opsRequest))
+		opsDeepCopy := opsRequest.DeepCopy()
+		if opsRequest.Status.Canary != nil {
+			opsRequest.Status.Canary.Phase = appsv1alpha1.CanaryCompletedPhase
+			opsRequest.Status.Canary.LastTransitionTime = metav1.Now()
+		}
+		return 0, PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, opsRequestPhase, appsv1alpha1.NewSucceedCondition(opsRequest))
 	case appsv1alpha1.OpsFailedPhase:
+		if cleanupErr := cleanupCanary(reqCtx, cli, opsRes); cleanupErr != nil {
+			return 0, cleanupErr
+		}
 		if opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 			return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase, appsv1alpha1.NewCancelFailedCondition(opsRequest, err))
 		}
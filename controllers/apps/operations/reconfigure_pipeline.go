@@ -20,11 +20,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"reflect"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -94,7 +100,7 @@ func (p *pipeline) Validate() *pipeline {
 
 func (p *pipeline) ConfigConstraints() *pipeline {
 	validateFn := func() (err error) {
-		if !hasFileUpdate(p.config) {
+		if !hasFileUpdate(p.config) && p.config.RollbackToRevision == "" {
 			p.isFailed = true
 			err = cfgcore.MakeError(
 				"current configSpec not support reconfigure, configSpec: %v",
@@ -120,6 +126,47 @@ func (p *pipeline) ConfigConstraints() *pipeline {
 	})
 }
 
+// doRollbackImpl restores the config file params to the revision in the revision history.
+func (p *pipeline) doRollbackImpl() error {
+	newConfigObj := p.ConfigurationObj.DeepCopy()
+
+	item := newConfigObj.Spec.GetConfigurationItem(p.config.Name)
+	if item == nil {
+		return cfgcore.MakeError("not found config item: %s", p.config.Name)
+	}
+
+	revisionCM := &corev1.ConfigMap{}
+	revisionKey := client.ObjectKey{
+		Namespace: p.ConfigMapObj.Namespace,
+		Name:      cfgcore.GenerateConfigRevisionName(p.ConfigMapObj.Name, p.config.RollbackToRevision),
+	}
+	if err := p.cli.Get(p.reqCtx.Ctx, revisionKey, revisionCM); err != nil {
+		if apierrors.IsNotFound(err) {
+			p.isFailed = true
+			return cfgcore.MakeError("not found revision %s of config %s", p.config.RollbackToRevision, p.config.Name)
+		}
+		return err
+	}
+
+	var formatter *appsv1alpha1.FormatterConfig
+	if p.configConstraint != nil {
+		formatter = p.configConstraint.Spec.FormatterConfig
+	}
+	params, err := configuration.RollbackConfigFileParams(item.ConfigFileParams, revisionCM, formatter)
+	if err != nil {
+		p.isFailed = true
+		return err
+	}
+	for _, param := range params {
+		if param.Content != nil {
+			p.isFileUpdated = true
+		}
+	}
+	item.ConfigFileParams = params
+	p.updatedObject = newConfigObj
+	return p.createUpdatePatch(item, p.configSpec)
+}
+
 func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error {
 	newConfigObj := p.ConfigurationObj.DeepCopy()
 
@@ -180,6 +227,9 @@ func (p *pipeline) doMerge() error {
 		return cfgcore.MakeError("not found config: %s",
 			cfgcore.GenerateComponentConfigurationName(p.clusterName, p.componentName))
 	}
+	if p.config.RollbackToRevision != "" {
+		return p.doRollbackImpl()
+	}
 	return p.doMergeImpl(p.config)
 }
 
@@ -208,6 +258,16 @@ func (p *pipeline) UpdateOpsLabel() *pipeline {
 
 func (p *pipeline) Sync() *pipeline {
 	return p.Wrap(func() error {
+		// record the OpsRequest and the revision it generates, the revision history refers to it.
+		if !reflect.DeepEqual(p.updatedObject.Spec, p.ConfigurationObj.Spec) {
+			annotations := p.updatedObject.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[constant.LastAppliedOpsCRAnnotationKey] = p.resource.OpsRequest.Name
+			annotations[constant.LastAppliedOpsRevisionAnnotationKey] = strconv.FormatInt(p.ConfigurationObj.GetGeneration()+1, 10)
+			p.updatedObject.SetAnnotations(annotations)
+		}
 		return p.Client.Patch(p.reqCtx.Ctx, p.updatedObject, client.MergeFrom(p.ConfigurationObj))
 	})
 }
@@ -46,6 +46,7 @@ func init() {
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		OpsHandler:        restartOpsHandler{},
+		IsDisruptive:      true,
 	}
 
 	opsMgr := GetOpsManager()
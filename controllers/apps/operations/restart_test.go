@@ -20,14 +20,20 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 )
 
@@ -93,6 +99,88 @@ var _ = Describe("Restart OpsRequest", func() {
 			Expect(err == nil).Should(BeTrue())
 		})
 
+		It("Test restart OpsRequest with canary", func() {
+			By("create Restart opsRequest with canary")
+			rsm := testapps.MockRSMComponent(&testCtx, clusterName, consensusComp)
+			Expect(testapps.ChangeObj(&testCtx, rsm, func(obj *workloads.ReplicatedStateMachine) {
+				updateStrategy := workloads.SerialUpdateStrategy
+				obj.Spec.MemberUpdateStrategy = &updateStrategy
+			})).Should(Succeed())
+			ops := testapps.NewOpsRequestObj("restart-canary-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+			ops.Spec.Canary = &appsv1alpha1.CanaryPolicy{Replicas: 1, FailureThreshold: 1}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			mockComponentIsOperating(opsRes.Cluster, appsv1alpha1.UpdatingClusterCompPhase, consensusComp)
+
+			By("expect the updated replicas of the rsm is limited to the canary replicas")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Canary.Phase).Should(Equal(appsv1alpha1.CanaryProgressingPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(rsm), func(g Gomega, fetched *workloads.ReplicatedStateMachine) {
+				g.Expect(fetched.Annotations[constant.MaxUpdatedReplicasAnnotationKey]).Should(Equal("1"))
+			})).Should(Succeed())
+
+			By("mock the canary pods are updated and healthy")
+			mockLorryCli := lorry.NewMockClient(gomock.NewController(GinkgoT()))
+			lorry.SetMockClient(mockLorryCli, nil)
+			defer lorry.UnsetMockClient()
+			sts := testapps.MockConsensusComponentStatefulSet(&testCtx, clusterName, consensusComp)
+			sts.Status.UpdateRevision = "canary-revision"
+			Expect(testapps.ChangeObjStatus(&testCtx, rsm, func() {
+				rsm.Status.UpdateRevision = sts.Status.UpdateRevision
+			})).Should(Succeed())
+			pods := testapps.MockConsensusComponentPods(&testCtx, sts, clusterName, consensusComp)
+			mockLorryCli.EXPECT().CheckStatus(gomock.Any()).Return(nil).Times(2 * len(pods))
+
+			By("expect the opsRequest is paused after the canary pods are healthy")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Canary.Phase).Should(Equal(appsv1alpha1.CanaryPausedPhase))
+			Expect(opsRes.OpsRequest.Status.Canary.UpdatedPods).Should(HaveLen(len(pods)))
+
+			By("expect the limit is removed after the opsRequest is resumed")
+			opsRes.OpsRequest.Spec.Canary.Resume = true
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Canary.Phase).Should(Equal(appsv1alpha1.CanaryPromotingPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(rsm), func(g Gomega, fetched *workloads.ReplicatedStateMachine) {
+				g.Expect(fetched.Annotations).ShouldNot(HaveKey(constant.MaxUpdatedReplicasAnnotationKey))
+			})).Should(Succeed())
+
+			By("expect the update is halted and the limit is removed when the health checks of the updated pods failed")
+			mockLorryCli.EXPECT().CheckStatus(gomock.Any()).Return(fmt.Errorf("status check failed")).AnyTimes()
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Canary.Phase).Should(Equal(appsv1alpha1.CanaryHaltedPhase))
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(rsm), func(g Gomega, fetched *workloads.ReplicatedStateMachine) {
+				g.Expect(fetched.Annotations).ShouldNot(HaveKey(constant.MaxUpdatedReplicasAnnotationKey))
+			})).Should(Succeed())
+		})
+
+		It("Test restart OpsRequest with canary which can not be enforced", func() {
+			By("create Restart opsRequest with canary for the rsm w/o member update strategy")
+			rsm := testapps.MockRSMComponent(&testCtx, clusterName, consensusComp)
+			ops := testapps.NewOpsRequestObj("restart-canary-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+			ops.Spec.Canary = &appsv1alpha1.CanaryPolicy{Replicas: 1}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+
+			By("expect the opsRequest fails")
+			_, _ = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(rsm), func(g Gomega, fetched *workloads.ReplicatedStateMachine) {
+				g.Expect(fetched.Annotations).ShouldNot(HaveKey(constant.MaxUpdatedReplicasAnnotationKey))
+			})).Should(Succeed())
+		})
+
 		It("expect failed when cluster is stopped", func() {
 			By("mock cluster is stopped")
 			Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
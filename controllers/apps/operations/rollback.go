@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// getHealthGateRemaining returns the remaining duration before the health gate of the rollbackPolicy times out,
+// the remaining duration is not positive if it has timed out.
+func getHealthGateRemaining(opsRequest *appsv1alpha1.OpsRequest, now time.Time) (time.Duration, bool) {
+	rollbackPolicy := opsRequest.Spec.RollbackPolicy
+	if rollbackPolicy == nil || rollbackPolicy.HealthGateTimeout == nil || opsRequest.Status.StartTimestamp.IsZero() {
+		return 0, false
+	}
+	return opsRequest.Status.StartTimestamp.Add(rollbackPolicy.HealthGateTimeout.Duration).Sub(now), true
+}
+
+// isRollbackOpsRequest checks if the opsRequest is created to roll back another opsRequest.
+func isRollbackOpsRequest(opsRequest *appsv1alpha1.OpsRequest) bool {
+	_, ok := opsRequest.Annotations[constant.OpsRequestRollbackForAnnotationKey]
+	return ok
+}
+
+// getRollbackOpsRequestName returns the name of the rollback opsRequest, the name of the failed opsRequest
+// is truncated and suffixed with its hash if the name exceeds 63 characters.
+func getRollbackOpsRequestName(opsName string) string {
+	const suffix = "-rollback"
+	if len(opsName)+len(suffix) <= validation.DNS1123LabelMaxLength {
+		return opsName + suffix
+	}
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(opsName))
+	hash := rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+	prefix := opsName[:validation.DNS1123LabelMaxLength-len(suffix)-len(hash)-1]
+	return fmt.Sprintf("%s-%s%s", prefix, hash, suffix)
+}
+
+// Rollback creates the inverse opsRequest to roll back the failed opsRequest if its rollbackPolicy is specified,
+// and records the rollback in the status.
+func (opsMgr *OpsManager) Rollback(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.RollbackPolicy == nil || opsRequest.Status.Rollback != nil ||
+		opsRequest.Status.Phase != appsv1alpha1.OpsFailedPhase {
+		return nil
+	}
+	opsBehaviour, ok := opsMgr.OpsMap[opsRequest.Spec.Type]
+	if !ok || opsBehaviour.RollbackFunc == nil {
+		return nil
+	}
+	rollbackSpec, err := opsBehaviour.RollbackFunc(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	rollback := &appsv1alpha1.OpsRequestRollbackStatus{StartTimestamp: metav1.Now()}
+	if rollbackSpec == nil {
+		rollback.Message = "nothing to roll back, the cluster is already in the last configuration"
+	} else {
+		rollbackOps := &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      getRollbackOpsRequestName(opsRequest.Name),
+				Namespace: opsRequest.Namespace,
+				Annotations: map[string]string{
+					constant.OpsRequestRollbackForAnnotationKey: opsRequest.Name,
+				},
+			},
+			Spec: *rollbackSpec,
+		}
+		rollbackOps.Spec.TTLSecondsAfterSucceed = opsRequest.Spec.TTLSecondsAfterSucceed
+		if err = cli.Create(reqCtx.Ctx, rollbackOps); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		rollback.OpsRequestName = rollbackOps.Name
+		rollback.Message = "roll back to the last configuration"
+	}
+	opsRequest.Status.Rollback = rollback
+	condition := appsv1alpha1.NewRollbackCondition(opsRequest)
+	opsRequest.SetStatusCondition(*condition)
+	if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+		return err
+	}
+	if opsRes.Recorder != nil {
+		opsRes.Recorder.Event(opsRequest, corev1.EventTypeNormal, condition.Reason, condition.Message)
+	}
+	return nil
+}
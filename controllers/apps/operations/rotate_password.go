@@ -0,0 +1,169 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type rotatePasswordOpsHandler struct{}
+
+// rotatePasswordTimeout is the max duration to wait for the passwords to be rotated, the failed rotations
+// are retried by the component controller until then.
+const rotatePasswordTimeout = 10 * time.Minute
+
+var _ OpsHandler = rotatePasswordOpsHandler{}
+
+func init() {
+	// ToClusterPhase is not defined, because the password rotation does not affect the cluster status.
+	rotatePasswordBehaviour := OpsBehaviour{
+		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.RunningClusterPhase},
+		OpsHandler:        rotatePasswordOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.RotatePasswordType, rotatePasswordBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the rotate password request.
+func (r rotatePasswordOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewRotatePasswordCondition(opsRes.OpsRequest), nil
+}
+
+// Action requests the component controller to rotate the passwords by annotating the account secrets,
+// the passwords are rotated by the component controller.
+func (r rotatePasswordOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	secrets, err := r.getAccountSecrets(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		return intctrlutil.NewFatalError("no system account found to rotate the password")
+	}
+	for _, secret := range secrets {
+		if secret.Immutable != nil && *secret.Immutable {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the password of account secret "%s" is not rotatable, `+
+				"the passwordRotationPolicy of the system account is required", secret.Name))
+		}
+	}
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Annotations[constant.PasswordRotationRequestAnnotationKey] == opsRes.OpsRequest.Name {
+			continue
+		}
+		patch := client.MergeFrom(secret.DeepCopy())
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[constant.PasswordRotationRequestAnnotationKey] = opsRes.OpsRequest.Name
+		if err = cli.Patch(reqCtx.Ctx, secret, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the OpsRequest succeeds when the passwords of all the account secrets are rotated for it.
+func (r rotatePasswordOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	secrets, err := r.getAccountSecrets(reqCtx, cli, opsRes)
+	if err != nil {
+		return "", 0, err
+	}
+	rotatedCount := 0
+	for _, secret := range secrets {
+		if secret.Annotations[constant.PasswordRotatedForAnnotationKey] == opsRes.OpsRequest.Name {
+			rotatedCount++
+		}
+	}
+
+	opsRequest := opsRes.OpsRequest
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", rotatedCount, len(secrets))
+	if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+		return "", 0, err
+	}
+	if rotatedCount == len(secrets) {
+		return appsv1alpha1.OpsSucceedPhase, 0, nil
+	}
+	if !opsRequest.Status.StartTimestamp.IsZero() && time.Since(opsRequest.Status.StartTimestamp.Time) > rotatePasswordTimeout {
+		if err = r.cancelRotation(reqCtx, cli, opsRequest.Name, secrets); err != nil {
+			return "", 0, err
+		}
+		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("the passwords are not rotated in %s, "+
+			"please check the RotatePasswordFailed events of the components", rotatePasswordTimeout)
+	}
+	return appsv1alpha1.OpsRunningPhase, 5 * time.Second, nil
+}
+
+// cancelRotation removes the rotation request from the account secrets whose passwords are not rotated yet,
+// the pending passwords which have been generated are still applied by the component controller.
+func (r rotatePasswordOpsHandler) cancelRotation(reqCtx intctrlutil.RequestCtx, cli client.Client, opsName string, secrets []corev1.Secret) error {
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Annotations[constant.PasswordRotationRequestAnnotationKey] != opsName ||
+			secret.Annotations[constant.PasswordRotatedForAnnotationKey] == opsName {
+			continue
+		}
+		patch := client.MergeFrom(secret.DeepCopy())
+		delete(secret.Annotations, constant.PasswordRotationRequestAnnotationKey)
+		if err := cli.Patch(reqCtx.Ctx, secret, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveLastConfiguration this operation does not change Cluster.spec.
+// empty implementation here.
+func (r rotatePasswordOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// getAccountSecrets gets the account secrets of the system accounts to rotate the passwords.
+func (r rotatePasswordOpsHandler) getAccountSecrets(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) ([]corev1.Secret, error) {
+	secrets := make([]corev1.Secret, 0)
+	for _, rotatePassword := range opsRes.OpsRequest.Spec.RotatePasswordList {
+		secretList := &corev1.SecretList{}
+		if err := cli.List(reqCtx.Ctx, secretList, client.InNamespace(opsRes.Cluster.Namespace),
+			client.MatchingLabels(constant.GetComponentWellKnownLabels(opsRes.Cluster.Name, rotatePassword.ComponentName)),
+			client.HasLabels{constant.ClusterAccountLabelKey}); err != nil {
+			return nil, err
+		}
+		for _, secret := range secretList.Items {
+			if len(rotatePassword.AccountNames) > 0 &&
+				!slices.Contains(rotatePassword.AccountNames, secret.Labels[constant.ClusterAccountLabelKey]) {
+				continue
+			}
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets, nil
+}
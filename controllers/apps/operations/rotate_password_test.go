@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("RotatePassword OpsRequest", func() {
+
+	var (
+		randomStr             = testCtx.GetRandomStr()
+		clusterDefinitionName = "cluster-definition-for-ops-" + randomStr
+		clusterVersionName    = "clusterversion-for-ops-" + randomStr
+		clusterName           = "cluster-for-ops-" + randomStr
+		accountName           = "kbadmin"
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	createAccountSecret := func(immutable bool) *corev1.Secret {
+		secretName := constant.GenerateAccountSecretName(clusterName, consensusComp, accountName)
+		secret := builder.NewSecretBuilder(testCtx.DefaultNamespace, secretName).
+			AddLabelsInMap(constant.GetComponentWellKnownLabels(clusterName, consensusComp)).
+			AddLabels(constant.ClusterAccountLabelKey, accountName).
+			PutData(constant.AccountNameForSecret, []byte(accountName)).
+			PutData(constant.AccountPasswdForSecret, []byte("123456")).
+			SetImmutable(immutable).
+			GetObject()
+		return testapps.CreateK8sResource(&testCtx, secret).(*corev1.Secret)
+	}
+
+	createRotatePasswordOps := func(opsRes *OpsResource) {
+		ops := testapps.NewOpsRequestObj("rotate-password-ops-"+randomStr, testCtx.DefaultNamespace,
+			clusterName, appsv1alpha1.RotatePasswordType)
+		ops.Spec.RotatePasswordList = []appsv1alpha1.RotatePassword{
+			{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+				AccountNames: []string{accountName},
+			},
+		}
+		opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+		// set ops phase to Pending
+		opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+	}
+
+	Context("Test OpsRequest", func() {
+		It("Test rotate password OpsRequest", func() {
+			By("init operations resources")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			secret := createAccountSecret(false)
+
+			By("create RotatePassword opsRequest")
+			createRotatePasswordOps(opsRes)
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+
+			By("do the action, expect the account secret is requested to rotate the password")
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(secret), func(g Gomega, obj *corev1.Secret) {
+				g.Expect(obj.Annotations[constant.PasswordRotationRequestAnnotationKey]).Should(Equal(opsRes.OpsRequest.Name))
+			})).Should(Succeed())
+
+			By("the password is not rotated yet, expect the opsRequest is not completed")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Progress).Should(Equal("0/1"))
+				g.Expect(ops.Status.Phase).ShouldNot(Equal(appsv1alpha1.OpsSucceedPhase))
+			})).Should(Succeed())
+
+			By("mock the password is rotated, expect the opsRequest succeeds")
+			Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(secret), func(obj *corev1.Secret) {
+				obj.Annotations[constant.PasswordRotatedForAnnotationKey] = opsRes.OpsRequest.Name
+			})).Should(Succeed())
+			Eventually(func(g Gomega) {
+				_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+			}).Should(Succeed())
+		})
+
+		It("Test rotate password OpsRequest timeout", func() {
+			By("init operations resources")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			secret := createAccountSecret(false)
+
+			By("create RotatePassword opsRequest and do the action")
+			createRotatePasswordOps(opsRes)
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(secret), func(g Gomega, obj *corev1.Secret) {
+				g.Expect(obj.Annotations[constant.PasswordRotationRequestAnnotationKey]).Should(Equal(opsRes.OpsRequest.Name))
+			})).Should(Succeed())
+
+			By("the password is not rotated in time, expect the opsRequest fails and the request is canceled")
+			opsRes.OpsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-rotatePasswordTimeout - time.Minute))
+			_, _ = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(secret), func(g Gomega, obj *corev1.Secret) {
+				g.Expect(obj.Annotations).ShouldNot(HaveKey(constant.PasswordRotationRequestAnnotationKey))
+			})).Should(Succeed())
+		})
+
+		It("Test rotate password OpsRequest with immutable account secret", func() {
+			By("init operations resources")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			createAccountSecret(true)
+
+			By("create RotatePassword opsRequest")
+			createRotatePasswordOps(opsRes)
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+
+			By("do the action, expect the opsRequest fails")
+			_, _ = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+		})
+	})
+})
@@ -40,6 +40,7 @@ func init() {
 		FromClusterPhases: append(appsv1alpha1.GetClusterUpRunningPhases(), appsv1alpha1.UpdatingClusterPhase),
 		ToClusterPhase:    appsv1alpha1.StoppingClusterPhase,
 		OpsHandler:        StopOpsHandler{},
+		IsDisruptive:      true,
 	}
 
 	opsMgr := GetOpsManager()
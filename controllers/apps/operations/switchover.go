@@ -52,6 +52,7 @@ func init() {
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		OpsHandler:        switchoverOpsHandler{},
+		IsDisruptive:      true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -45,8 +45,8 @@ const (
 	KBSwitchoverCandidateInstanceForAnyPod = "*"
 
 	KBJobTTLSecondsAfterFinished  = 5
-	KBSwitchoverJobLabelKey       = "kubeblocks.io/switchover-job"
-	KBSwitchoverJobLabelValue     = "kb-switchover-job"
+	KBSwitchoverJobLabelKey       = constant.SwitchoverJobLabelKey
+	KBSwitchoverJobLabelValue     = constant.SwitchoverJobLabelValue
 	KBSwitchoverJobNamePrefix     = "kb-switchover-job"
 	KBSwitchoverJobContainerName  = "kb-switchover-job-container"
 	KBSwitchoverCheckJobKey       = "CheckJob"
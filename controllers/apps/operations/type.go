@@ -59,9 +59,17 @@ type OpsBehaviour struct {
 	// only update the opsRequest object, then opsRequest controller will update uniformly.
 	CancelFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
 
+	// RollbackFunc builds the spec of the inverse opsRequest from the status.lastConfiguration to roll back
+	// the failed opsRequest, it returns nil if there is nothing to roll back.
+	RollbackFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) (*appsv1alpha1.OpsRequestSpec, error)
+
 	// IsClusterCreation indicates whether the opsRequest will create a new cluster.
 	IsClusterCreation bool
 
+	// IsDisruptive indicates whether the opsRequest disrupts the availability of the cluster,
+	// such opsRequest only starts in the maintenance windows.
+	IsDisruptive bool
+
 	OpsHandler OpsHandler
 }
 
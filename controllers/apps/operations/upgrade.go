@@ -42,6 +42,8 @@ func init() {
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		OpsHandler:        upgradeOpsHandler{},
+		RollbackFunc:      upgradeOpsHandler{}.Rollback,
+		IsDisruptive:      true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -76,6 +78,19 @@ func (u upgradeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Rollback builds the upgrade opsRequest to restore the clusterVersion in the last configuration.
+func (u upgradeOpsHandler) Rollback(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.OpsRequestSpec, error) {
+	lastClusterVersion := opsRes.OpsRequest.Status.LastConfiguration.ClusterVersionRef
+	if lastClusterVersion == "" || lastClusterVersion == opsRes.Cluster.Spec.ClusterVersionRef {
+		return nil, nil
+	}
+	return &appsv1alpha1.OpsRequestSpec{
+		ClusterRef: opsRes.OpsRequest.Spec.ClusterRef,
+		Type:       appsv1alpha1.UpgradeType,
+		Upgrade:    &appsv1alpha1.Upgrade{ClusterVersionRef: lastClusterVersion},
+	}, nil
+}
+
 // getUpgradeComponentsStatus compares the ClusterVersions before and after upgrade, and get the changed components map.
 func (u upgradeOpsHandler) getUpgradeComponentsStatus(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (map[string]appsv1alpha1.OpsRequestComponentStatus, error) {
 	lastComponents, err := u.getClusterComponentVersionMap(reqCtx.Ctx, cli,
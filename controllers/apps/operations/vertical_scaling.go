@@ -22,6 +22,7 @@ package operations
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -42,6 +43,8 @@ func init() {
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		OpsHandler:        vsHandler,
 		CancelFunc:        vsHandler.Cancel,
+		RollbackFunc:      vsHandler.Rollback,
+		IsDisruptive:      true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -111,3 +114,38 @@ func (vs verticalScalingHandler) Cancel(reqCxt intctrlutil.RequestCtx, cli clien
 		return nil
 	})
 }
+
+// Rollback builds the verticalScaling opsRequest to restore the resources of the components in the last configuration.
+func (vs verticalScalingHandler) Rollback(reqCxt intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.OpsRequestSpec, error) {
+	var verticalScalingList []appsv1alpha1.VerticalScaling
+	for _, comp := range opsRes.Cluster.Spec.ComponentSpecs {
+		lastConfig, ok := opsRes.OpsRequest.Status.LastConfiguration.Components[comp.Name]
+		if !ok {
+			continue
+		}
+		verticalScaling := appsv1alpha1.VerticalScaling{
+			ComponentOps: appsv1alpha1.ComponentOps{ComponentName: comp.Name},
+		}
+		// the empty classDefRef is set when scaling with the resources.
+		if lastConfig.ClassDefRef != nil && lastConfig.ClassDefRef.Class != "" {
+			if equality.Semantic.DeepEqual(lastConfig.ClassDefRef, comp.ClassDefRef) {
+				continue
+			}
+			verticalScaling.ClassDefRef = lastConfig.ClassDefRef
+		} else {
+			if equality.Semantic.DeepEqual(lastConfig.ResourceRequirements, comp.Resources) {
+				continue
+			}
+			verticalScaling.ResourceRequirements = lastConfig.ResourceRequirements
+		}
+		verticalScalingList = append(verticalScalingList, verticalScaling)
+	}
+	if len(verticalScalingList) == 0 {
+		return nil, nil
+	}
+	return &appsv1alpha1.OpsRequestSpec{
+		ClusterRef:          opsRes.OpsRequest.Spec.ClusterRef,
+		Type:                appsv1alpha1.VerticalScalingType,
+		VerticalScalingList: verticalScalingList,
+	}, nil
+}
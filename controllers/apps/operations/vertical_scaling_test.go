@@ -196,5 +196,68 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			Expect(progressDetail.Status).Should(Equal(appsv1alpha1.SucceedProgressStatus))
 			Expect(progressDetail.Message).Should(ContainSubstring("with rollback"))
 		})
+
+		It("roll back the vertical scaling opsRequest when the health gate times out", func() {
+			By("init operations resources")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+
+			By("create VerticalScaling ops with rollbackPolicy")
+			ops := testapps.NewOpsRequestObj("vertical-scaling-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VerticalScalingType)
+			ops.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("400m"),
+							corev1.ResourceMemory: resource.MustParse("300Mi"),
+						},
+					},
+				},
+			}
+			ops.Spec.RollbackPolicy = &appsv1alpha1.RollbackPolicy{
+				HealthGateTimeout: &metav1.Duration{Duration: time.Second},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			lastResources := opsRes.Cluster.Spec.GetComponentByName(consensusComp).Resources
+
+			By("do the vertical scaling action")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(verticalScalingHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			mockComponentIsOperating(opsRes.Cluster, appsv1alpha1.UpdatingClusterCompPhase, consensusComp)
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+				opsRes.OpsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now().Add(-time.Minute)}
+			})).ShouldNot(HaveOccurred())
+
+			By("expect the opsRequest to fail since the health gate times out")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+
+			By("expect the rollback opsRequest to be created with the last resources")
+			Expect(GetOpsManager().Rollback(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(opsRes.OpsRequest.Status.Rollback).ShouldNot(BeNil())
+			rollbackOps := &appsv1alpha1.OpsRequest{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: opsRes.OpsRequest.Status.Rollback.OpsRequestName,
+				Namespace: testCtx.DefaultNamespace}, rollbackOps)).Should(Succeed())
+			Expect(rollbackOps.Annotations[constant.OpsRequestRollbackForAnnotationKey]).Should(Equal(ops.Name))
+			Expect(rollbackOps.Spec.VerticalScalingList).Should(HaveLen(1))
+			Expect(rollbackOps.Spec.VerticalScalingList[0].ResourceRequirements).Should(BeEquivalentTo(lastResources))
+
+			By("expect the rollback opsRequest to start when the cluster is Failed")
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.Cluster, func() {
+				opsRes.Cluster.Status.Phase = appsv1alpha1.FailedClusterPhase
+			})).ShouldNot(HaveOccurred())
+			rollbackOpsRes := &OpsResource{Cluster: opsRes.Cluster, OpsRequest: rollbackOps, Recorder: opsRes.Recorder}
+			rollbackOps.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, rollbackOpsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rollbackOps.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			Expect(k8sClient.Delete(ctx, rollbackOps)).Should(Succeed())
+		})
 	})
 })
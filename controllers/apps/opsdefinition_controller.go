@@ -81,6 +81,25 @@ func (r *OpsDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// check the dependencies and the expressions of the steps.
+	if err = opsDef.Spec.ValidateSteps(); err != nil {
+		if patchErr := r.updateStatusUnavailable(reqCtx, opsDef, err); patchErr != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+	for _, v := range opsDef.Spec.Steps {
+		if v.WaitFor == nil {
+			continue
+		}
+		if _, err = template.New("opsDefTemplate").Parse(v.WaitFor.Expression); err != nil {
+			if patchErr := r.updateStatusUnavailable(reqCtx, opsDef, err); patchErr != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			return intctrlutil.Reconciled()
+		}
+	}
+
 	// TODO: check serviceKind, connectionCredentialName and serviceName
 	statusPatch := client.MergeFrom(opsDef.DeepCopy())
 	opsDef.Status.ObservedGeneration = opsDef.Generation
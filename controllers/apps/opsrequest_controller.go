@@ -164,7 +164,9 @@ func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.Reques
 		return r.reconcileStatusDuringRunningOrCanceling(reqCtx, opsRes)
 	case appsv1alpha1.OpsSucceedPhase:
 		return r.handleSucceedOpsRequest(reqCtx, opsRes.OpsRequest)
-	case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+	case appsv1alpha1.OpsFailedPhase:
+		return r.handleFailedOpsRequest(reqCtx, opsRes)
+	case appsv1alpha1.OpsCancelledPhase:
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	}
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -217,6 +219,15 @@ func (r *OpsRequestReconciler) handleSucceedOpsRequest(reqCtx intctrlutil.Reques
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
 }
 
+// handleFailedOpsRequest rolls back the failed opsRequest if its rollbackPolicy is specified.
+func (r *OpsRequestReconciler) handleFailedOpsRequest(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
+	if err := operations.GetOpsManager().Rollback(reqCtx, r.Client, opsRes); err != nil {
+		r.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, reasonOpsRollbackFailed, "Failed to roll back the OpsRequest: %s", err.Error())
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
+	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+}
+
 // reconcileStatusDuringRunningOrCanceling reconciles the status of OpsRequest when it is running or canceling.
 func (r *OpsRequestReconciler) reconcileStatusDuringRunningOrCanceling(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	opsRequest := opsRes.OpsRequest
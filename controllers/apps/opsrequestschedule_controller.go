@@ -0,0 +1,196 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// maxMissedScheduleDuration is the max duration to make up the missed schedule.
+const maxMissedScheduleDuration = 24 * time.Hour
+
+// OpsRequestScheduleReconciler reconciles a OpsRequestSchedule object
+type OpsRequestScheduleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequestschedules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequestschedules/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequestschedules/finalizers,verbs=update
+
+// Reconcile creates an OpsRequest from the template each time the cron schedule is met,
+// and requeues the OpsRequestSchedule till the next schedule time.
+func (r *OpsRequestScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx: ctx,
+		Req: req,
+		Log: log.FromContext(ctx).WithValues("opsRequestSchedule", req.NamespacedName),
+	}
+
+	opsSchedule := &appsv1alpha1.OpsRequestSchedule{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, opsSchedule); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !opsSchedule.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	if err := r.cleanupHistory(reqCtx, opsSchedule); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	now := time.Now()
+	schedule, err := cron.ParseStandard(opsSchedule.Spec.Schedule)
+	if err != nil {
+		r.Recorder.Eventf(opsSchedule, corev1.EventTypeWarning, reasonOpsRequestScheduled,
+			"the schedule %q is invalid: %s", opsSchedule.Spec.Schedule, err.Error())
+		return intctrlutil.Reconciled()
+	}
+
+	statusPatch := client.MergeFrom(opsSchedule.DeepCopy())
+	opsSchedule.Status.ObservedGeneration = opsSchedule.Generation
+	opsSchedule.Status.NextScheduleTime = nil
+	if !opsSchedule.Spec.Suspend {
+		// create the OpsRequest for the most recent schedule time that is not met,
+		// the earlier missed schedules are not made up.
+		earliest := opsSchedule.CreationTimestamp.Time
+		if opsSchedule.Status.LastScheduleTime != nil {
+			earliest = opsSchedule.Status.LastScheduleTime.Time
+		}
+		if scheduleTime, ok := getMostRecentScheduleTime(schedule, earliest, now); ok {
+			opsRequest := buildScheduledOpsRequest(opsSchedule, scheduleTime)
+			if err = controllerutil.SetControllerReference(opsSchedule, opsRequest, r.Scheme); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			if err = r.Client.Create(reqCtx.Ctx, opsRequest); err != nil && !apierrors.IsAlreadyExists(err) {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			r.Recorder.Eventf(opsSchedule, corev1.EventTypeNormal, reasonOpsRequestScheduled,
+				"Created OpsRequest: %s", opsRequest.Name)
+			opsSchedule.Status.LastScheduleTime = &metav1.Time{Time: scheduleTime}
+			opsSchedule.Status.LastOpsRequestName = opsRequest.Name
+		}
+		opsSchedule.Status.NextScheduleTime = &metav1.Time{Time: schedule.Next(now)}
+	}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, opsSchedule, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if opsSchedule.Status.NextScheduleTime == nil {
+		return intctrlutil.Reconciled()
+	}
+	return intctrlutil.RequeueAfter(opsSchedule.Status.NextScheduleTime.Sub(now), reqCtx.Log, "wait for the next schedule")
+}
+
+// getMostRecentScheduleTime returns the most recent schedule time that is after the earliest
+// time and not after now. The schedules missed for more than maxMissedScheduleDuration are ignored.
+func getMostRecentScheduleTime(schedule cron.Schedule, earliest, now time.Time) (time.Time, bool) {
+	if earliest.Before(now.Add(-maxMissedScheduleDuration)) {
+		earliest = now.Add(-maxMissedScheduleDuration)
+	}
+	var (
+		mostRecent time.Time
+		found      bool
+	)
+	for t := schedule.Next(earliest); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		mostRecent, found = t, true
+	}
+	return mostRecent, found
+}
+
+// cleanupHistory deletes the oldest finished OpsRequests created by the schedule
+// that exceed the history limit.
+func (r *OpsRequestScheduleReconciler) cleanupHistory(reqCtx intctrlutil.RequestCtx,
+	opsSchedule *appsv1alpha1.OpsRequestSchedule) error {
+	if opsSchedule.Spec.HistoryLimit == nil {
+		return nil
+	}
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(opsSchedule.Namespace),
+		client.MatchingLabels{constant.OpsRequestScheduleLabelKey: opsSchedule.Name}); err != nil {
+		return err
+	}
+	var finished []*appsv1alpha1.OpsRequest
+	for i := range opsList.Items {
+		ops := &opsList.Items[i]
+		if !ops.DeletionTimestamp.IsZero() || !metav1.IsControlledBy(ops, opsSchedule) {
+			continue
+		}
+		switch ops.Status.Phase {
+		case appsv1alpha1.OpsSucceedPhase, appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+			finished = append(finished, ops)
+		}
+	}
+	limit := int(*opsSchedule.Spec.HistoryLimit)
+	if len(finished) <= limit {
+		return nil
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].CreationTimestamp.Before(&finished[j].CreationTimestamp)
+	})
+	for _, ops := range finished[:len(finished)-limit] {
+		if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, ops); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildScheduledOpsRequest builds the OpsRequest to create at the schedule time.
+func buildScheduledOpsRequest(opsSchedule *appsv1alpha1.OpsRequestSchedule, scheduleTime time.Time) *appsv1alpha1.OpsRequest {
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			// the name is unique for each schedule time to avoid creating duplicate OpsRequests.
+			Name:      fmt.Sprintf("%s-%d", opsSchedule.Name, scheduleTime.Unix()/60),
+			Namespace: opsSchedule.Namespace,
+			Labels: map[string]string{
+				constant.OpsRequestScheduleLabelKey: opsSchedule.Name,
+			},
+		},
+		Spec: *opsSchedule.Spec.OpsRequestTemplate.DeepCopy(),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpsRequestScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.OpsRequestSchedule{}).
+		Owns(&appsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
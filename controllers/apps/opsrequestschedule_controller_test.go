@@ -0,0 +1,175 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("OpsRequestSchedule Controller", func() {
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+		testapps.ClearResources(&testCtx, intctrlutil.OpsRequestScheduleSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, intctrlutil.OpsRequestSignature, true, inNS,
+			client.HasLabels{constant.OpsRequestScheduleLabelKey})
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+	})
+
+	newOpsRequestSchedule := func(name string, suspend bool) *appsv1alpha1.OpsRequestSchedule {
+		opsSchedule := &appsv1alpha1.OpsRequestSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1alpha1.OpsRequestScheduleSpec{
+				// create the OpsRequest every minute.
+				Schedule:     "* * * * *",
+				Suspend:      suspend,
+				HistoryLimit: pointer.Int32(1),
+				OpsRequestTemplate: appsv1alpha1.OpsRequestSpec{
+					ClusterRef:  "test-cluster",
+					Type:        appsv1alpha1.RestartType,
+					RestartList: []appsv1alpha1.ComponentOps{{ComponentName: "mysql"}},
+				},
+			},
+		}
+		return testapps.CreateK8sResource(&testCtx, opsSchedule).(*appsv1alpha1.OpsRequestSchedule)
+	}
+
+	Context("Test OpsRequestSchedule", func() {
+		It("should create the OpsRequest when the schedule is met", func() {
+			opsSchedule := newOpsRequestSchedule("test-ops-schedule", false)
+
+			By("checking the OpsRequest is created")
+			var opsName string
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsSchedule),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequestSchedule) {
+					g.Expect(fetched.Status.LastOpsRequestName).ShouldNot(BeEmpty())
+					g.Expect(fetched.Status.LastScheduleTime).ShouldNot(BeNil())
+					g.Expect(fetched.Status.NextScheduleTime).ShouldNot(BeNil())
+					opsName = fetched.Status.LastOpsRequestName
+				})).WithTimeout(90 * time.Second).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Name: opsName, Namespace: testCtx.DefaultNamespace},
+				func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+					g.Expect(ops.Labels[constant.OpsRequestScheduleLabelKey]).Should(Equal(opsSchedule.Name))
+					g.Expect(ops.Spec.Type).Should(Equal(appsv1alpha1.RestartType))
+					g.Expect(metav1.IsControlledBy(ops, opsSchedule)).Should(BeTrue())
+				})).Should(Succeed())
+		})
+
+		It("should delete the finished OpsRequests exceeding the history limit", func() {
+			opsSchedule := newOpsRequestSchedule("test-ops-schedule-history", true)
+
+			By("creating the finished OpsRequests of the schedule")
+			createFinishedOps := func(name string) *appsv1alpha1.OpsRequest {
+				ops := buildScheduledOpsRequest(opsSchedule, time.Now())
+				ops.Name = name
+				Expect(controllerutil.SetControllerReference(opsSchedule, ops, testCtx.Cli.Scheme())).Should(Succeed())
+				ops = testapps.CreateK8sResource(&testCtx, ops).(*appsv1alpha1.OpsRequest)
+				Eventually(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(ops),
+					func(fetched *appsv1alpha1.OpsRequest) {
+						fetched.Status.Phase = appsv1alpha1.OpsSucceedPhase
+					})).Should(Succeed())
+				return ops
+			}
+			older := createFinishedOps(opsSchedule.Name + "-older")
+			time.Sleep(time.Second)
+			newer := createFinishedOps(opsSchedule.Name + "-newer")
+
+			By("triggering the reconciliation of the schedule")
+			Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(opsSchedule),
+				func(fetched *appsv1alpha1.OpsRequestSchedule) {
+					fetched.Spec.HistoryLimit = pointer.Int32(1)
+					fetched.Annotations = map[string]string{"test": "reconcile"}
+				})).Should(Succeed())
+
+			By("checking only the newer OpsRequest is kept")
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(older),
+				&appsv1alpha1.OpsRequest{}, false)).Should(Succeed())
+			Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(newer),
+				&appsv1alpha1.OpsRequest{}, true)).Should(Succeed())
+		})
+
+		It("should not create the OpsRequest if the schedule is suspended", func() {
+			opsSchedule := newOpsRequestSchedule("test-ops-schedule-suspended", true)
+
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsSchedule),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequestSchedule) {
+					g.Expect(fetched.Status.ObservedGeneration).Should(Equal(fetched.Generation))
+					g.Expect(fetched.Status.LastOpsRequestName).Should(BeEmpty())
+					g.Expect(fetched.Status.NextScheduleTime).Should(BeNil())
+				})).Should(Succeed())
+		})
+
+		It("should get the most recent schedule time", func() {
+			schedule, err := cron.ParseStandard("0 2 * * 0")
+			Expect(err).ShouldNot(HaveOccurred())
+			// 2023-10-01 is a Sunday.
+			lastSunday := time.Date(2023, 10, 1, 2, 0, 0, 0, time.UTC)
+
+			By("not met before the schedule time")
+			_, ok := getMostRecentScheduleTime(schedule, lastSunday.Add(-time.Hour*24), lastSunday.Add(-time.Minute))
+			Expect(ok).Should(BeFalse())
+
+			By("met at the schedule time")
+			scheduleTime, ok := getMostRecentScheduleTime(schedule, lastSunday.Add(-time.Hour*24), lastSunday.Add(time.Minute))
+			Expect(ok).Should(BeTrue())
+			Expect(scheduleTime).Should(BeTemporally("==", lastSunday))
+
+			By("not met again after the last schedule time")
+			_, ok = getMostRecentScheduleTime(schedule, lastSunday, lastSunday.Add(time.Hour))
+			Expect(ok).Should(BeFalse())
+
+			By("the schedules missed long ago are ignored")
+			_, ok = getMostRecentScheduleTime(schedule, lastSunday.Add(-time.Hour*24*30), lastSunday.Add(time.Hour*25))
+			Expect(ok).Should(BeFalse())
+		})
+	})
+})
@@ -208,8 +208,9 @@ var _ = Describe("TLS self-signed cert function", func() {
 
 			BeforeEach(func() {
 				// prepare self provided tls certs secret
-				var err error
-				userProvidedTLSSecretObj, err = plan.ComposeTLSSecret(testCtx.DefaultNamespace, "test", "self-provided")
+				caSecret, err := plan.ComposeTLSCASecret(testCtx.DefaultNamespace, "test")
+				Expect(err).Should(BeNil())
+				userProvidedTLSSecretObj, err = plan.ComposeTLSSecret(testCtx.DefaultNamespace, "test", "self-provided", caSecret, nil, nil)
 				Expect(err).Should(BeNil())
 				Expect(k8sClient.Create(ctx, userProvidedTLSSecretObj)).Should(Succeed())
 			})
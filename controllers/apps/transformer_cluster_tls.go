@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+)
+
+// clusterTLSTransformer creates the cluster CA secret, which signs the TLS certificates of the components
+// with the KubeBlocks issuer.
+type clusterTLSTransformer struct{}
+
+var _ graph.Transformer = &clusterTLSTransformer{}
+
+func (t *clusterTLSTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	if model.IsObjectDeleting(transCtx.OrigCluster) {
+		return nil
+	}
+	if !t.isKubeBlocksIssuerRequired(transCtx) {
+		return nil
+	}
+
+	cluster := transCtx.Cluster
+	secretKey := types.NamespacedName{Namespace: cluster.Namespace, Name: plan.GenerateTLSCASecretName(cluster.Name)}
+	err := transCtx.Client.Get(transCtx.Context, secretKey, &corev1.Secret{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+	secret, err := plan.ComposeTLSCASecret(cluster.Namespace, cluster.Name)
+	if err != nil {
+		return err
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	graphCli.Create(dag, secret)
+	return nil
+}
+
+func (t *clusterTLSTransformer) isKubeBlocksIssuerRequired(transCtx *clusterTransformContext) bool {
+	for _, compSpec := range transCtx.ComponentSpecs {
+		if compSpec.TLS && compSpec.Issuer != nil && compSpec.Issuer.Name == appsv1alpha1.IssuerKubeBlocks {
+			return true
+		}
+	}
+	return false
+}
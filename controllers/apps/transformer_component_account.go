@@ -156,6 +156,6 @@ func (t *componentAccountTransformer) buildAccountSecretWithPassword(synthesizeC
 		AddLabels(constant.ClusterAccountLabelKey, account.Name).
 		PutData(constant.AccountNameForSecret, []byte(account.Name)).
 		PutData(constant.AccountPasswdForSecret, password).
-		SetImmutable(true).
+		SetImmutable(account.PasswordRotationPolicy == nil).
 		GetObject()
 }
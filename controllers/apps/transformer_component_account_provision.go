@@ -79,7 +79,7 @@ func (t *componentAccountProvisionTransformer) Transform(ctx graph.TransformCont
 	}
 	// TODO: support custom handler for account
 	// TODO: build lorry client if accountProvision is built-in
-	lorryCli, err := t.buildLorryClient(transCtx)
+	lorryCli, err := buildAccountLorryClient(transCtx)
 	if err != nil {
 		return err
 	}
@@ -174,7 +174,8 @@ func (t *componentAccountProvisionTransformer) markAccountProvisioned(cond *meta
 	cond.Message = strings.Join(accounts, ",")
 }
 
-func (t *componentAccountProvisionTransformer) buildLorryClient(transCtx *componentTransformContext) (lorry.Client, error) {
+// buildAccountLorryClient builds the lorry client of the serviceable and writable replica to manage the accounts.
+func buildAccountLorryClient(transCtx *componentTransformContext) (lorry.Client, error) {
 	synthesizedComp := transCtx.SynthesizeComponent
 
 	roleName := ""
@@ -192,7 +193,7 @@ func (t *componentAccountProvisionTransformer) buildLorryClient(transCtx *compon
 		return nil, err
 	}
 	if podList == nil || len(podList.Items) == 0 {
-		return nil, fmt.Errorf("unable to find appropriate pods to create accounts")
+		return nil, fmt.Errorf("unable to find appropriate pods to manage accounts")
 	}
 
 	lorryCli, err := lorry.NewClient(podList.Items[0])
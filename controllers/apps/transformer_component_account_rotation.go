@@ -0,0 +1,312 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+// componentAccountRotationTransformer rotates the passwords of component system accounts
+// which have the password rotation policy specified.
+//
+// a rotation takes two reconciliations:
+//  1. a new password is generated and saved in the account secret as the pending password;
+//  2. the pending password is applied to the database through lorry, and then it replaces the password in the secret,
+//     the old password is kept in the secret and keeps valid until the grace period is over.
+//
+// so the new password will never be lost even if the reconciliation is interrupted.
+type componentAccountRotationTransformer struct{}
+
+// accountRotationRetryInterval is the interval to retry the rotation after it fails.
+const accountRotationRetryInterval = 30 * time.Second
+
+// nonReplicatedAccountCharacterTypes are the engines whose accounts are not replicated between the replicas,
+// e.g. the ACL of redis, so the passwords are rotated on every replica.
+var nonReplicatedAccountCharacterTypes = []string{constant.RedisCharacterType}
+
+var _ graph.Transformer = &componentAccountRotationTransformer{}
+
+func (t *componentAccountRotationTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+	if common.IsCompactMode(transCtx.ComponentOrig.Annotations) {
+		transCtx.V(1).Info("Component is in compact mode, no need to rotate component account passwords",
+			"component", client.ObjectKeyFromObject(transCtx.ComponentOrig))
+		return nil
+	}
+	if transCtx.Component.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+		return nil
+	}
+
+	accounts := make([]appsv1alpha1.SystemAccount, 0)
+	for _, account := range transCtx.SynthesizeComponent.SystemAccounts {
+		// the init account is not rotatable, see the validation of ComponentDefinition.
+		if account.PasswordRotationPolicy != nil && !account.InitAccount {
+			accounts = append(accounts, account)
+		}
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	// the errors of the rotation are not returned to abort the reconciliation of the component,
+	// the rotation is retried later instead.
+	lorryClis, err := t.buildLorryClients(transCtx)
+	if err != nil {
+		return controllerutil.NewDelayedRequeueError(accountRotationRetryInterval, err.Error())
+	}
+	if len(lorryClis) == 0 {
+		return nil
+	}
+
+	var (
+		now        = time.Now()
+		nextRotate time.Time
+		rotateErr  error
+	)
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	for _, account := range accounts {
+		secret, err := t.getAccountSecret(transCtx, account)
+		if err != nil {
+			return controllerutil.NewDelayedRequeueError(accountRotationRetryInterval, err.Error())
+		}
+		if secret == nil || secret.Immutable != nil && *secret.Immutable {
+			continue
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secretCopy := secret.DeepCopy()
+		// the secret is still updated if the rotation fails, since the old password may have been discarded.
+		if err = t.rotate(transCtx, lorryClis, account, secretCopy, now); err != nil {
+			transCtx.Logger.Error(err, "failed to rotate the password", "account", account.Name)
+			transCtx.EventRecorder.Event(transCtx.Component, corev1.EventTypeWarning, "RotatePasswordFailed", err.Error())
+			rotateErr = err
+			if retryAt := now.Add(accountRotationRetryInterval); nextRotate.IsZero() || retryAt.Before(nextRotate) {
+				nextRotate = retryAt
+			}
+		}
+		if next := t.nextRotateTime(account, secretCopy); !next.IsZero() && (nextRotate.IsZero() || next.Before(nextRotate)) {
+			nextRotate = next
+		}
+		if reflect.DeepEqual(secret.Data, secretCopy.Data) && reflect.DeepEqual(secret.Annotations, secretCopy.Annotations) {
+			continue
+		}
+		graphCli.Update(dag, secret, secretCopy)
+	}
+
+	if nextRotate.IsZero() {
+		return nil
+	}
+	reason := "requeue to rotate account passwords"
+	if rotateErr != nil {
+		reason = fmt.Sprintf("requeue to retry the password rotation: %s", rotateErr.Error())
+	}
+	// the secret updated will trigger the reconciliation to apply the pending password, so no need to requeue immediately.
+	return controllerutil.NewDelayedRequeueError(max(nextRotate.Sub(now), time.Second), reason)
+}
+
+// buildLorryClients builds the lorry clients to rotate the passwords, the clients of all the pods are built
+// if the accounts are not replicated between the replicas.
+func (t *componentAccountRotationTransformer) buildLorryClients(transCtx *componentTransformContext) ([]lorry.Client, error) {
+	synthesizeComp := transCtx.SynthesizeComponent
+	if !slices.Contains(nonReplicatedAccountCharacterTypes, synthesizeComp.CharacterType) {
+		lorryCli, err := buildAccountLorryClient(transCtx)
+		if err != nil || controllerutil.IsNil(lorryCli) {
+			return nil, err
+		}
+		return []lorry.Client{lorryCli}, nil
+	}
+	podList, err := component.GetComponentPodList(transCtx.Context, transCtx.Client, *transCtx.Cluster, synthesizeComp.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(podList.Items) == 0 {
+		return nil, fmt.Errorf("unable to find appropriate pods to manage accounts")
+	}
+	lorryClis := make([]lorry.Client, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		lorryCli, err := lorry.NewClient(pod)
+		if err != nil {
+			return nil, err
+		}
+		if controllerutil.IsNil(lorryCli) {
+			return nil, nil
+		}
+		lorryClis = append(lorryClis, lorryCli)
+	}
+	return lorryClis, nil
+}
+
+func (t *componentAccountRotationTransformer) getAccountSecret(transCtx *componentTransformContext,
+	account appsv1alpha1.SystemAccount) (*corev1.Secret, error) {
+	synthesizeComp := transCtx.SynthesizeComponent
+	secretKey := types.NamespacedName{
+		Namespace: synthesizeComp.Namespace,
+		Name:      constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name),
+	}
+	secret := &corev1.Secret{}
+	if err := transCtx.Client.Get(transCtx.Context, secretKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// rotate discards the expired old password, applies the pending password or generates a new one if the rotation is due.
+func (t *componentAccountRotationTransformer) rotate(transCtx *componentTransformContext, lorryClis []lorry.Client,
+	account appsv1alpha1.SystemAccount, secret *corev1.Secret, now time.Time) error {
+	userName := string(secret.Data[constant.AccountNameForSecret])
+	if len(userName) == 0 {
+		return nil
+	}
+	expiresAt, ok := t.parseTime(secret.Annotations[constant.OldPasswordExpiresAtAnnotationKey])
+	if ok && !now.Before(expiresAt) {
+		if err := t.discardOldPassword(transCtx, lorryClis, userName, secret); err != nil {
+			return err
+		}
+	}
+
+	pending := secret.Data[constant.AccountPendingPasswdForSecret]
+	if len(pending) > 0 {
+		return t.applyPendingPassword(transCtx, lorryClis, account, userName, secret, now)
+	}
+
+	if t.isRotationDue(account, secret, now) {
+		transCtx.V(1).Info("generate a new password for account", "account", account.Name)
+		secret.Data[constant.AccountPendingPasswdForSecret] = (&componentAccountTransformer{}).generatePassword(account)
+	}
+	return nil
+}
+
+func (t *componentAccountRotationTransformer) applyPendingPassword(transCtx *componentTransformContext, lorryClis []lorry.Client,
+	account appsv1alpha1.SystemAccount, userName string, secret *corev1.Secret, now time.Time) error {
+	// the engine may keep only one old password, discard the previous one before the rotation.
+	if _, ok := secret.Data[constant.AccountOldPasswdForSecret]; ok {
+		if err := t.discardOldPassword(transCtx, lorryClis, userName, secret); err != nil {
+			return err
+		}
+	}
+
+	var gracePeriod time.Duration
+	if account.PasswordRotationPolicy.GracePeriod != nil {
+		gracePeriod = account.PasswordRotationPolicy.GracePeriod.Duration
+	}
+	pending := secret.Data[constant.AccountPendingPasswdForSecret]
+	for _, lorryCli := range lorryClis {
+		if err := lorryCli.UpdateUserPassword(transCtx.Context, userName, string(pending), gracePeriod > 0); err != nil {
+			return fmt.Errorf("failed to rotate the password of account %s: %s", account.Name, err.Error())
+		}
+	}
+
+	if gracePeriod > 0 {
+		secret.Data[constant.AccountOldPasswdForSecret] = secret.Data[constant.AccountPasswdForSecret]
+		secret.Annotations[constant.OldPasswordExpiresAtAnnotationKey] = now.Add(gracePeriod).UTC().Format(time.RFC3339)
+	}
+	secret.Data[constant.AccountPasswdForSecret] = pending
+	delete(secret.Data, constant.AccountPendingPasswdForSecret)
+	secret.Annotations[constant.PasswordRotatedAtAnnotationKey] = now.UTC().Format(time.RFC3339)
+	if request, ok := secret.Annotations[constant.PasswordRotationRequestAnnotationKey]; ok {
+		secret.Annotations[constant.PasswordRotatedForAnnotationKey] = request
+	}
+	transCtx.V(1).Info("the password of account is rotated", "account", account.Name)
+	return nil
+}
+
+func (t *componentAccountRotationTransformer) discardOldPassword(transCtx *componentTransformContext, lorryClis []lorry.Client,
+	userName string, secret *corev1.Secret) error {
+	oldPassword := secret.Data[constant.AccountOldPasswdForSecret]
+	if len(oldPassword) > 0 {
+		for _, lorryCli := range lorryClis {
+			if err := lorryCli.DiscardUserOldPassword(transCtx.Context, userName, string(oldPassword)); err != nil {
+				return fmt.Errorf("failed to discard the old password of account %s: %s", userName, err.Error())
+			}
+		}
+	}
+	delete(secret.Data, constant.AccountOldPasswdForSecret)
+	delete(secret.Annotations, constant.OldPasswordExpiresAtAnnotationKey)
+	return nil
+}
+
+// isRotationDue checks whether the password should be rotated, it is requested by an OpsRequest or the rotation period is over.
+func (t *componentAccountRotationTransformer) isRotationDue(account appsv1alpha1.SystemAccount, secret *corev1.Secret, now time.Time) bool {
+	request := secret.Annotations[constant.PasswordRotationRequestAnnotationKey]
+	if len(request) > 0 && request != secret.Annotations[constant.PasswordRotatedForAnnotationKey] {
+		return true
+	}
+	next := t.nextPeriodicRotateTime(account, secret)
+	return !next.IsZero() && !now.Before(next)
+}
+
+// nextRotateTime returns the next time that the secret needs to be reconciled, zero means no need.
+func (t *componentAccountRotationTransformer) nextRotateTime(account appsv1alpha1.SystemAccount, secret *corev1.Secret) time.Time {
+	next := t.nextPeriodicRotateTime(account, secret)
+	if expiresAt, ok := t.parseTime(secret.Annotations[constant.OldPasswordExpiresAtAnnotationKey]); ok {
+		if next.IsZero() || expiresAt.Before(next) {
+			next = expiresAt
+		}
+	}
+	return next
+}
+
+func (t *componentAccountRotationTransformer) nextPeriodicRotateTime(account appsv1alpha1.SystemAccount, secret *corev1.Secret) time.Time {
+	period := account.PasswordRotationPolicy.Period
+	if period == nil || period.Duration <= 0 {
+		return time.Time{}
+	}
+	rotatedAt, ok := t.parseTime(secret.Annotations[constant.PasswordRotatedAtAnnotationKey])
+	if !ok {
+		rotatedAt = secret.CreationTimestamp.Time
+	}
+	return rotatedAt.Add(period.Duration)
+}
+
+func (t *componentAccountRotationTransformer) parseTime(value string) (time.Time, bool) {
+	if len(value) == 0 {
+		return time.Time{}, false
+	}
+	tm, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return tm, true
+}
@@ -33,6 +33,7 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -259,6 +260,8 @@ func buildServiceAccount(transCtx *componentTransformContext) (*corev1.ServiceAc
 
 	serviceAccountName := comp.Spec.ServiceAccountName
 	volumeProtectionEnable := isVolumeProtectionEnabled(compDef)
+	// lorry reviews the ServiceAccount tokens if its authentication is enabled, which requires the clusterRoleBinding permission too.
+	lorryAuthEnable := component.IsLorryTLSEnabled(transCtx.SynthesizeComponent)
 	dataProtectionEnable := isDataProtectionEnabled(backupPolicyTPL, cluster, comp)
 	if serviceAccountName == "" {
 		// If probe, volume protection, and data protection are disabled at the same tme, then do not create a service account.
@@ -271,13 +274,13 @@ func buildServiceAccount(transCtx *componentTransformContext) (*corev1.ServiceAc
 
 	if isRoleBindingExist(transCtx, serviceAccountName) && isServiceAccountExist(transCtx, serviceAccountName) {
 		// Volume protection requires the clusterRoleBinding permission, if volume protection is not enabled or the corresponding clusterRoleBinding already exists, then skip.
-		if !volumeProtectionEnable || isClusterRoleBindingExist(transCtx, serviceAccountName) {
+		if !(volumeProtectionEnable || lorryAuthEnable) || isClusterRoleBindingExist(transCtx, serviceAccountName) {
 			return nil, false, nil
 		}
 	}
 
 	// if volume protection is enabled, the service account needs to be bound to the clusterRoleBinding.
-	return factory.BuildServiceAccount(cluster, serviceAccountName), volumeProtectionEnable, nil
+	return factory.BuildServiceAccount(cluster, serviceAccountName), volumeProtectionEnable || lorryAuthEnable, nil
 }
 
 func createServiceAccount(serviceAccount *corev1.ServiceAccount, graphCli model.GraphClient, dag *graph.DAG, parent client.Object) {
@@ -218,6 +218,12 @@ func (r *componentStatusHandler) reconcileComponentStatus() error {
 		r.setComponentStatusPhase(appsv1alpha1.AbnormalClusterCompPhase, nil, "component is Abnormal")
 	}
 
+	// sync the leader history of the rsm
+	r.comp.Status.LeaderHistory = nil
+	for _, change := range r.runningRSM.Status.LeaderHistory {
+		r.comp.Status.LeaderHistory = append(r.comp.Status.LeaderHistory, *change.DeepCopy())
+	}
+
 	// update component info to pods' annotations
 	// TODO(xingran): should be move this to rsm controller
 	if err := UpdateComponentInfoToPods(r.reqCtx.Ctx, r.cli, r.cluster, r.synthesizeComp, r.dag); err != nil {
@@ -23,8 +23,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -52,16 +55,57 @@ func (t *componentTLSTransformer) Transform(ctx graph.TransformContext, dag *gra
 		return err
 	}
 
-	// build tls cert
-	if err := buildTLSCert(transCtx.Context, transCtx.Client, *synthesizedComp, dag); err != nil {
-		return err
+	// build tls cert, the delayed requeue error is returned at last if the cluster CA is not ready.
+	secret, certErr := buildTLSCert(transCtx.Context, transCtx.Client, *synthesizedComp, dag)
+	if certErr != nil && !intctrlutil.IsDelayedRequeueError(certErr) {
+		return certErr
 	}
 
 	if err := checkAndTriggerReRender(transCtx.Context, transCtx.Client, *synthesizedComp, dag); err != nil {
 		return err
 	}
 
-	return nil
+	renewErr := t.updateTLSCertificateStatus(transCtx, secret)
+	if certErr != nil {
+		return certErr
+	}
+	return renewErr
+}
+
+// updateTLSCertificateStatus records the certificate in use to the component status, and requeues to renew
+// the certificate in time if it is issued by KubeBlocks.
+func (t *componentTLSTransformer) updateTLSCertificateStatus(transCtx *componentTransformContext, secret *corev1.Secret) error {
+	tls := transCtx.SynthesizeComponent.TLSConfig
+	if tls == nil || !tls.Enable || tls.Issuer == nil {
+		transCtx.Component.Status.TLSCertificate = nil
+		return nil
+	}
+	if secret == nil {
+		return nil
+	}
+
+	certKey := constant.CertName
+	if tls.Issuer.Name == appsv1alpha1.IssuerUserProvided {
+		certKey = tls.Issuer.SecretRef.Cert
+	}
+	cert, err := plan.ParseTLSCert(secret, certKey)
+	if err != nil {
+		transCtx.Logger.Info(fmt.Sprintf("failed to parse the TLS certificate in secret %s: %s", secret.Name, err.Error()))
+		return nil
+	}
+	status := &appsv1alpha1.TLSCertificateStatus{
+		SecretName:   secret.Name,
+		SerialNumber: cert.SerialNumber.Text(16),
+		NotBefore:    &metav1.Time{Time: cert.NotBefore},
+		NotAfter:     &metav1.Time{Time: cert.NotAfter},
+	}
+	transCtx.Component.Status.TLSCertificate = status
+	if tls.Issuer.Name != appsv1alpha1.IssuerKubeBlocks {
+		return nil
+	}
+	renewalTime := plan.GetTLSCertRenewalTime(cert, tls.Issuer)
+	status.RenewalTime = &metav1.Time{Time: renewalTime}
+	return intctrlutil.NewDelayedRequeueError(max(time.Until(renewalTime), time.Second), "requeue to renew the TLS certificates")
 }
 
 // a hack way to notify the configuration controller to re-render config
@@ -128,30 +172,98 @@ func checkAndTriggerReRender(ctx context.Context, cli client.Reader, synthesized
 	return nil
 }
 
-func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) error {
+// buildTLSCert builds the TLS certificates of the component, and returns the secret which contains the certificates in use.
+func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) (*corev1.Secret, error) {
 	tls := synthesizedComp.TLSConfig
 	if tls == nil || !tls.Enable {
-		return nil
+		return nil, nil
 	}
 	if tls.Issuer == nil {
-		return fmt.Errorf("issuer shouldn't be nil when tls enabled")
+		return nil, fmt.Errorf("issuer shouldn't be nil when tls enabled")
 	}
 
 	switch tls.Issuer.Name {
 	case appsv1alpha1.IssuerUserProvided:
 		if err := plan.CheckTLSSecretRef(ctx, cli, synthesizedComp.Namespace, tls.Issuer.SecretRef); err != nil {
-			return err
+			return nil, err
+		}
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: tls.Issuer.SecretRef.Name}
+		if err := cli.Get(ctx, secretKey, secret); err != nil {
+			return nil, err
 		}
+		return secret, nil
 	case appsv1alpha1.IssuerKubeBlocks:
-		secret, err := plan.ComposeTLSSecret(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
-		if err != nil {
-			return err
+		return buildKubeBlocksTLSCert(ctx, cli, synthesizedComp, dag)
+	}
+
+	return nil, nil
+}
+
+// buildKubeBlocksTLSCert issues the TLS certificates signed by the cluster CA for the component,
+// the certificates are re-issued if they are not signed by the cluster CA, the SANs don't cover the component anymore,
+// or they are due for renewal.
+func buildKubeBlocksTLSCert(ctx context.Context, cli client.Reader, synthesizedComp component.SynthesizedComponent, dag *graph.DAG) (*corev1.Secret, error) {
+	caSecret := &corev1.Secret{}
+	caSecretKey := types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: plan.GenerateTLSCASecretName(synthesizedComp.ClusterName)}
+	if err := cli.Get(ctx, caSecretKey, caSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, intctrlutil.NewDelayedRequeueError(time.Second, "wait for the cluster CA secret to be created")
 		}
-		graphCli, _ := cli.(model.GraphClient)
+		return nil, err
+	}
+
+	issuer := synthesizedComp.TLSConfig.Issuer
+	dnsNames := plan.BuildTLSDNSNames(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.Replicas, getComponentServiceNames(synthesizedComp))
+	existed := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)}
+	if err := cli.Get(ctx, secretKey, existed); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		existed = nil
+	}
+	if existed != nil && plan.IsTLSSecretValid(existed, caSecret, dnsNames, issuer) {
+		return existed, nil
+	}
+
+	secret, err := plan.ComposeTLSSecret(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name, caSecret, dnsNames, issuer)
+	if err != nil {
+		return nil, err
+	}
+	graphCli, _ := cli.(model.GraphClient)
+	if existed == nil {
 		graphCli.Create(dag, secret)
+		return secret, nil
+	}
+	// the renewed certificates are delivered to the running pods by the kubelet, no restart is required.
+	existedCopy := existed.DeepCopy()
+	existedCopy.Data = make(map[string][]byte)
+	for k, v := range secret.StringData {
+		existedCopy.Data[k] = []byte(v)
 	}
+	graphCli.Update(dag, existed, existedCopy)
+	return existedCopy, nil
+}
 
-	return nil
+// getComponentServiceNames gets the names of the services of the component.
+func getComponentServiceNames(synthesizedComp component.SynthesizedComponent) []string {
+	svcNames := make([]string, 0)
+	for _, svc := range synthesizedComp.ComponentServices {
+		if !svc.GeneratePodOrdinalService {
+			svcNames = append(svcNames, constant.GenerateComponentServiceName(synthesizedComp.ClusterName, synthesizedComp.Name, svc.ServiceName))
+			continue
+		}
+		for i := int32(0); i < synthesizedComp.Replicas; i++ {
+			svcName := fmt.Sprintf("%d", i)
+			if len(svc.ServiceName) > 0 {
+				svcName = fmt.Sprintf("%s-%d", svc.ServiceName, i)
+			}
+			svcNames = append(svcNames, constant.GenerateComponentServiceName(synthesizedComp.ClusterName, synthesizedComp.Name, svcName))
+		}
+	}
+	return svcNames
 }
 
 func updateTLSVolumeAndVolumeMount(podSpec *corev1.PodSpec, clusterName string, synthesizeComp component.SynthesizedComponent) error {
@@ -205,17 +317,23 @@ func composeTLSVolume(clusterName string, synthesizeComp component.SynthesizedCo
 		cert = tls.Issuer.SecretRef.Cert
 		key = tls.Issuer.SecretRef.Key
 	}
+	items := []corev1.KeyToPath{
+		{Key: ca, Path: constant.CAName},
+		{Key: cert, Path: constant.CertName},
+		{Key: key, Path: constant.KeyName},
+	}
+	if tls.Issuer.Name == appsv1alpha1.IssuerKubeBlocks && tls.Issuer.EnableClientCert {
+		items = append(items,
+			corev1.KeyToPath{Key: constant.ClientCertName, Path: constant.ClientCertName},
+			corev1.KeyToPath{Key: constant.ClientKeyName, Path: constant.ClientKeyName})
+	}
 	mode := int32(0600)
 	volume := corev1.Volume{
 		Name: constant.VolumeName,
 		VolumeSource: corev1.VolumeSource{
 			Secret: &corev1.SecretVolumeSource{
-				SecretName: secretName,
-				Items: []corev1.KeyToPath{
-					{Key: ca, Path: constant.CAName},
-					{Key: cert, Path: constant.CertName},
-					{Key: key, Path: constant.KeyName},
-				},
+				SecretName:  secretName,
+				Items:       items,
 				Optional:    func() *bool { o := false; return &o }(),
 				DefaultMode: &mode,
 			},
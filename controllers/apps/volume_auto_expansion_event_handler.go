@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/k8score"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorryutil "github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+const (
+	// volumeAutoExpansionAnnotKey is used to mark the high volume watermark event has been handled.
+	volumeAutoExpansionAnnotKey = "apps.kubeblocks.io/volume-auto-expansion-handled"
+
+	reasonVolumeAutoExpansion        = "VolumeAutoExpansion"
+	reasonVolumeAutoExpansionLimited = "VolumeAutoExpansionLimited"
+)
+
+// VolumeAutoExpansionEventHandler expands the volumes by VolumeExpansion OpsRequests when lorry reports
+// that their space usages are over the high watermark. The instance locked by the volume protection
+// is unlocked by lorry once the space usages drop below the high watermark after the expansion.
+type VolumeAutoExpansionEventHandler struct{}
+
+var _ k8score.EventHandler = &VolumeAutoExpansionEventHandler{}
+
+func init() {
+	k8score.EventHandlerMap["volume-auto-expansion-handler"] = &VolumeAutoExpansionEventHandler{}
+}
+
+// Handle handles the high volume watermark events.
+func (h *VolumeAutoExpansionEventHandler) Handle(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder, event *corev1.Event) error {
+	if event.Reason != lorryutil.HighVolumeWatermarkReason || event.InvolvedObject.Kind != constant.PodKind {
+		return nil
+	}
+	if event.Annotations[volumeAutoExpansionAnnotKey] == "true" {
+		return nil
+	}
+	if err := expandVolumesForEvent(cli, reqCtx, recorder, event); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(event.DeepCopy())
+	if event.Annotations == nil {
+		event.Annotations = map[string]string{}
+	}
+	event.Annotations[volumeAutoExpansionAnnotKey] = "true"
+	return cli.Patch(reqCtx.Ctx, event, patch)
+}
+
+// expandVolumesForEvent creates a VolumeExpansion OpsRequest for the volumes over the high watermark.
+func expandVolumesForEvent(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder, event *corev1.Event) error {
+	usages := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(event.Message), &usages); err != nil {
+		reqCtx.Log.Info("parse volume usages failed", "message", event.Message, "error", err)
+		return nil
+	}
+	var highVolumes []string
+	if raw, ok := usages[lorryutil.VolumeHighWatermarkField]; ok {
+		if err := json.Unmarshal(raw, &highVolumes); err != nil {
+			reqCtx.Log.Info("parse volumes over the high watermark failed", "message", event.Message, "error", err)
+			return nil
+		}
+	}
+	if len(highVolumes) == 0 {
+		return nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Namespace: event.InvolvedObject.Namespace, Name: event.InvolvedObject.Name}, pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	clusterName := pod.Labels[constant.AppInstanceLabelKey]
+	compName := pod.Labels[constant.KBAppComponentLabelKey]
+	if clusterName == "" || compName == "" {
+		return nil
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Namespace: pod.Namespace, Name: clusterName}, cluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	compSpec := cluster.Spec.GetComponentByName(compName)
+	if compSpec == nil {
+		return nil
+	}
+
+	vcts := make([]appsv1alpha1.OpsRequestVolumeClaimTemplate, 0)
+	for _, vct := range compSpec.VolumeClaimTemplates {
+		if vct.AutoExpansion == nil || !slices.Contains(highVolumes, vct.Name) {
+			continue
+		}
+		current := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+		next, ok := vct.AutoExpansion.NextSize(current)
+		if !ok {
+			recorder.Event(cluster, corev1.EventTypeWarning, reasonVolumeAutoExpansionLimited,
+				fmt.Sprintf("volume %s of component %s can not be expanded automatically beyond %s", vct.Name, compName, current.String()))
+			continue
+		}
+		vcts = append(vcts, appsv1alpha1.OpsRequestVolumeClaimTemplate{Name: vct.Name, Storage: next})
+	}
+	if len(vcts) == 0 {
+		return nil
+	}
+
+	running, err := hasRunningVolumeExpansion(cli, reqCtx, cluster)
+	if err != nil || running {
+		return err
+	}
+	opsRequest := buildVolumeAutoExpansionOpsRequest(cluster, compName, vcts)
+	if err = cli.Create(reqCtx.Ctx, opsRequest); err != nil {
+		return err
+	}
+	volumes := make([]string, 0, len(vcts))
+	for _, vct := range vcts {
+		volumes = append(volumes, fmt.Sprintf("%s=%s", vct.Name, vct.Storage.String()))
+	}
+	reqCtx.Log.Info("create volume auto expansion OpsRequest", "opsRequest", opsRequest.Name, "volumes", volumes)
+	recorder.Event(cluster, corev1.EventTypeNormal, reasonVolumeAutoExpansion,
+		fmt.Sprintf("create OpsRequest %s to expand volumes of component %s: %s", opsRequest.Name, compName, strings.Join(volumes, ",")))
+	return nil
+}
+
+// hasRunningVolumeExpansion checks if there is a VolumeExpansion OpsRequest of the cluster in progress.
+func hasRunningVolumeExpansion(cli client.Client, reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster) (bool, error) {
+	opsRequests := &appsv1alpha1.OpsRequestList{}
+	if err := cli.List(reqCtx.Ctx, opsRequests, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:    cluster.Name,
+		constant.OpsRequestTypeLabelKey: string(appsv1alpha1.VolumeExpansionType),
+	}); err != nil {
+		return false, err
+	}
+	for i := range opsRequests.Items {
+		if !opsRequests.Items[i].IsComplete() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func buildVolumeAutoExpansionOpsRequest(cluster *appsv1alpha1.Cluster, compName string,
+	vcts []appsv1alpha1.OpsRequestVolumeClaimTemplate) *appsv1alpha1.OpsRequest {
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-volume-auto-expansion-%s", cluster.Name, compName, rand.String(4)),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:                   cluster.Name,
+				constant.KBAppComponentLabelKey:                compName,
+				constant.OpsRequestTypeLabelKey:                string(appsv1alpha1.VolumeExpansionType),
+				constant.OpsRequestVolumeAutoExpansionLabelKey: "true",
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterRef: cluster.Name,
+			Type:       appsv1alpha1.VolumeExpansionType,
+			VolumeExpansionList: []appsv1alpha1.VolumeExpansion{
+				{
+					ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: compName},
+					VolumeClaimTemplates: vcts,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorryutil "github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+var _ = Describe("volume auto expansion event handler", func() {
+	const (
+		namespace   = "default"
+		clusterName = "test-cluster"
+		compName    = "mysql"
+		podName     = "test-cluster-mysql-0"
+		volumeName  = "data"
+	)
+
+	var (
+		cli      client.Client
+		recorder *record.FakeRecorder
+		reqCtx   intctrlutil.RequestCtx
+		handler  = &VolumeAutoExpansionEventHandler{}
+	)
+
+	newEvent := func(message string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: podName + ".high-watermark"},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      constant.PodKind,
+				Namespace: namespace,
+				Name:      podName,
+			},
+			Reason:  lorryutil.HighVolumeWatermarkReason,
+			Message: message,
+		}
+	}
+
+	setup := func(storage string, autoExpansion *appsv1alpha1.VolumeAutoExpansion, objs ...client.Object) {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+					{
+						Name: compName,
+						VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+							{
+								Name: volumeName,
+								Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(storage)},
+									},
+								},
+								AutoExpansion: autoExpansion,
+							},
+						},
+					},
+				},
+			},
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      podName,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+				},
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, cluster, pod)...).Build()
+		recorder = record.NewFakeRecorder(10)
+		reqCtx = intctrlutil.RequestCtx{Ctx: context.Background(), Log: logf.FromContext(context.Background())}
+	}
+
+	handle := func(event *corev1.Event) {
+		Expect(cli.Create(reqCtx.Ctx, event)).Should(Succeed())
+		Expect(handler.Handle(cli, reqCtx, recorder, event)).Should(Succeed())
+	}
+
+	listOpsRequests := func() []appsv1alpha1.OpsRequest {
+		opsRequests := &appsv1alpha1.OpsRequestList{}
+		Expect(cli.List(reqCtx.Ctx, opsRequests, client.InNamespace(namespace))).Should(Succeed())
+		return opsRequests.Items
+	}
+
+	autoExpansion := &appsv1alpha1.VolumeAutoExpansion{
+		MaxSize: resource.MustParse("25Gi"),
+		Step:    resource.MustParse("10Gi"),
+	}
+
+	It("creates a VolumeExpansion OpsRequest for the volumes over the high watermark", func() {
+		setup("10Gi", autoExpansion)
+		event := newEvent(`{"highWatermark":"90","volumes":[{"data":"95%"}],"highVolumes":["data"]}`)
+		handle(event)
+
+		opsRequests := listOpsRequests()
+		Expect(opsRequests).Should(HaveLen(1))
+		ops := opsRequests[0]
+		Expect(ops.Spec.ClusterRef).Should(Equal(clusterName))
+		Expect(ops.Spec.Type).Should(Equal(appsv1alpha1.VolumeExpansionType))
+		Expect(ops.Labels).Should(HaveKeyWithValue(constant.OpsRequestVolumeAutoExpansionLabelKey, "true"))
+		Expect(ops.Spec.VolumeExpansionList).Should(HaveLen(1))
+		Expect(ops.Spec.VolumeExpansionList[0].ComponentName).Should(Equal(compName))
+		Expect(ops.Spec.VolumeExpansionList[0].VolumeClaimTemplates).Should(HaveLen(1))
+		Expect(ops.Spec.VolumeExpansionList[0].VolumeClaimTemplates[0].Storage.String()).Should(Equal("20Gi"))
+		Expect(event.Annotations).Should(HaveKeyWithValue(volumeAutoExpansionAnnotKey, "true"))
+
+		By("the handled event is ignored")
+		Expect(handler.Handle(cli, reqCtx, recorder, event)).Should(Succeed())
+		Expect(listOpsRequests()).Should(HaveLen(1))
+	})
+
+	It("waits for the running VolumeExpansion OpsRequest", func() {
+		running := &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "running-volume-expansion",
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.OpsRequestTypeLabelKey: string(appsv1alpha1.VolumeExpansionType),
+				},
+			},
+			Status: appsv1alpha1.OpsRequestStatus{Phase: appsv1alpha1.OpsRunningPhase},
+		}
+		setup("10Gi", autoExpansion, running)
+		handle(newEvent(`{"highVolumes":["data"]}`))
+		Expect(listOpsRequests()).Should(HaveLen(1))
+	})
+
+	It("does nothing if the volume reaches the max size or auto expansion is disabled", func() {
+		setup("25Gi", autoExpansion)
+		handle(newEvent(`{"highVolumes":["data"]}`))
+		Expect(listOpsRequests()).Should(BeEmpty())
+		Expect(recorder.Events).Should(Receive(ContainSubstring(reasonVolumeAutoExpansionLimited)))
+
+		setup("10Gi", nil)
+		handle(newEvent(`{"highVolumes":["data"]}`))
+		Expect(listOpsRequests()).Should(BeEmpty())
+	})
+})
@@ -24,12 +24,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/client/v3/apis/volumesnapshot/v1beta1"
 	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -41,8 +46,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -64,14 +71,24 @@ type BackupReconciler struct {
 	Scheme     *k8sruntime.Scheme
 	Recorder   record.EventRecorder
 	RestConfig *rest.Config
-	clock      clock.RealClock
+	// APIReader reads the objects from the API server directly, it is used
+	// to count the running backups without the stale cache.
+	APIReader client.Reader
+	clock     clock.RealClock
+
+	// throttleMutex serializes the admission of the throttled backups.
+	throttleMutex sync.Mutex
 }
 
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch;create;delete
+
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;deletecollection
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots/finalizers,verbs=update;patch
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotclasses,verbs=get;list;watch;create;update;patch;delete
@@ -108,7 +125,7 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	switch backup.Status.Phase {
-	case "", dpv1alpha1.BackupPhaseNew:
+	case "", dpv1alpha1.BackupPhaseNew, dpv1alpha1.BackupPhasePending:
 		return r.handleNewPhase(reqCtx, backup)
 	case dpv1alpha1.BackupPhaseRunning:
 		return r.handleRunningPhase(reqCtx, backup)
@@ -129,7 +146,27 @@ func (r *BackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			MaxConcurrentReconciles: viper.GetInt(maxConcurDataProtectionReconKey),
 		}).
 		Owns(&batchv1.Job{}).
-		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.parseBackupJob))
+		Owns(&dpv1alpha1.Restore{}).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.parseBackupJob)).
+		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parseParentBackup),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(_ event.CreateEvent) bool { return false },
+				UpdateFunc:  func(_ event.UpdateEvent) bool { return false },
+				DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+				GenericFunc: func(_ event.GenericEvent) bool { return false },
+			})).
+		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parsePendingBackups),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(_ event.CreateEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					// a running backup is finished, the pending backups may be started.
+					oldBackup, newBackup := e.ObjectOld.(*dpv1alpha1.Backup), e.ObjectNew.(*dpv1alpha1.Backup)
+					return oldBackup.Status.Phase == dpv1alpha1.BackupPhaseRunning &&
+						newBackup.Status.Phase != dpv1alpha1.BackupPhaseRunning
+				},
+				DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+				GenericFunc: func(_ event.GenericEvent) bool { return false },
+			}))
 
 	if intctrlutil.InVolumeSnapshotV1Beta1() {
 		b.Owns(&vsv1beta1.VolumeSnapshot{}, builder.Predicates{})
@@ -155,6 +192,46 @@ func (r *BackupReconciler) parseBackupJob(ctx context.Context, object client.Obj
 	return requests
 }
 
+// parseParentBackup enqueues the parent backup when the backup is deleted,
+// the parent backup may be waiting for its dependents to be deleted.
+func (r *BackupReconciler) parseParentBackup(ctx context.Context, object client.Object) []reconcile.Request {
+	backup := object.(*dpv1alpha1.Backup)
+	parentName := dputils.GetParentBackupName(backup)
+	if parentName == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: backup.Namespace,
+			Name:      parentName,
+		},
+	}}
+}
+
+// parsePendingBackups enqueues the pending backups in the same backup repo
+// when a running backup is finished, they are queued by the throttling of
+// the backup repo.
+func (r *BackupReconciler) parsePendingBackups(ctx context.Context, object client.Object) []reconcile.Request {
+	repoName := object.GetLabels()[dataProtectionBackupRepoKey]
+	if repoName == "" {
+		return nil
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(ctx, backupList, client.MatchingLabels{dataProtectionBackupRepoKey: repoName}); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, backup := range backupList.Items {
+		if backup.Status.Phase != dpv1alpha1.BackupPhasePending {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&backup),
+		})
+	}
+	return requests
+}
+
 // deleteBackupFiles deletes the backup files stored in backup repository.
 func (r *BackupReconciler) deleteBackupFiles(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) error {
 	deleteBackup := func() error {
@@ -206,6 +283,14 @@ func (r *BackupReconciler) handleDeletingPhase(reqCtx intctrlutil.RequestCtx, ba
 		return intctrlutil.Reconciled()
 	}
 
+	// do not delete the backup data if there are alive backups depending on it,
+	// the backup will be reconciled again when its dependents are deleted.
+	if retained, err := r.retainBackupWithDependents(reqCtx, backup); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	} else if retained {
+		return intctrlutil.Reconciled()
+	}
+
 	if err := r.deleteVolumeSnapshots(reqCtx, backup); err != nil {
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	}
@@ -216,12 +301,41 @@ func (r *BackupReconciler) handleDeletingPhase(reqCtx intctrlutil.RequestCtx, ba
 	return intctrlutil.Reconciled()
 }
 
+// retainBackupWithDependents checks if there are alive backups depending on the
+// backup, and sets the retained condition if there are.
+func (r *BackupReconciler) retainBackupWithDependents(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (bool, error) {
+	graph, err := dpbackup.GetBackupDependencyGraph(reqCtx.Ctx, r.Client, backup)
+	if err != nil {
+		return false, err
+	}
+	var dependents []string
+	for _, b := range graph.GetDependents(backup.Name) {
+		dependents = append(dependents, b.Name)
+	}
+	if len(dependents) == 0 {
+		return false, nil
+	}
+	message := fmt.Sprintf("can not delete the backup data, the backups %s depend on it", strings.Join(dependents, ","))
+	patch := client.MergeFrom(backup.DeepCopy())
+	if dpbackup.SetRetainedCondition(backup, metav1.ConditionTrue, dpbackup.ReasonHasDependents, message) {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, dpbackup.ReasonHasDependents, message)
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 func (r *BackupReconciler) handleNewPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	if dpbackup.IsReplica(backup) {
+		return r.handleReplicaNewPhase(reqCtx, backup)
+	}
 	request, err := r.prepareBackupRequest(reqCtx, backup)
 	if err != nil {
-		if intctrlutil.IsTargetError(err, dperrors.ErrorTypeWaitForExternalHandler) {
+		if intctrlutil.IsTargetError(err, dperrors.ErrorTypeWaitForExternalHandler) ||
+			intctrlutil.IsTargetError(err, dperrors.ErrorTypeParentBackupNotReady) {
 			return RecorderEventAndRequeue(reqCtx, r.Recorder, backup, err)
 		}
 		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
@@ -235,6 +349,15 @@ func (r *BackupReconciler) handleNewPhase(
 		return intctrlutil.Reconciled()
 	}
 
+	// queue the backup if the max concurrent backups of the backup repo is reached.
+	unlock := r.lockThrottling(request)
+	defer unlock()
+	if throttled, err := r.checkThrottling(reqCtx, backup, request); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	} else if throttled {
+		return intctrlutil.Reconciled()
+	}
+
 	// set and patch backup status
 	if err = r.patchBackupStatus(backup, request); err != nil {
 		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
@@ -285,8 +408,7 @@ func (r *BackupReconciler) prepareBackupRequest(
 		actionSet, err := dputils.GetActionSetByName(reqCtx, r.Client, backupMethod.ActionSetName)
 		if err != nil {
 			return nil, err
-		} else if actionSet.Spec.BackupType != dpv1alpha1.BackupTypeFull {
-			// TODO: refactor it if supports other backup type.
+		} else if actionSet.Spec.BackupType == dpv1alpha1.BackupTypeContinuous {
 			return nil, intctrlutil.NewErrorf(dperrors.ErrorTypeWaitForExternalHandler,
 				`wait for external handler to handle this backup type "%s"`, actionSet.Spec.BackupType)
 		}
@@ -310,6 +432,11 @@ func (r *BackupReconciler) prepareBackupRequest(
 	}
 	request.BackupMethod = backupMethod
 
+	// resolve the parent backup for incremental or differential backup.
+	if err = request.ResolveParentBackup(); err != nil {
+		return nil, err
+	}
+
 	targetPods, err := GetTargetPods(reqCtx, r.Client,
 		backup.Annotations[dptypes.BackupTargetPodLabelKey], backupMethod, backupPolicy)
 	if err != nil || len(targetPods) == 0 {
@@ -333,6 +460,8 @@ func (r *BackupReconciler) patchBackupStatus(
 	if request.BackupRepoPVC != nil {
 		request.Status.PersistentVolumeClaimName = request.BackupRepoPVC.Name
 	}
+	// record the key ID, so the backup can be restored after the key is rotated.
+	request.Status.Encryption = dpbackup.BuildBackupEncryption(request.BackupRepo)
 	if request.BackupPolicy.Spec.UseKopia {
 		request.Status.KopiaRepoPath = dpbackup.BuildKopiaRepoPath(request.Backup, request.BackupPolicy.Spec.PathPrefix)
 	}
@@ -363,6 +492,9 @@ func (r *BackupReconciler) patchBackupStatus(
 func (r *BackupReconciler) handleRunningPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	if dpbackup.IsReplica(backup) {
+		return r.handleReplicaRunningPhase(reqCtx, backup)
+	}
 	request, err := r.prepareBackupRequest(reqCtx, backup)
 	if err != nil {
 		// external controller is already processing it, only mark reconciled
@@ -413,34 +545,222 @@ func (r *BackupReconciler) handleRunningPhase(
 	}
 
 	// all actions completed, update backup status to completed
-	request.Status.Phase = dpv1alpha1.BackupPhaseCompleted
-	request.Status.CompletionTimestamp = &metav1.Time{Time: r.clock.Now().UTC()}
-	if !request.Status.StartTimestamp.IsZero() {
+	return r.completeBackup(reqCtx, backup, request.Backup)
+}
+
+// completeBackup updates the backup status to completed, and sets the
+// expiration time by the retention period.
+func (r *BackupReconciler) completeBackup(
+	reqCtx intctrlutil.RequestCtx,
+	original *dpv1alpha1.Backup,
+	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+	backup.Status.CompletionTimestamp = &metav1.Time{Time: r.clock.Now().UTC()}
+	if !backup.Status.StartTimestamp.IsZero() {
 		// round the duration to a multiple of seconds.
-		duration := request.Status.CompletionTimestamp.Sub(request.Status.StartTimestamp.Time).Round(time.Second)
-		request.Status.Duration = &metav1.Duration{Duration: duration}
+		duration := backup.Status.CompletionTimestamp.Sub(backup.Status.StartTimestamp.Time).Round(time.Second)
+		backup.Status.Duration = &metav1.Duration{Duration: duration}
 	}
-	if request.Spec.RetentionPeriod != "" {
+	if backup.Spec.RetentionPeriod != "" {
 		// set expiration time
-		duration, err := request.Spec.RetentionPeriod.ToDuration()
+		duration, err := backup.Spec.RetentionPeriod.ToDuration()
 		if err != nil {
-			return r.updateStatusIfFailed(reqCtx, backup, request.Backup, fmt.Errorf("failed to parse retention period %s, %v", request.Spec.RetentionPeriod, err))
+			return r.updateStatusIfFailed(reqCtx, original, backup, fmt.Errorf("failed to parse retention period %s, %v", backup.Spec.RetentionPeriod, err))
 		}
 		if duration.Seconds() > 0 {
-			request.Status.Expiration = &metav1.Time{
-				Time: request.Status.CompletionTimestamp.Add(duration),
+			backup.Status.Expiration = &metav1.Time{
+				Time: backup.Status.CompletionTimestamp.Add(duration),
 			}
 		}
 	}
-	r.Recorder.Event(backup, corev1.EventTypeNormal, "CreatedBackup", "Completed backup")
+	r.Recorder.Event(original, corev1.EventTypeNormal, "CreatedBackup", "Completed backup")
+	if err := r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// lockThrottling locks the admission of the backups if the backup repo limits
+// the max concurrent backups. The lock is held until the backup is started, so
+// the concurrent reconciles can not take the same free slot.
+func (r *BackupReconciler) lockThrottling(request *dpbackup.Request) func() {
+	if dpbackup.GetMaxConcurrentBackups(request.BackupRepo) == 0 {
+		return func() {}
+	}
+	r.throttleMutex.Lock()
+	return r.throttleMutex.Unlock
+}
+
+// checkThrottling checks the max concurrent backups of the backup repo. If the
+// limit is reached, the backup is queued in the pending phase with the throttled
+// condition, and it is started when the running backups are finished.
+func (r *BackupReconciler) checkThrottling(
+	reqCtx intctrlutil.RequestCtx,
+	original *dpv1alpha1.Backup,
+	request *dpbackup.Request) (bool, error) {
+	maxConcurrent := dpbackup.GetMaxConcurrentBackups(request.BackupRepo)
+	if maxConcurrent == 0 || !dpbackup.IsThrottledBackup(request.Backup) {
+		return false, nil
+	}
+	// list the backups from the API server, the backups started by the
+	// previous reconciles may not be synced to the cache yet.
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := reader.List(reqCtx.Ctx, backupList,
+		client.MatchingLabels{dataProtectionBackupRepoKey: request.BackupRepo.Name}); err != nil {
+		return false, err
+	}
+	ahead := dpbackup.CountBackupsAhead(original, backupList.Items)
+	if ahead < maxConcurrent {
+		if meta.FindStatusCondition(original.Status.Conditions, dpbackup.ConditionTypeThrottled) != nil {
+			dpbackup.SetThrottledCondition(request.Backup, metav1.ConditionFalse, dpbackup.ReasonThrottlingReleased, "")
+		}
+		return false, nil
+	}
+
+	backup := original.DeepCopy()
+	backup.Status.Phase = dpv1alpha1.BackupPhasePending
+	message := fmt.Sprintf(`%d backups are running or queued ahead in backup repo "%s", the max concurrent backups is %d`,
+		ahead, request.BackupRepo.Name, maxConcurrent)
+	changed := dpbackup.SetThrottledCondition(backup, metav1.ConditionTrue, dpbackup.ReasonMaxConcurrentBackupsReached, message)
+	if original.Status.Phase == dpv1alpha1.BackupPhasePending && !changed {
+		return true, nil
+	}
+	if original.Status.Phase != dpv1alpha1.BackupPhasePending {
+		r.Recorder.Event(original, corev1.EventTypeNormal, dpbackup.ReasonMaxConcurrentBackupsReached, message)
+	}
+	return true, r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original))
+}
+
+// handleReplicaNewPhase handles the replica backup in new phase. It waits for
+// the backup repo of the replica to be prepared, then builds the status of the
+// replica from the source backup.
+func (r *BackupReconciler) handleReplicaNewPhase(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	replicator := &dpbackup.Replicator{RequestCtx: reqCtx, Client: r.Client}
+	source, err := replicator.GetSourceBackup(backup)
+	if err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
+	}
+
+	// the parent of the replica is the replica of the source parent, it should
+	// be completed before the replica is copied.
+	if parentName := backup.Spec.ParentBackupName; parentName != "" {
+		parent := &dpv1alpha1.Backup{}
+		if err = r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backup.Namespace, Name: parentName}, parent); err != nil {
+			if apierrors.IsNotFound(err) {
+				err = fmt.Errorf(`the parent backup "%s" of the source backup is not replicated`, source.Status.ParentBackupName)
+			}
+			return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
+		}
+		if parent.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			return RecorderEventAndRequeue(reqCtx, r.Recorder, backup, dperrors.NewParentBackupNotReady(parentName))
+		}
+	}
+
+	request := &dpbackup.Request{
+		Backup:     backup.DeepCopy(),
+		RequestCtx: reqCtx,
+		Client:     r.Client,
+	}
+	if err = HandleBackupRepo(request); err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
+	}
+
+	// set and patch backup object meta, wait for the backup repo controller to
+	// prepare the essential resources in the namespace.
+	wait := setBackupRepoLabels(request)
+	controllerutil.AddFinalizer(request.Backup, dptypes.DataProtectionFinalizerName)
+	if !reflect.DeepEqual(backup.ObjectMeta, request.ObjectMeta) {
+		if err = r.Client.Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
+			return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
+		}
+	}
+	if wait {
+		return intctrlutil.Reconciled()
+	}
+
+	// queue the replica if the max concurrent backups of the backup repo is reached.
+	unlock := r.lockThrottling(request)
+	defer unlock()
+	if throttled, err := r.checkThrottling(reqCtx, backup, request); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	} else if throttled {
+		return intctrlutil.Reconciled()
+	}
+
+	// set and patch backup status
+	dpbackup.BuildReplicaStatus(request.Backup, source, request.BackupRepo)
+	if request.BackupRepoPVC != nil {
+		request.Status.PersistentVolumeClaimName = request.BackupRepoPVC.Name
+	}
+	request.Status.Phase = dpv1alpha1.BackupPhaseRunning
+	request.Status.StartTimestamp = &metav1.Time{Time: r.clock.Now().UTC()}
+	if err = dpbackup.SetExpirationByCreationTime(request.Backup); err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
+	}
 	if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 	return intctrlutil.Reconciled()
 }
 
+// handleReplicaRunningPhase handles the replica backup in running phase, it
+// runs the job to copy the backup data from the backup repo of the source
+// backup to the backup repo of the replica.
+func (r *BackupReconciler) handleReplicaRunningPhase(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	replicator := &dpbackup.Replicator{RequestCtx: reqCtx, Client: r.Client}
+	source, err := replicator.GetSourceBackup(backup)
+	if err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
+	}
+	sourceRepo, err := replicator.GetSourceBackupRepo(source)
+	if err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
+	}
+	repo := &dpv1alpha1.BackupRepo{}
+	if err = r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, repo); err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
+	}
+
+	replica := backup.DeepCopy()
+	if err = replicator.PrepareEncryptionKeys(replica, source, sourceRepo, repo); err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup, replica, err)
+	}
+	act := replicator.BuildReplicateAction(replica, source, sourceRepo, repo)
+	status, err := act.Execute(action.Context{
+		Ctx:              reqCtx.Ctx,
+		Client:           r.Client,
+		Recorder:         r.Recorder,
+		Scheme:           r.Scheme,
+		RestClientConfig: r.RestConfig,
+	})
+	if err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup, replica, err)
+	}
+	replica.Status.Actions[0] = mergeActionStatus(&replica.Status.Actions[0], status)
+	switch status.Phase {
+	case dpv1alpha1.ActionPhaseCompleted:
+		return r.completeBackup(reqCtx, backup, replica)
+	case dpv1alpha1.ActionPhaseFailed:
+		return r.updateStatusIfFailed(reqCtx, backup, replica,
+			fmt.Errorf("action %s failed, %s", act.GetName(), status.FailureReason))
+	default:
+		if err = r.Client.Status().Patch(reqCtx.Ctx, replica, client.MergeFrom(backup)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+}
+
 // handleCompletedPhase handles the backup object in completed phase.
-// It will delete the reference workloads.
+// It will delete the reference workloads and handle the verification request.
 func (r *BackupReconciler) handleCompletedPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
@@ -448,6 +768,81 @@ func (r *BackupReconciler) handleCompletedPhase(
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 
+	if err := r.handleReplication(reqCtx, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	return r.handleVerification(reqCtx, backup)
+}
+
+// handleReplication creates the replica backups of the completed backup for
+// the replications of the backup policy, and records them in the backup status.
+func (r *BackupReconciler) handleReplication(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) error {
+	if dpbackup.IsReplica(backup) {
+		return nil
+	}
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	policyKey := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.BackupPolicyName}
+	if err := r.Client.Get(reqCtx.Ctx, policyKey, backupPolicy); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	original := backup.DeepCopy()
+	for _, replication := range dpbackup.GetReplications(backupPolicy, backup) {
+		if slices.ContainsFunc(backup.Status.Replicas, func(replica dpv1alpha1.BackupReplica) bool {
+			return replica.ReplicationName == replication.Name
+		}) {
+			continue
+		}
+		replica := dpbackup.BuildReplicaBackup(backup, &replication)
+		replica.Labels[dataProtectionBackupRepoKey] = replication.BackupRepoName
+		if err := r.Client.Create(reqCtx.Ctx, replica); client.IgnoreAlreadyExists(err) != nil {
+			return err
+		}
+		r.Recorder.Eventf(backup, corev1.EventTypeNormal, "CreatedReplica",
+			"created replica backup %s in backup repo %s", replica.Name, replication.BackupRepoName)
+		backup.Status.Replicas = append(backup.Status.Replicas, dpv1alpha1.BackupReplica{
+			ReplicationName: replication.Name,
+			BackupName:      replica.Name,
+			BackupRepoName:  replication.BackupRepoName,
+		})
+	}
+	if reflect.DeepEqual(original.Status, backup.Status) {
+		return nil
+	}
+	return r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original))
+}
+
+// handleVerification verifies the backup is restorable if it is requested by
+// the verification request annotation, and patches the verification status.
+func (r *BackupReconciler) handleVerification(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	original := backup.DeepCopy()
+	verifier := &dpbackup.Verifier{
+		RequestCtx:       reqCtx,
+		Client:           r.Client,
+		Scheme:           r.Scheme,
+		RestClientConfig: r.RestConfig,
+	}
+	inProgress, err := verifier.Verify(backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !reflect.DeepEqual(original.Status, backup.Status) {
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if v := backup.Status.Verification; v.Phase == dpv1alpha1.BackupVerificationPhaseFailed {
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "VerificationFailed", v.FailureReason)
+		} else if v.Phase == dpv1alpha1.BackupVerificationPhasePassed {
+			r.Recorder.Event(backup, corev1.EventTypeNormal, "VerificationPassed", "backup is verified to be restorable")
+		}
+	}
+	if inProgress {
+		return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log, "")
+	}
 	return intctrlutil.Reconciled()
 }
 
@@ -521,16 +916,7 @@ func PatchBackupObjectMeta(
 	request.Labels[constant.AppManagedByLabelKey] = dptypes.AppName
 	request.Labels[dptypes.BackupTypeLabelKey] = request.GetBackupType()
 	request.Labels[dptypes.BackupPolicyLabelKey] = request.Spec.BackupPolicyName
-	// wait for the backup repo controller to prepare the essential resource.
-	wait := false
-	if request.BackupRepo != nil {
-		request.Labels[dataProtectionBackupRepoKey] = request.BackupRepo.Name
-		if (request.BackupRepo.AccessByMount() && request.BackupRepoPVC == nil) ||
-			(request.BackupRepo.AccessByTool() && request.ToolConfigSecret == nil) {
-			request.Labels[dataProtectionWaitRepoPreparationKey] = trueVal
-			wait = true
-		}
-	}
+	wait := setBackupRepoLabels(request)
 
 	// set annotations
 	request.Annotations[dptypes.BackupTargetPodLabelKey] = targetPod.Name
@@ -545,6 +931,22 @@ func PatchBackupObjectMeta(
 	return wait, request.Client.Patch(request.Ctx, request.Backup, client.MergeFrom(original))
 }
 
+// setBackupRepoLabels sets the backup repo labels of the backup, and returns
+// true if it needs to wait for the backup repo controller to prepare the
+// essential resources.
+func setBackupRepoLabels(request *dpbackup.Request) bool {
+	if request.BackupRepo == nil {
+		return false
+	}
+	request.Labels[dataProtectionBackupRepoKey] = request.BackupRepo.Name
+	if (request.BackupRepo.AccessByMount() && request.BackupRepoPVC == nil) ||
+		(request.BackupRepo.AccessByTool() && request.ToolConfigSecret == nil) {
+		request.Labels[dataProtectionWaitRepoPreparationKey] = trueVal
+		return true
+	}
+	return false
+}
+
 func mergeActionStatus(original, new *dpv1alpha1.ActionStatus) dpv1alpha1.ActionStatus {
 	as := new.DeepCopy()
 	if original.StartTimestamp != nil {
@@ -128,8 +128,9 @@ type BackupRepoReconciler struct {
 	Recorder   record.EventRecorder
 	RestConfig *rest.Config
 
-	secretRefMapper   refObjectMapper
-	providerRefMapper refObjectMapper
+	secretRefMapper        refObjectMapper
+	providerRefMapper      refObjectMapper
+	encryptionKeyRefMapper refObjectMapper
 }
 
 // full access on BackupRepos
@@ -193,6 +194,12 @@ func (r *BackupRepoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		})
 	}
 	r.providerRefMapper.setRef(repo, types.NamespacedName{Name: repo.Spec.StorageProviderRef})
+	if repo.Spec.Encryption != nil {
+		r.encryptionKeyRefMapper.setRef(repo, types.NamespacedName{
+			Name:      repo.Spec.Encryption.KeySecretRef.Name,
+			Namespace: repo.Spec.Encryption.KeySecretRef.Namespace,
+		})
+	}
 
 	// check storage provider
 	provider, err := r.checkStorageProvider(reqCtx, repo)
@@ -395,6 +402,13 @@ func (r *BackupRepoReconciler) checkParameters(reqCtx intctrlutil.RequestCtx,
 		}
 		return nil, err
 	}
+	// check the encryption key
+	if repo.Spec.Encryption != nil {
+		if _, err = r.getEncryptionKeySecret(reqCtx.Ctx, repo); err != nil {
+			reason = ReasonEncryptionKeyNotFound
+			return nil, err
+		}
+	}
 	// TODO: verify parameters
 	reason = ReasonParametersChecked
 	return parameters, nil
@@ -1112,6 +1126,29 @@ func (r *BackupRepoReconciler) createToolConfigSecret(reconCtx *reconcileContext
 	return secret, err
 }
 
+// getEncryptionKeySecret gets the encryption key secret referenced by the repo,
+// and checks that the current key exists in the secret.
+func (r *BackupRepoReconciler) getEncryptionKeySecret(ctx context.Context, repo *dpv1alpha1.BackupRepo) (*corev1.Secret, error) {
+	encryption := repo.Spec.Encryption
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, client.ObjectKey{
+		Namespace: encryption.KeySecretRef.Namespace,
+		Name:      encryption.KeySecretRef.Name,
+	}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, newDependencyError(fmt.Sprintf("encryption key secret %s/%s not found",
+				encryption.KeySecretRef.Namespace, encryption.KeySecretRef.Name))
+		}
+		return nil, fmt.Errorf("failed to get encryption key secret: %w", err)
+	}
+	if len(secret.Data[encryption.CurrentKeyID]) == 0 {
+		return nil, newDependencyError(fmt.Sprintf("key %s not found in the encryption key secret %s/%s",
+			encryption.CurrentKeyID, secret.Namespace, secret.Name))
+	}
+	return secret, nil
+}
+
 func (r *BackupRepoReconciler) collectParameters(
 	reqCtx intctrlutil.RequestCtx, repo *dpv1alpha1.BackupRepo) (map[string]string, error) {
 	values := make(map[string]string)
@@ -1193,6 +1230,7 @@ func (r *BackupRepoReconciler) deleteExternalResources(
 	// maintain mappers
 	r.secretRefMapper.removeRef(repo)
 	r.providerRefMapper.removeRef(repo)
+	r.encryptionKeyRefMapper.removeRef(repo)
 
 	return nil
 }
@@ -1336,7 +1374,8 @@ func (r *BackupRepoReconciler) mapSecretToRepos(ctx context.Context, obj client.
 	}
 
 	// get repos which is referencing this secret
-	return r.secretRefMapper.mapToRequests(obj)
+	requests := r.secretRefMapper.mapToRequests(obj)
+	return append(requests, r.encryptionKeyRefMapper.mapToRequests(obj)...)
 }
 
 // SetupWithManager sets up the controller with the Manager.
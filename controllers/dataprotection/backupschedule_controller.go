@@ -82,11 +82,17 @@ func (r *BackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return *res, err
 	}
 
-	if err = r.handleSchedule(reqCtx, backupSchedule); err != nil {
+	nextVerificationTime, err := r.handleSchedule(reqCtx, backupSchedule)
+	if err != nil {
 		return r.patchStatusFailed(reqCtx, backupSchedule, "HandleBackupScheduleFailed", err)
 	}
 
-	return r.patchStatusAvailable(reqCtx, original, backupSchedule)
+	result, err := r.patchStatusAvailable(reqCtx, original, backupSchedule)
+	if err != nil || nextVerificationTime == nil {
+		return result, err
+	}
+	// requeue to request the next backup verification
+	return intctrlutil.RequeueAfter(time.Until(*nextVerificationTime), reqCtx.Log, "wait for the next verification")
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -185,16 +191,17 @@ func (r *BackupScheduleReconciler) patchStatusFailed(reqCtx intctrlutil.RequestC
 	return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 }
 
-// handleSchedule handles backup schedules for different backup method.
+// handleSchedule handles backup schedules for different backup method, and
+// returns the next backup verification time if any.
 func (r *BackupScheduleReconciler) handleSchedule(
 	reqCtx intctrlutil.RequestCtx,
-	backupSchedule *dpv1alpha1.BackupSchedule) error {
+	backupSchedule *dpv1alpha1.BackupSchedule) (*time.Time, error) {
 	backupPolicy, err := dputils.GetBackupPolicyByName(reqCtx, r.Client, backupSchedule.Spec.BackupPolicyName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err = r.patchScheduleMetadata(reqCtx, backupSchedule); err != nil {
-		return err
+		return nil, err
 	}
 	scheduler := dpbackup.Scheduler{
 		RequestCtx:     reqCtx,
@@ -203,7 +210,10 @@ func (r *BackupScheduleReconciler) handleSchedule(
 		Client:         r.Client,
 		Scheme:         r.Scheme,
 	}
-	return scheduler.Schedule()
+	if err = scheduler.Schedule(); err != nil {
+		return nil, err
+	}
+	return scheduler.NextVerificationTime, nil
 }
 
 func (r *BackupScheduleReconciler) patchScheduleMetadata(
@@ -21,10 +21,14 @@ package dataprotection
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,12 +40,14 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // GCReconciler garbage collection reconciler, which periodically deletes expired backups.
+// The backups in the same backup chain are deleted together when all of them have expired.
 type GCReconciler struct {
 	client.Client
 	Recorder  record.EventRecorder
@@ -83,7 +89,9 @@ func (r *GCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;delete
-// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupschedules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupschedules/status,verbs=get;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // delete expired backups.
@@ -115,21 +123,151 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 	reqCtx.Log = reqCtx.Log.WithValues("expiration", backup.Status.Expiration)
 
 	now := r.clock.Now()
+	if err := r.applyRetentionPolicy(reqCtx, backup, now); err != nil {
+		// the retention policy must not block the expiration of the backup,
+		// it will be applied again in the next reconciliation.
+		reqCtx.Log.Error(err, "failed to apply the retention policy")
+	}
 	if backup.Status.Expiration == nil || backup.Status.Expiration.After(now) {
 		reqCtx.Log.V(1).Info("backup is not expired yet, skipping")
 		return ctrlutil.Reconciled()
 	}
 
-	reqCtx.Log.Info("backup has expired, delete it", "backup", req.String())
-	if err := ctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
-		reqCtx.Log.Error(err, "failed to delete backup")
-		r.Recorder.Event(backup, corev1.EventTypeWarning, "RemoveExpiredBackupsFailed", err.Error())
+	// failed backup can not be depended by other backups, delete it directly.
+	if backup.Status.Phase == dpv1alpha1.BackupPhaseFailed {
+		return r.deleteExpiredBackups(reqCtx, backup)
+	}
+
+	graph, err := dpbackup.GetBackupDependencyGraph(reqCtx.Ctx, r.Client, backup)
+	if err != nil {
+		return ctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	chain := graph.GetChain(backup.Name)
+	if len(chain) == 0 {
+		return r.deleteExpiredBackups(reqCtx, backup)
+	}
+
+	// the backups in the same chain can only be deleted together when all of
+	// them have expired, otherwise the backups depending on the deleted one can
+	// not be restored.
+	var unexpired []string
+	for _, b := range chain {
+		if !dpbackup.IsBackupExpired(b, now) {
+			unexpired = append(unexpired, b.Name)
+		}
+	}
+	if len(unexpired) > 0 {
+		reason := dpbackup.ReasonChainNotExpired
+		if len(graph.GetDependents(backup.Name)) > 0 {
+			reason = dpbackup.ReasonHasDependents
+		}
+		message := fmt.Sprintf("backup has expired but is retained, the backups %s in the same backup chain have not expired",
+			strings.Join(unexpired, ","))
+		reqCtx.Log.V(1).Info("backup has expired but is retained", "reason", reason, "unexpired", unexpired)
+		if err = r.patchRetainedCondition(reqCtx, backup, metav1.ConditionTrue, reason, message); err != nil {
+			return ctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return ctrlutil.Reconciled()
+	}
+
+	names := make([]string, len(chain))
+	for i := range chain {
+		names[i] = chain[i].Name
+	}
+	message := fmt.Sprintf("all backups in the backup chain have expired, delete them together: %s", strings.Join(names, ","))
+	if err = r.patchRetainedCondition(reqCtx, backup, metav1.ConditionFalse, dpbackup.ReasonChainExpired, message); err != nil {
 		return ctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	return r.deleteExpiredBackups(reqCtx, chain...)
+}
 
+// applyRetentionPolicy evaluates the retention policy of the schedule that
+// creates the backup, and expires the backup if it is not kept by the policy.
+// In dry-run mode, the backups not kept are reported in the schedule status
+// instead.
+func (r *GCReconciler) applyRetentionPolicy(reqCtx ctrlutil.RequestCtx, backup *dpv1alpha1.Backup, now time.Time) error {
+	scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]
+	if scheduleName == "" || !dpbackup.IsRetentionCandidate(backup) {
+		return nil
+	}
+	backupSchedule := &dpv1alpha1.BackupSchedule{}
+	if err := r.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backup.Namespace, Name: scheduleName}, backupSchedule); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	method := backup.Spec.BackupMethod
+	policy := dpbackup.GetRetentionPolicy(backupSchedule, method)
+	if policy == nil {
+		return r.patchBackupsToDelete(reqCtx, backupSchedule, method, nil)
+	}
+
+	candidates, err := dpbackup.GetRetentionCandidates(reqCtx.Ctx, r.Client, backup.Namespace, backup.Spec.BackupPolicyName, method)
+	if err != nil {
+		return err
+	}
+	toDelete := dpbackup.EvaluateRetentionPolicy(policy, candidates)
+	if policy.DryRun {
+		return r.patchBackupsToDelete(reqCtx, backupSchedule, method, toDelete)
+	}
+	if err = r.patchBackupsToDelete(reqCtx, backupSchedule, method, nil); err != nil {
+		return err
+	}
+	if !slices.Contains(toDelete, backup.Name) {
+		return nil
+	}
+
+	reqCtx.Log.Info("backup is not kept by the retention policy, expire it", "schedule", scheduleName)
+	// keep the backup unchanged if the patch fails, so the expiration is not
+	// checked against a value which is not persisted.
+	expiredBackup := backup.DeepCopy()
+	expiredBackup.Status.Expiration = &metav1.Time{Time: now}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, expiredBackup, client.MergeFrom(backup)); err != nil {
+		return err
+	}
+	expiredBackup.DeepCopyInto(backup)
+	return nil
+}
+
+// patchBackupsToDelete records the backups to delete by the retention policy
+// in dry-run mode in the schedule status.
+func (r *GCReconciler) patchBackupsToDelete(reqCtx ctrlutil.RequestCtx,
+	backupSchedule *dpv1alpha1.BackupSchedule, method string, names []string) error {
+	status := backupSchedule.Status.Schedules[method]
+	if slices.Equal(status.BackupsToDelete, names) {
+		return nil
+	}
+	patch := client.MergeFrom(backupSchedule.DeepCopy())
+	if backupSchedule.Status.Schedules == nil {
+		backupSchedule.Status.Schedules = map[string]dpv1alpha1.ScheduleStatus{}
+	}
+	status.BackupsToDelete = names
+	backupSchedule.Status.Schedules[method] = status
+	return r.Client.Status().Patch(reqCtx.Ctx, backupSchedule, patch)
+}
+
+// deleteExpiredBackups deletes the expired backups.
+func (r *GCReconciler) deleteExpiredBackups(reqCtx ctrlutil.RequestCtx, backups ...*dpv1alpha1.Backup) (ctrl.Result, error) {
+	for _, backup := range backups {
+		reqCtx.Log.Info("backup has expired, delete it", "backup", client.ObjectKeyFromObject(backup).String())
+		if err := ctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
+			reqCtx.Log.Error(err, "failed to delete backup")
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "RemoveExpiredBackupsFailed", err.Error())
+			return ctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
 	return ctrlutil.Reconciled()
 }
 
+func (r *GCReconciler) patchRetainedCondition(reqCtx ctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup,
+	status metav1.ConditionStatus,
+	reason, message string) error {
+	patch := client.MergeFrom(backup.DeepCopy())
+	if !dpbackup.SetRetainedCondition(backup, status, reason, message) {
+		return nil
+	}
+	return r.Client.Status().Patch(reqCtx.Ctx, backup, patch)
+}
+
 func getGCFrequency() time.Duration {
 	gcFrequencySeconds := viper.GetInt(dptypes.CfgKeyGCFrequencySeconds)
 	if gcFrequencySeconds > 0 {
@@ -27,6 +27,7 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
@@ -53,6 +54,7 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml)
 		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupScheduleSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
 
 		// wait all backup to be deleted, otherwise the controller maybe create
@@ -172,5 +174,79 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 			Eventually(testapps.CheckObjExists(&testCtx, backup1Key, &dpv1alpha1.Backup{}, true)).Should(Succeed())
 			Eventually(testapps.CheckObjExists(&testCtx, expiredKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 		})
+
+		It("delete backups not kept by the retention policy", func() {
+			By("create a backup schedule with a dry-run retention policy")
+			backupSchedule := testdp.NewFakeBackupSchedule(&testCtx, func(schedule *dpv1alpha1.BackupSchedule) {
+				for i := range schedule.Spec.Schedules {
+					if schedule.Spec.Schedules[i].BackupMethod != testdp.BackupMethodName {
+						continue
+					}
+					schedule.Spec.Schedules[i].RetentionPolicy = &dpv1alpha1.BackupRetentionPolicy{
+						KeepLast: pointer.Int32(1),
+						DryRun:   true,
+					}
+				}
+			})
+
+			scheduleLabel := map[string]string{
+				dptypes.AutoBackupLabelKey:     "true",
+				dptypes.BackupScheduleLabelKey: backupSchedule.Name,
+				dptypes.BackupMethodLabelKey:   testdp.BackupMethodName,
+			}
+
+			createCompletedBackup := func(name string, completionTime time.Time) *dpv1alpha1.Backup {
+				backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, name).
+					WithRandomName().AddLabelsInMap(scheduleLabel).
+					SetBackupPolicyName(testdp.BackupPolicyName).
+					SetBackupMethod(testdp.BackupMethodName).
+					Create(&testCtx).GetObject()
+				testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup),
+					func(g Gomega, fetched *dpv1alpha1.Backup) {
+						g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+					})).Should(Succeed())
+				Eventually(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(backup),
+					func(fetched *dpv1alpha1.Backup) {
+						fetched.Status.Expiration = nil
+						fetched.Status.StartTimestamp = &metav1.Time{Time: completionTime}
+						fetched.Status.CompletionTimestamp = &metav1.Time{Time: completionTime}
+					})).Should(Succeed())
+				return backup
+			}
+
+			By("create an older and a newer backup")
+			older := createCompletedBackup(backupNamePrefix+"older", fakeClock.Now().Add(-time.Hour*2))
+			newer := createCompletedBackup(backupNamePrefix+"newer", fakeClock.Now().Add(-time.Hour))
+
+			By("the older backup is reported to delete in dry-run mode")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backupSchedule),
+				func(g Gomega, fetched *dpv1alpha1.BackupSchedule) {
+					g.Expect(fetched.Status.Schedules[testdp.BackupMethodName].BackupsToDelete).
+						To(Equal([]string{older.Name}))
+				})).Should(Succeed())
+			Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(older),
+				&dpv1alpha1.Backup{}, true)).Should(Succeed())
+
+			By("disable dry-run mode")
+			Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(backupSchedule),
+				func(schedule *dpv1alpha1.BackupSchedule) {
+					for i := range schedule.Spec.Schedules {
+						if schedule.Spec.Schedules[i].RetentionPolicy != nil {
+							schedule.Spec.Schedules[i].RetentionPolicy.DryRun = false
+						}
+					}
+				})).Should(Succeed())
+
+			By("the older backup is deleted and the newer backup is retained")
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(older),
+				&dpv1alpha1.Backup{}, false)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(newer),
+				&dpv1alpha1.Backup{}, true)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backupSchedule),
+				func(g Gomega, fetched *dpv1alpha1.BackupSchedule) {
+					g.Expect(fetched.Status.Schedules[testdp.BackupMethodName].BackupsToDelete).To(BeEmpty())
+				})).Should(Succeed())
+		})
 	})
 })
@@ -63,6 +63,7 @@ const (
 	ReasonInvalidStorageProvider    = "InvalidStorageProvider"
 	ReasonParametersChecked         = "ParametersChecked"
 	ReasonCredentialSecretNotFound  = "CredentialSecretNotFound"
+	ReasonEncryptionKeyNotFound     = "EncryptionKeyNotFound"
 	ReasonPrepareCSISecretFailed    = "PrepareCSISecretFailed"
 	ReasonPrepareStorageClassFailed = "PrepareStorageClassFailed"
 	ReasonBadPVCTemplate            = "BadPVCTemplate"
@@ -121,7 +121,14 @@ func HandleBackupRepo(request *dpbackup.Request) error {
 			request.ToolConfigSecret = secret
 		}
 	}
-	return nil
+
+	// copy the encryption key used by the backup from the backup repo, the key
+	// recorded in the status is used if the backup has been started.
+	encryption := request.Status.Encryption
+	if encryption == nil {
+		encryption = dpbackup.BuildBackupEncryption(repo)
+	}
+	return dputils.EnsureEncryptionKeySecret(request.Ctx, request.Client, request.Backup, repo, encryption)
 }
 
 // GetTargetPods gets the target pods by BackupPolicy. If podName is not empty,
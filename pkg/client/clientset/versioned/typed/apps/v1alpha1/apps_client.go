@@ -39,6 +39,7 @@ type AppsV1alpha1Interface interface {
 	ConfigConstraintsGetter
 	OpsDefinitionsGetter
 	OpsRequestsGetter
+	OpsRequestSchedulesGetter
 	ServiceDescriptorsGetter
 }
 
@@ -91,6 +92,10 @@ func (c *AppsV1alpha1Client) OpsRequests(namespace string) OpsRequestInterface {
 	return newOpsRequests(c, namespace)
 }
 
+func (c *AppsV1alpha1Client) OpsRequestSchedules(namespace string) OpsRequestScheduleInterface {
+	return newOpsRequestSchedules(c, namespace)
+}
+
 func (c *AppsV1alpha1Client) ServiceDescriptors(namespace string) ServiceDescriptorInterface {
 	return newServiceDescriptors(c, namespace)
 }
@@ -72,6 +72,10 @@ func (c *FakeAppsV1alpha1) OpsRequests(namespace string) v1alpha1.OpsRequestInte
 	return &FakeOpsRequests{c, namespace}
 }
 
+func (c *FakeAppsV1alpha1) OpsRequestSchedules(namespace string) v1alpha1.OpsRequestScheduleInterface {
+	return &FakeOpsRequestSchedules{c, namespace}
+}
+
 func (c *FakeAppsV1alpha1) ServiceDescriptors(namespace string) v1alpha1.ServiceDescriptorInterface {
 	return &FakeServiceDescriptors{c, namespace}
 }
@@ -40,4 +40,6 @@ type OpsDefinitionExpansion interface{}
 
 type OpsRequestExpansion interface{}
 
+type OpsRequestScheduleExpansion interface{}
+
 type ServiceDescriptorExpansion interface{}
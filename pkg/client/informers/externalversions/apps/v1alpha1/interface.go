@@ -46,6 +46,8 @@ type Interface interface {
 	OpsDefinitions() OpsDefinitionInformer
 	// OpsRequests returns a OpsRequestInformer.
 	OpsRequests() OpsRequestInformer
+	// OpsRequestSchedules returns a OpsRequestScheduleInformer.
+	OpsRequestSchedules() OpsRequestScheduleInformer
 	// ServiceDescriptors returns a ServiceDescriptorInformer.
 	ServiceDescriptors() ServiceDescriptorInformer
 }
@@ -116,6 +118,11 @@ func (v *version) OpsRequests() OpsRequestInformer {
 	return &opsRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// OpsRequestSchedules returns a OpsRequestScheduleInformer.
+func (v *version) OpsRequestSchedules() OpsRequestScheduleInformer {
+	return &opsRequestScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ServiceDescriptors returns a ServiceDescriptorInformer.
 func (v *version) ServiceDescriptors() ServiceDescriptorInformer {
 	return &serviceDescriptorInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
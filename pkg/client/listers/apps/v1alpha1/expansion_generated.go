@@ -74,6 +74,14 @@ type OpsRequestListerExpansion interface{}
 // OpsRequestNamespaceLister.
 type OpsRequestNamespaceListerExpansion interface{}
 
+// OpsRequestScheduleListerExpansion allows custom methods to be added to
+// OpsRequestScheduleLister.
+type OpsRequestScheduleListerExpansion interface{}
+
+// OpsRequestScheduleNamespaceListerExpansion allows custom methods to be added to
+// OpsRequestScheduleNamespaceLister.
+type OpsRequestScheduleNamespaceListerExpansion interface{}
+
 // ServiceDescriptorListerExpansion allows custom methods to be added to
 // ServiceDescriptorLister.
 type ServiceDescriptorListerExpansion interface{}
@@ -41,6 +41,11 @@ import (
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+const (
+	// TLSReloadSpecName is the name of the handler to reload the TLS certificates.
+	TLSReloadSpecName = "kb-tls-certs"
+)
+
 const (
 	configTemplateName = "reload.yaml"
 	scriptVolumePrefix = "cm-script-"
@@ -88,9 +93,44 @@ func BuildConfigManagerContainerParams(cli client.Client, ctx context.Context, m
 	downwardAPIVolumes := buildDownwardAPIVolumes(managerParams)
 	allVolumeMounts = append(allVolumeMounts, downwardAPIVolumes...)
 	managerParams.Volumes = append(managerParams.Volumes, downwardAPIVolumes...)
+	if managerParams.TLSReloadSpec = buildTLSReloadSpec(managerParams); managerParams.TLSReloadSpec != nil {
+		allVolumeMounts = append(allVolumeMounts, *managerParams.TLSVolume)
+		managerParams.Volumes = append(managerParams.Volumes, *managerParams.TLSVolume)
+	}
 	return buildConfigManagerArgs(managerParams, allVolumeMounts, cli, ctx)
 }
 
+// buildTLSReloadSpec builds the handle meta to reload the TLS certificates by the tlsReloadOptions of the ConfigConstraint,
+// the TLS volume is watched by the config manager only if the certificates can be reloaded.
+func buildTLSReloadSpec(params *CfgManagerBuildParams) *ConfigSpecInfo {
+	if params.TLSVolume == nil {
+		return nil
+	}
+	for _, param := range params.ConfigSpecsBuildParams {
+		options := param.TLSReloadOptions
+		if options == nil || options.UnixSignalTrigger == nil && options.ShellTrigger == nil {
+			continue
+		}
+		reloadOptions := &appsv1alpha1.ReloadOptions{
+			UnixSignalTrigger: options.UnixSignalTrigger,
+			ShellTrigger:      options.ShellTrigger,
+		}
+		return &ConfigSpecInfo{
+			ReloadOptions: reloadOptions,
+			ReloadType:    FromReloadTypeConfig(reloadOptions),
+			ConfigSpec: appsv1alpha1.ComponentConfigSpec{
+				ComponentTemplateSpec: appsv1alpha1.ComponentTemplateSpec{
+					Name:       TLSReloadSpecName,
+					VolumeName: params.TLSVolume.Name,
+				},
+			},
+			MountPoint:      params.TLSVolume.MountPath,
+			TLSCertificates: true,
+		}
+	}
+	return nil
+}
+
 func getWatchedVolume(volumeDirs []corev1.VolumeMount, buildParams []ConfigSpecMeta) []corev1.VolumeMount {
 	enableWatchVolume := func(volume corev1.VolumeMount) bool {
 		for _, param := range buildParams {
@@ -102,6 +142,10 @@ func getWatchedVolume(volumeDirs []corev1.VolumeMount, buildParams []ConfigSpecM
 				return core.IsWatchModuleForTplTrigger(param.ReloadOptions.TPLScriptTrigger)
 			case appsv1alpha1.ShellType:
 				return core.IsWatchModuleForShellTrigger(param.ReloadOptions.ShellTrigger)
+			case appsv1alpha1.HTTPType:
+				return core.IsWatchModuleForHTTPTrigger(param.ReloadOptions.HTTPTrigger)
+			case appsv1alpha1.SQLType:
+				return core.IsWatchModuleForSQLTrigger(param.ReloadOptions.SQLTrigger)
 			default:
 				return true
 			}
@@ -169,7 +213,11 @@ func buildConfigManagerArgs(params *CfgManagerBuildParams, volumeDirs []corev1.V
 	args = append(args, "--operator-update-enable")
 	args = append(args, "--tcp", strconv.Itoa(int(params.ContainerPort)))
 
-	if err := createOrUpdateConfigMap(fromConfigSpecMeta(params.ConfigSpecsBuildParams), params, cli, ctx); err != nil {
+	configSpecs := fromConfigSpecMeta(params.ConfigSpecsBuildParams)
+	if params.TLSReloadSpec != nil {
+		configSpecs = append(configSpecs, *params.TLSReloadSpec)
+	}
+	if err := createOrUpdateConfigMap(configSpecs, params, cli, ctx); err != nil {
 		return err
 	}
 	args = append(args, "--config", filepath.Join(configManagerConfigMountPoint, configManagerConfig))
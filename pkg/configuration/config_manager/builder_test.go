@@ -289,6 +289,48 @@ formatterConfig:
 			}
 		})
 
+		It("builds TLS reloader correctly", func() {
+			param := newCMBuildParams(false)
+			mockTplScriptCM()
+			tlsVolume := corev1.VolumeMount{
+				Name:      "tls",
+				MountPath: "/etc/pki/tls",
+				ReadOnly:  true,
+			}
+			param.TLSVolume = &tlsVolume
+			for i := range param.ConfigSpecsBuildParams {
+				buildParam := &param.ConfigSpecsBuildParams[i]
+				buildParam.ReloadOptions = newReloadOptions(appsv1alpha1.ShellType, nil)
+				buildParam.ReloadType = appsv1alpha1.ShellType
+			}
+
+			By("the TLS volume is not watched without tlsReloadOptions")
+			Expect(BuildConfigManagerContainerParams(mockK8sCli.Client(), context.TODO(), param, newVolumeMounts())).Should(Succeed())
+			Expect(param.TLSReloadSpec).Should(BeNil())
+			Expect(param.Args).ShouldNot(ContainElement(tlsVolume.MountPath))
+
+			By("the TLS volume is watched with tlsReloadOptions")
+			param = newCMBuildParams(false)
+			param.TLSVolume = &tlsVolume
+			for i := range param.ConfigSpecsBuildParams {
+				buildParam := &param.ConfigSpecsBuildParams[i]
+				buildParam.ReloadOptions = newReloadOptions(appsv1alpha1.ShellType, nil)
+				buildParam.ReloadType = appsv1alpha1.ShellType
+				buildParam.TLSReloadOptions = &appsv1alpha1.TLSReloadOptions{
+					UnixSignalTrigger: newReloadOptions(appsv1alpha1.UnixSignalType, nil).UnixSignalTrigger,
+				}
+			}
+			Expect(BuildConfigManagerContainerParams(mockK8sCli.Client(), context.TODO(), param, newVolumeMounts())).Should(Succeed())
+			Expect(param.TLSReloadSpec).ShouldNot(BeNil())
+			Expect(param.TLSReloadSpec.ReloadType).Should(Equal(appsv1alpha1.UnixSignalType))
+			Expect(param.TLSReloadSpec.MountPoint).Should(Equal(tlsVolume.MountPath))
+			Expect(param.TLSReloadSpec.TLSCertificates).Should(BeTrue())
+			Expect(FindVolumeMount(param.Volumes, tlsVolume.Name)).ShouldNot(BeNil())
+			for _, arg := range []string{`--volume-dir`, `/postgresql/conf`, `--volume-dir`, tlsVolume.MountPath} {
+				Expect(param.Args).Should(ContainElement(arg))
+			}
+		})
+
 		It("builds downwardAPI correctly", func() {
 			mockTplScriptCM()
 			param := newCMBuildParams(false)
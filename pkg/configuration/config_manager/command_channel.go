@@ -0,0 +1,321 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+)
+
+const (
+	postgresql = "postgresql"
+	redisType  = "redis"
+	mongodb    = "mongodb"
+)
+
+// CommandChannelDriver describes how the config manager connects to an engine and updates the parameters dynamically.
+type CommandChannelDriver struct {
+	// NewChannel connects to the engine with the data source name.
+	NewChannel func(ctx context.Context, dsn string) (DynamicParamUpdater, error)
+
+	// StatementTemplate is the default go template of the sql trigger, which renders the statement to update a parameter.
+	// the driver can not be used by the sql trigger without a statement template if both it and ReloadStatement are empty.
+	StatementTemplate string
+
+	// ReloadStatement is executed once after all the parameters are updated, optional.
+	// the driver only reloads the updated configuration files by default if StatementTemplate is empty.
+	ReloadStatement string
+}
+
+var commandChannelDrivers = map[string]CommandChannelDriver{}
+
+// RegisterCommandChannel registers the command channel driver of an engine, the name is case-insensitive.
+func RegisterCommandChannel(name string, driver CommandChannelDriver) {
+	commandChannelDrivers[strings.ToLower(name)] = driver
+}
+
+func GetCommandChannelDriver(name string) (CommandChannelDriver, bool) {
+	driver, ok := commandChannelDrivers[strings.ToLower(name)]
+	return driver, ok
+}
+
+func init() {
+	RegisterCommandChannel(mysql, CommandChannelDriver{
+		NewChannel:        newMysqlConnection,
+		StatementTemplate: `SET GLOBAL {{ mysqlParamName .Name }} = {{ mysqlParamValue .Value }}`,
+	})
+	RegisterCommandChannel(patroni, CommandChannelDriver{
+		NewChannel: func(_ context.Context, dsn string) (DynamicParamUpdater, error) {
+			return newPGPatroniConnection(dsn)
+		},
+	})
+	// ALTER SYSTEM is not used since it writes the parameters to postgresql.auto.conf, which overrides
+	// the rendered configuration files afterwards, so postgresql only reloads the updated configuration files.
+	RegisterCommandChannel(postgresql, CommandChannelDriver{
+		NewChannel:      newPostgresConnection,
+		ReloadStatement: `SELECT pg_reload_conf()`,
+	})
+	RegisterCommandChannel(redisType, CommandChannelDriver{
+		NewChannel:        newRedisConnection,
+		StatementTemplate: `CONFIG SET {{ .Name }} {{ quote .Value }}`,
+	})
+	RegisterCommandChannel(mongodb, CommandChannelDriver{
+		NewChannel:        newMongoConnection,
+		StatementTemplate: `{"setParameter": 1, {{ toJson .Name }}: {{ jsonValue .Value }}}`,
+	})
+}
+
+func getDataSourceName(dsn string) (string, error) {
+	if dsn == "" {
+		dsn = os.Getenv(mysqlDsnEnv)
+	}
+	if dsn == "" {
+		return "", cfgcore.MakeError("require DATA_SOURCE_NAME env.")
+	}
+	return dsn, nil
+}
+
+type pgCommandChannel struct {
+	conn *pgx.Conn
+}
+
+func newPostgresConnection(ctx context.Context, dsn string) (DynamicParamUpdater, error) {
+	logger.V(1).Info("connecting postgresql.")
+	dsn, err := getDataSourceName(dsn)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, cfgcore.WrapError(err, "failed to opening connection to postgresql.")
+	}
+	return &pgCommandChannel{conn: conn}, nil
+}
+
+func (p *pgCommandChannel) ExecCommand(ctx context.Context, command string, _ ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	tag, err := p.conn.Exec(ctx, command)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(tag.RowsAffected(), 10), nil
+}
+
+func (p *pgCommandChannel) Close() {
+	p.conn.Close(context.Background())
+	logger.V(1).Info("closed postgresql connection.")
+}
+
+type redisCommandChannel struct {
+	client *redis.Client
+}
+
+func newRedisConnection(ctx context.Context, dsn string) (DynamicParamUpdater, error) {
+	logger.V(1).Info("connecting redis.")
+	dsn, err := getDataSourceName(dsn)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := redis.ParseURL(dsn)
+	if err != nil {
+		return nil, cfgcore.WrapError(err, "invalid redis url.")
+	}
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, cfgcore.WrapError(err, "failed to opening connection to redis.")
+	}
+	return &redisCommandChannel{client: client}, nil
+}
+
+func (r *redisCommandChannel) ExecCommand(ctx context.Context, command string, _ ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	args, err := splitCommandArgs(command)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return "", cfgcore.MakeError("empty redis command.")
+	}
+	cmdArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		cmdArgs[i] = arg
+	}
+	result, err := r.client.Do(ctx, cmdArgs...).Result()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(result), nil
+}
+
+func (r *redisCommandChannel) Close() {
+	r.client.Close()
+	logger.V(1).Info("closed redis connection.")
+}
+
+type mongoCommandChannel struct {
+	client *mongo.Client
+}
+
+func newMongoConnection(ctx context.Context, dsn string) (DynamicParamUpdater, error) {
+	logger.V(1).Info("connecting mongodb.")
+	dsn, err := getDataSourceName(dsn)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dsn).SetDirect(true))
+	if err != nil {
+		return nil, cfgcore.WrapError(err, "failed to opening connection to mongodb.")
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, cfgcore.WrapError(err, "failed to ping mongodb.")
+	}
+	return &mongoCommandChannel{client: client}, nil
+}
+
+// ExecCommand runs the command against the admin database, the command is a document in the extended JSON format,
+// e.g. {"setParameter": 1, "logLevel": 1}.
+func (m *mongoCommandChannel) ExecCommand(ctx context.Context, command string, _ ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	var cmd bson.D
+	if err := bson.UnmarshalExtJSON([]byte(command), false, &cmd); err != nil {
+		return "", cfgcore.WrapError(err, "invalid mongodb command: %s", command)
+	}
+	result, err := m.client.Database("admin").RunCommand(ctx, cmd).DecodeBytes()
+	if err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+func (m *mongoCommandChannel) Close() {
+	_ = m.client.Disconnect(context.Background())
+	logger.V(1).Info("closed mongodb connection.")
+}
+
+// splitCommandArgs splits the command into arguments by the whitespaces,
+// the arguments may be quoted by the double quotes, back quotes or single quotes.
+func splitCommandArgs(command string) ([]string, error) {
+	var args []string
+	for s := strings.TrimSpace(command); s != ""; s = strings.TrimLeftFunc(s, unicode.IsSpace) {
+		var arg string
+		switch s[0] {
+		case '"', '`':
+			quoted, err := strconv.QuotedPrefix(s)
+			if err != nil {
+				return nil, cfgcore.MakeError("invalid quoted argument: %s", s)
+			}
+			if arg, err = strconv.Unquote(quoted); err != nil {
+				return nil, cfgcore.MakeError("invalid quoted argument: %s", quoted)
+			}
+			s = s[len(quoted):]
+		case '\'':
+			end := strings.IndexByte(s[1:], '\'')
+			if end < 0 {
+				return nil, cfgcore.MakeError("unterminated quoted argument: %s", s)
+			}
+			arg, s = s[1:end+1], s[end+2:]
+		default:
+			end := strings.IndexFunc(s, unicode.IsSpace)
+			if end < 0 {
+				end = len(s)
+			}
+			arg, s = s[:end], s[end:]
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+var numberRegex = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// sqlValue formats the parameter value as a sql literal, the numbers are kept as they are, and the others are quoted.
+func sqlValue(value string) string {
+	if numberRegex.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// mysqlParamName converts the parameter name in the option files to the system variable name,
+// the option files accept both dashes and underscores in the names, while the system variables accept underscores only.
+func mysqlParamName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+var mysqlSizeRegex = regexp.MustCompile(`^([0-9]+)([kKmMgGtTpPeE])$`)
+
+// mysqlParamValue formats the parameter value as a sql literal like sqlValue, except that the sizes with a suffix,
+// e.g. 1G, are converted to bytes, since the suffixes are accepted by the option files only.
+func mysqlParamValue(value string) string {
+	matches := mysqlSizeRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return sqlValue(value)
+	}
+	n, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return sqlValue(value)
+	}
+	shift := uint(10 * (strings.Index("KMGTPE", strings.ToUpper(matches[2])) + 1))
+	if n > math.MaxUint64>>shift {
+		return sqlValue(value)
+	}
+	return strconv.FormatUint(n<<shift, 10)
+}
+
+// jsonValue formats the parameter value as a json value, the numbers and booleans are kept as they are, and the others are quoted.
+func jsonValue(value string) string {
+	if numberRegex.MatchString(value) {
+		return value
+	}
+	if v := strings.ToLower(value); v == "true" || v == "false" {
+		return v
+	}
+	b, _ := json.Marshal(value)
+	return string(b)
+}
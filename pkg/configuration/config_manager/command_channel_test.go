@@ -0,0 +1,86 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configmanager
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Command Channel Test", func() {
+
+	Context("TestCommandChannelDriver", func() {
+		It("the drivers are registered", func() {
+			for _, name := range []string{"mysql", "patroni", "postgresql", "redis", "MongoDB"} {
+				_, ok := GetCommandChannelDriver(name)
+				Expect(ok).Should(BeTrue())
+			}
+			_, ok := GetCommandChannelDriver("oracle")
+			Expect(ok).Should(BeFalse())
+		})
+
+		It("the data source name is required", func() {
+			for _, name := range []string{postgresql, redisType, mongodb} {
+				_, err := NewCommandChannel(ctx, name, "")
+				Expect(err).ShouldNot(Succeed())
+				Expect(err.Error()).Should(ContainSubstring("DATA_SOURCE_NAME"))
+			}
+		})
+
+		It("postgresql only reloads the configuration files", func() {
+			d, _ := GetCommandChannelDriver(postgresql)
+			Expect(d.StatementTemplate).Should(BeEmpty())
+			Expect(d.ReloadStatement).Should(Equal("SELECT pg_reload_conf()"))
+		})
+
+		It("renders the default statements", func() {
+			render := func(driver, name, value string) string {
+				d, _ := GetCommandChannelDriver(driver)
+				statements, err := renderSQLTriggerStatements(d.StatementTemplate, map[string]string{name: value})
+				Expect(err).Should(Succeed())
+				Expect(statements).Should(HaveLen(1))
+				return statements[0]
+			}
+			Expect(render(mysql, "innodb_buffer_pool_size", "134217728")).Should(Equal("SET GLOBAL innodb_buffer_pool_size = 134217728"))
+			Expect(render(mysql, "innodb-buffer-pool-size", "128M")).Should(Equal("SET GLOBAL innodb_buffer_pool_size = 134217728"))
+			Expect(render(mysql, "max_binlog_size", "1g")).Should(Equal("SET GLOBAL max_binlog_size = 1073741824"))
+			Expect(render(mysql, "sql_mode", "ONLY_FULL_GROUP_BY")).Should(Equal("SET GLOBAL sql_mode = 'ONLY_FULL_GROUP_BY'"))
+			Expect(render(mysql, "max_binlog_size", "99999999999999999999E")).Should(Equal("SET GLOBAL max_binlog_size = '99999999999999999999E'"))
+			Expect(render(redisType, "save", "3600 1 300 100")).Should(Equal(`CONFIG SET save "3600 1 300 100"`))
+			Expect(render(mongodb, "logLevel", "1")).Should(Equal(`{"setParameter": 1, "logLevel": 1}`))
+			Expect(render(mongodb, "notablescan", "TRUE")).Should(Equal(`{"setParameter": 1, "notablescan": true}`))
+		})
+	})
+
+	Context("TestSplitCommandArgs", func() {
+		It("splits the quoted arguments", func() {
+			args, err := splitCommandArgs(` CONFIG SET save "3600 1 \"300\" 100" 'a b'` + " `c d` e")
+			Expect(err).Should(Succeed())
+			Expect(args).Should(Equal([]string{"CONFIG", "SET", "save", `3600 1 "300" 100`, "a b", "c d", "e"}))
+		})
+
+		It("fails with the unterminated quotes", func() {
+			_, err := splitCommandArgs(`CONFIG SET save "3600`)
+			Expect(err).ShouldNot(Succeed())
+			_, err = splitCommandArgs(`CONFIG SET save '3600`)
+			Expect(err).ShouldNot(Succeed())
+		})
+	})
+})
@@ -426,6 +426,179 @@ func CreateTPLScriptHandler(name, configPath string, dirs []string, backupPath s
 	return tplHandler, nil
 }
 
+type httpTriggerHandler struct {
+	configVolumeHandleMeta
+
+	trigger    *appsv1alpha1.HTTPTrigger
+	fileFilter regexFilter
+	backupPath string
+}
+
+func (h *httpTriggerHandler) OnlineUpdate(ctx context.Context, name string, updatedParams map[string]string) error {
+	logger.V(1).Info(fmt.Sprintf("online update[%v]", updatedParams))
+	if len(updatedParams) == 0 {
+		return nil
+	}
+	body, err := renderHTTPTriggerBody(h.trigger, updatedParams)
+	if err != nil {
+		return err
+	}
+	return sendHTTPTriggerRequest(ctx, h.trigger, body)
+}
+
+func (h *httpTriggerHandler) VolumeHandle(ctx context.Context, event fsnotify.Event) error {
+	if !isOwnerEvent(h.MountPoint(), event) {
+		logger.Info(fmt.Sprintf("ignore event: %s, current watch volume: %s", event.String(), h.mountPoint))
+		return nil
+	}
+	updatedParams, files, err := h.prepare(h.backupPath, h.fileFilter, event)
+	if err != nil {
+		return err
+	}
+	if err := h.OnlineUpdate(ctx, event.Name, updatedParams); err != nil {
+		return err
+	}
+	return backupLastConfigFiles(files, h.backupPath)
+}
+
+func CreateHTTPTriggerHandler(configMeta *ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
+	if configMeta.ReloadOptions == nil || configMeta.HTTPTrigger == nil {
+		return nil, cfgcore.MakeError("http trigger is nil")
+	}
+	if err := checkHTTPTrigger(configMeta.HTTPTrigger); err != nil {
+		return nil, err
+	}
+	filter, err := createFileRegex(fromConfigSpecInfo(configMeta))
+	if err != nil {
+		return nil, err
+	}
+	if err := backupConfigFiles([]string{configMeta.MountPoint}, filter, backupPath); err != nil {
+		return nil, err
+	}
+	return &httpTriggerHandler{
+		configVolumeHandleMeta: createConfigVolumeMeta(configMeta.ConfigSpec.Name, appsv1alpha1.HTTPType, []string{configMeta.MountPoint}, &configMeta.FormatterConfig),
+		trigger:                configMeta.HTTPTrigger,
+		fileFilter:             filter,
+		backupPath:             backupPath,
+	}, nil
+}
+
+type sqlTriggerHandler struct {
+	configVolumeHandleMeta
+
+	trigger           *appsv1alpha1.SQLTrigger
+	statementTemplate string
+	reloadStatement   string
+	fileFilter        regexFilter
+	backupPath        string
+}
+
+func (h *sqlTriggerHandler) OnlineUpdate(ctx context.Context, name string, updatedParams map[string]string) error {
+	logger.V(1).Info(fmt.Sprintf("online update[%v]", updatedParams))
+	if len(updatedParams) == 0 {
+		return nil
+	}
+	// the parameters are not updated by statements but only reloaded from the configuration files,
+	// which are delivered after the sync trigger is called, so the reload waits for the files.
+	if h.statementTemplate == "" {
+		delivered, err := h.isDelivered(updatedParams)
+		if err != nil {
+			return err
+		}
+		if !delivered {
+			return cfgcore.MakeError("the configuration files are not delivered yet, params: %v", updatedParams)
+		}
+	}
+	var statements []string
+	if h.statementTemplate != "" {
+		rendered, err := renderSQLTriggerStatements(h.statementTemplate, updatedParams)
+		if err != nil {
+			return err
+		}
+		statements = rendered
+	}
+	if h.reloadStatement != "" {
+		statements = append(statements, h.reloadStatement)
+	}
+
+	commandChannel, err := newCommandChannel(ctx, h.trigger.Driver, os.ExpandEnv(h.trigger.DSN))
+	if err != nil {
+		return err
+	}
+	defer commandChannel.Close()
+	for _, statement := range statements {
+		r, err := commandChannel.ExecCommand(ctx, statement)
+		logger.V(1).Info(fmt.Sprintf("statement: [%s], result: [%v], err: [%+v]", statement, r, err))
+		if err != nil {
+			return cfgcore.WrapError(err, "failed to execute statement: %s", statement)
+		}
+	}
+	return nil
+}
+
+// isDelivered returns true if the configuration files have the updated parameters.
+func (h *sqlTriggerHandler) isDelivered(updatedParams map[string]string) (bool, error) {
+	files, err := scanConfigFiles(h.mountPoint, h.fileFilter)
+	if err != nil {
+		return false, err
+	}
+	params, err := createUpdatedParamsPatch(files, nil, h.formatterConfig)
+	if err != nil {
+		return false, err
+	}
+	for name, value := range updatedParams {
+		if v, ok := params[name]; !ok || v != value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (h *sqlTriggerHandler) VolumeHandle(ctx context.Context, event fsnotify.Event) error {
+	if !isOwnerEvent(h.MountPoint(), event) {
+		logger.Info(fmt.Sprintf("ignore event: %s, current watch volume: %s", event.String(), h.mountPoint))
+		return nil
+	}
+	updatedParams, files, err := h.prepare(h.backupPath, h.fileFilter, event)
+	if err != nil {
+		return err
+	}
+	if err := h.OnlineUpdate(ctx, event.Name, updatedParams); err != nil {
+		return err
+	}
+	return backupLastConfigFiles(files, h.backupPath)
+}
+
+func CreateSQLTriggerHandler(configMeta *ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
+	if configMeta.ReloadOptions == nil || configMeta.SQLTrigger == nil {
+		return nil, cfgcore.MakeError("sql trigger is nil")
+	}
+	trigger := configMeta.SQLTrigger
+	if err := checkSQLTrigger(trigger); err != nil {
+		return nil, err
+	}
+	driver, _ := GetCommandChannelDriver(trigger.Driver)
+	statementTemplate := trigger.StatementTemplate
+	if statementTemplate == "" {
+		statementTemplate = driver.StatementTemplate
+	}
+	filter, err := createFileRegex(fromConfigSpecInfo(configMeta))
+	if err != nil {
+		return nil, err
+	}
+	if err := backupConfigFiles([]string{configMeta.MountPoint}, filter, backupPath); err != nil {
+		return nil, err
+	}
+	return &sqlTriggerHandler{
+		configVolumeHandleMeta: createConfigVolumeMeta(configMeta.ConfigSpec.Name, appsv1alpha1.SQLType, []string{configMeta.MountPoint}, &configMeta.FormatterConfig),
+		trigger:                trigger,
+		statementTemplate:      statementTemplate,
+		reloadStatement:        driver.ReloadStatement,
+		fileFilter:             filter,
+		backupPath:             backupPath,
+	}, nil
+}
+
 func CreateCombinedHandler(config string, backupPath string) (ConfigHandler, error) {
 	shellHandler := func(configMeta ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
 		if configMeta.ShellTrigger == nil {
@@ -440,6 +613,18 @@ func CreateCombinedHandler(config string, backupPath string) (ConfigHandler, err
 		}
 		return CreateSignalHandler(signalTrigger.Signal, signalTrigger.ProcessName, mountPoint)
 	}
+	// the TLS certificates are not parsed as the configuration, the command is executed once any of them changes.
+	tlsShellHandler := func(configMeta ConfigSpecInfo) (ConfigHandler, error) {
+		if configMeta.ShellTrigger == nil {
+			return nil, cfgcore.MakeError("shell trigger is nil")
+		}
+		h, err := CreateExecHandler(configMeta.ShellTrigger.Command, configMeta.MountPoint, &configMeta, "")
+		if err != nil {
+			return nil, err
+		}
+		h.(*shellCommandHandler).downwardAPITrigger = true
+		return h, nil
+	}
 	tplHandler := func(tplTrigger *appsv1alpha1.TPLScriptTrigger, configMeta ConfigSpecInfo, backupPath string) (ConfigHandler, error) {
 		if tplTrigger == nil {
 			return nil, cfgcore.MakeError("tpl trigger is nil")
@@ -471,11 +656,19 @@ func CreateCombinedHandler(config string, backupPath string) (ConfigHandler, err
 		default:
 			return nil, fmt.Errorf("not support reload type: %s", configMeta.ReloadType)
 		case appsv1alpha1.ShellType:
+			if configMeta.TLSCertificates {
+				h, err = tlsShellHandler(configMeta)
+				break
+			}
 			h, err = shellHandler(configMeta, tmpPath)
 		case appsv1alpha1.UnixSignalType:
 			h, err = signalHandler(configMeta.ReloadOptions.UnixSignalTrigger, configMeta.MountPoint)
 		case appsv1alpha1.TPLScriptType:
 			h, err = tplHandler(configMeta.ReloadOptions.TPLScriptTrigger, configMeta, tmpPath)
+		case appsv1alpha1.HTTPType:
+			h, err = CreateHTTPTriggerHandler(&configMeta, tmpPath)
+		case appsv1alpha1.SQLType:
+			h, err = CreateSQLTriggerHandler(&configMeta, tmpPath)
 		}
 		if err != nil {
 			return nil, err
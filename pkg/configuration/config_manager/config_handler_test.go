@@ -21,7 +21,10 @@ package configmanager
 
 import (
 	"context"
+	"io"
 	"io/fs"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -108,6 +111,24 @@ var _ = Describe("Config Handler Test", func() {
 		}
 	}
 
+	newTLSShellConfig := func(mountPoint, reloadedFile string) ConfigSpecInfo {
+		return ConfigSpecInfo{
+			ReloadOptions: &appsv1alpha1.ReloadOptions{
+				ShellTrigger: &appsv1alpha1.ShellTrigger{
+					Command: []string{"touch", reloadedFile},
+				}},
+			ReloadType: appsv1alpha1.ShellType,
+			MountPoint: mountPoint,
+			ConfigSpec: appsv1alpha1.ComponentConfigSpec{
+				ComponentTemplateSpec: appsv1alpha1.ComponentTemplateSpec{
+					Name:       TLSReloadSpecName,
+					VolumeName: "tls",
+				},
+			},
+			TLSCertificates: true,
+		}
+	}
+
 	newDownwardAPIOptions := func() []appsv1alpha1.DownwardAPIOption {
 		return []appsv1alpha1.DownwardAPIOption{
 			{
@@ -151,6 +172,35 @@ var _ = Describe("Config Handler Test", func() {
 		}
 	}
 
+	newHTTPTriggerConfig := func(mountPoint, url string) ConfigSpecInfo {
+		return ConfigSpecInfo{
+			ReloadOptions: &appsv1alpha1.ReloadOptions{
+				HTTPTrigger: &appsv1alpha1.HTTPTrigger{
+					URL:          url,
+					Method:       http.MethodPut,
+					Headers:      map[string]string{"X-Reload-Token": "token"},
+					BodyTemplate: `{{ range $k, $v := .Parameters }}{{ $k }}={{ $v }};{{ end }}`,
+				}},
+			ReloadType:      appsv1alpha1.HTTPType,
+			MountPoint:      mountPoint,
+			ConfigSpec:      newConfigSpec(),
+			FormatterConfig: newFormatter(),
+		}
+	}
+
+	newSQLTriggerConfig := func(mountPoint, driver string) ConfigSpecInfo {
+		return ConfigSpecInfo{
+			ReloadOptions: &appsv1alpha1.ReloadOptions{
+				SQLTrigger: &appsv1alpha1.SQLTrigger{
+					Driver: driver,
+				}},
+			ReloadType:      appsv1alpha1.SQLType,
+			MountPoint:      mountPoint,
+			ConfigSpec:      newConfigSpec(),
+			FormatterConfig: newFormatter(),
+		}
+	}
+
 	prepareTestConfig := func(configPath string, config string) {
 		fileInfo, err := os.Stat(configPath)
 		if err != nil {
@@ -268,6 +318,22 @@ var _ = Describe("Config Handler Test", func() {
 			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, nil)).Should(Succeed())
 		})
 
+		It("TLSShellHandler", func() {
+			tlsPath := filepath.Join(tmpWorkDir, "tls")
+			reloadedFile := filepath.Join(tmpWorkDir, "reloaded")
+			Expect(os.MkdirAll(tlsPath, fs.ModePerm)).Should(Succeed())
+			Expect(os.WriteFile(filepath.Join(tlsPath, "tls.crt"), []byte("cert"), fs.ModePerm)).Should(Succeed())
+			config := newTLSShellConfig(tlsPath, reloadedFile)
+			handler, err := CreateCombinedHandler(toJSONString(config), filepath.Join(tmpWorkDir, "backup"))
+			Expect(err).Should(Succeed())
+			Expect(handler.MountPoint()).Should(ContainElement(tlsPath))
+
+			By("the certificates are renewed, expect the reload command is executed")
+			Expect(os.WriteFile(filepath.Join(tlsPath, "tls.crt"), []byte("renewed cert"), fs.ModePerm)).Should(Succeed())
+			Expect(handler.VolumeHandle(context.TODO(), fsnotify.Event{Name: tlsPath})).Should(Succeed())
+			Expect(reloadedFile).Should(BeAnExistingFile())
+		})
+
 		It("TplScriptsHandler", func() {
 			By("mock command channel")
 			newCommandChannel = func(ctx context.Context, dataType, dsn string) (DynamicParamUpdater, error) {
@@ -326,6 +392,117 @@ var _ = Describe("Config Handler Test", func() {
 
 		})
 
+		It("HTTPTriggerHandler Volume Event", func() {
+			var (
+				method string
+				header string
+				body   string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				method, header, body = r.Method, r.Header.Get("X-Reload-Token"), string(b)
+			}))
+			defer server.Close()
+
+			By("prepare config data")
+			configPath := filepath.Join(tmpWorkDir, "config")
+			prepareTestConfig(configPath, oldVersion)
+
+			config := newHTTPTriggerConfig(configPath, server.URL)
+			handler, err := CreateCombinedHandler(toJSONString(config), filepath.Join(tmpWorkDir, "backup"))
+			Expect(err).Should(Succeed())
+
+			By("change config, expect the updated parameters are sent")
+			prepareTestConfig(configPath, newVersion)
+			Expect(handler.VolumeHandle(context.TODO(), fsnotify.Event{Name: configPath})).Should(Succeed())
+			Expect(method).Should(Equal(http.MethodPut))
+			Expect(header).Should(Equal("token"))
+			Expect(body).Should(Equal("a=2;c=100;"))
+
+			By("the admin endpoint fails, expect an error")
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{"a": "3"})).ShouldNot(Succeed())
+		})
+
+		It("SQLTriggerHandler", func() {
+			By("mock command channel")
+			cc := &recordCommandChannel{}
+			newCommandChannel = func(ctx context.Context, dataType, dsn string) (DynamicParamUpdater, error) {
+				return cc, nil
+			}
+			configPath := filepath.Join(tmpWorkDir, "config")
+			prepareTestConfig(configPath, oldVersion)
+
+			config := newSQLTriggerConfig(configPath, "mysql")
+			handler, err := CreateCombinedHandler(toJSONString(config), filepath.Join(tmpWorkDir, "backup"))
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{
+				"max_connections": "1000",
+				"sql_mode":        "STRICT_TRANS_TABLES",
+			})).Should(Succeed())
+			Expect(cc.commands).Should(Equal([]string{
+				"SET GLOBAL max_connections = 1000",
+				"SET GLOBAL sql_mode = 'STRICT_TRANS_TABLES'",
+			}))
+
+			By("the names and the sizes are converted to the system variables of mysql")
+			cc.commands = nil
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{
+				"innodb-buffer-pool-size": "1G",
+				"max_allowed_packet":      "64m",
+			})).Should(Succeed())
+			Expect(cc.commands).Should(Equal([]string{
+				"SET GLOBAL innodb_buffer_pool_size = 1073741824",
+				"SET GLOBAL max_allowed_packet = 67108864",
+			}))
+
+			By("the configuration files of postgresql are only reloaded after they are delivered")
+			cc.commands = nil
+			config = newSQLTriggerConfig(configPath, "postgresql")
+			handler, err = CreateCombinedHandler(toJSONString(config), filepath.Join(tmpWorkDir, "backup"))
+			Expect(err).Should(Succeed())
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{
+				"a": "2",
+				"c": "100",
+			})).ShouldNot(Succeed())
+			Expect(cc.commands).Should(BeEmpty())
+
+			prepareTestConfig(configPath, newVersion)
+			Expect(handler.OnlineUpdate(context.TODO(), config.ConfigSpec.Name, map[string]string{
+				"a": "2",
+				"c": "100",
+			})).Should(Succeed())
+			Expect(cc.commands).Should(Equal([]string{
+				"SELECT pg_reload_conf()",
+			}))
+		})
+
+		It("SQLTriggerHandler Volume Event", func() {
+			By("mock command channel")
+			cc := &recordCommandChannel{}
+			newCommandChannel = func(ctx context.Context, dataType, dsn string) (DynamicParamUpdater, error) {
+				return cc, nil
+			}
+
+			By("prepare config data")
+			configPath := filepath.Join(tmpWorkDir, "config")
+			prepareTestConfig(configPath, oldVersion)
+
+			config := newSQLTriggerConfig(configPath, "redis")
+			handler, err := CreateCombinedHandler(toJSONString(config), filepath.Join(tmpWorkDir, "backup"))
+			Expect(err).Should(Succeed())
+
+			By("change config")
+			prepareTestConfig(configPath, newVersion)
+			Expect(handler.VolumeHandle(context.TODO(), fsnotify.Event{Name: configPath})).Should(Succeed())
+			Expect(cc.commands).Should(Equal([]string{
+				`CONFIG SET a "2"`,
+				`CONFIG SET c "100"`,
+			}))
+		})
+
 		It("DownwardAPIsHandler", func() {
 			config := newDownwardAPIConfig()
 			handler, err := CreateCombinedHandler(toJSONString(config), filepath.Join(tmpWorkDir, "backup"))
@@ -373,3 +550,15 @@ func (m *mockCommandChannel) Close() {
 }
 
 var mockCChannel = &mockCommandChannel{}
+
+type recordCommandChannel struct {
+	commands []string
+}
+
+func (m *recordCommandChannel) ExecCommand(ctx context.Context, command string, args ...string) (string, error) {
+	m.commands = append(m.commands, command)
+	return "", nil
+}
+
+func (m *recordCommandChannel) Close() {
+}
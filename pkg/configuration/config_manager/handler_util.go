@@ -57,12 +57,17 @@ type CfgManagerBuildParams struct {
 	CMConfigVolumes           []corev1.Volume
 	ConfigLazyRenderedVolumes map[string]corev1.VolumeMount
 
+	// the TLS certificates volume, which is watched to reload the certificates
+	TLSVolume     *corev1.VolumeMount
+	TLSReloadSpec *ConfigSpecInfo
+
 	// support host network
 	ContainerPort int32 `json:"containerPort"`
 }
 
 func IsSupportReload(reload *appsv1alpha1.ReloadOptions) bool {
-	return reload != nil && (reload.ShellTrigger != nil || reload.UnixSignalTrigger != nil || reload.TPLScriptTrigger != nil || reload.AutoTrigger != nil)
+	return reload != nil && (reload.ShellTrigger != nil || reload.UnixSignalTrigger != nil || reload.TPLScriptTrigger != nil || reload.AutoTrigger != nil ||
+		reload.HTTPTrigger != nil || reload.SQLTrigger != nil)
 }
 
 func IsAutoReload(reload *appsv1alpha1.ReloadOptions) bool {
@@ -79,6 +84,10 @@ func FromReloadTypeConfig(reloadOptions *appsv1alpha1.ReloadOptions) appsv1alpha
 		return appsv1alpha1.TPLScriptType
 	case reloadOptions.AutoTrigger != nil:
 		return appsv1alpha1.AutoType
+	case reloadOptions.HTTPTrigger != nil:
+		return appsv1alpha1.HTTPType
+	case reloadOptions.SQLTrigger != nil:
+		return appsv1alpha1.SQLType
 	}
 	return ""
 }
@@ -93,10 +102,26 @@ func ValidateReloadOptions(reloadOptions *appsv1alpha1.ReloadOptions, cli client
 		return checkTPLScriptTrigger(reloadOptions.TPLScriptTrigger, cli, ctx)
 	case reloadOptions.AutoTrigger != nil:
 		return nil
+	case reloadOptions.HTTPTrigger != nil:
+		return checkHTTPTrigger(reloadOptions.HTTPTrigger)
+	case reloadOptions.SQLTrigger != nil:
+		return checkSQLTrigger(reloadOptions.SQLTrigger)
 	}
 	return core.MakeError("require special reload type!")
 }
 
+func ValidateTLSReloadOptions(reloadOptions *appsv1alpha1.TLSReloadOptions) error {
+	switch {
+	case reloadOptions.UnixSignalTrigger != nil && reloadOptions.ShellTrigger != nil:
+		return core.MakeError("only one of the unixSignalTrigger and shellTrigger can be specified for TLS reload")
+	case reloadOptions.UnixSignalTrigger != nil:
+		return checkSignalTrigger(reloadOptions.UnixSignalTrigger)
+	case reloadOptions.ShellTrigger != nil:
+		return checkShellTrigger(reloadOptions.ShellTrigger)
+	}
+	return core.MakeError("require special TLS reload type!")
+}
+
 func checkTPLScriptTrigger(options *appsv1alpha1.TPLScriptTrigger, cli client.Client, ctx context.Context) error {
 	cm := corev1.ConfigMap{}
 	return cli.Get(ctx, client.ObjectKey{
@@ -112,6 +137,35 @@ func checkShellTrigger(options *appsv1alpha1.ShellTrigger) error {
 	return nil
 }
 
+func checkHTTPTrigger(options *appsv1alpha1.HTTPTrigger) error {
+	if options.URL == "" {
+		return core.MakeError("required url for http trigger")
+	}
+	if options.BodyTemplate != "" {
+		if err := checkTPLScript("http-trigger", options.BodyTemplate); err != nil {
+			return core.WrapError(err, "invalid body template of http trigger")
+		}
+	}
+	return nil
+}
+
+func checkSQLTrigger(options *appsv1alpha1.SQLTrigger) error {
+	driver, ok := GetCommandChannelDriver(options.Driver)
+	if !ok {
+		return core.MakeError("not supported driver for sql trigger: %s", options.Driver)
+	}
+	if options.StatementTemplate == "" {
+		if driver.StatementTemplate == "" && driver.ReloadStatement == "" {
+			return core.MakeError("required statement template for sql trigger with driver: %s", options.Driver)
+		}
+		return nil
+	}
+	if err := checkTPLScript("sql-trigger", options.StatementTemplate); err != nil {
+		return core.WrapError(err, "invalid statement template of sql trigger")
+	}
+	return nil
+}
+
 func checkSignalTrigger(options *appsv1alpha1.UnixSignalTrigger) error {
 	signal := options.Signal
 	if !IsValidUnixSignal(signal) {
@@ -173,6 +227,7 @@ func GetSupportReloadConfigSpecs(configSpecs []appsv1alpha1.ComponentConfigSpec,
 				DownwardAPIOptions: cc.Spec.DownwardAPIOptions,
 				FormatterConfig:    *cc.Spec.FormatterConfig,
 			},
+			TLSReloadOptions: cc.Spec.TLSReloadOptions,
 		})
 	}
 	return reloadConfigSpecMeta, nil
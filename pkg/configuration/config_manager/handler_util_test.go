@@ -371,6 +371,20 @@ var _ = Describe("Handler Util Test", func() {
 				}})))
 		})
 
+		It("TestHTTPTrigger", func() {
+			Expect(appsv1alpha1.HTTPType).Should(BeEquivalentTo(FromReloadTypeConfig(&appsv1alpha1.ReloadOptions{
+				HTTPTrigger: &appsv1alpha1.HTTPTrigger{
+					URL: "http://127.0.0.1:8080/config",
+				}})))
+		})
+
+		It("TestSQLTrigger", func() {
+			Expect(appsv1alpha1.SQLType).Should(BeEquivalentTo(FromReloadTypeConfig(&appsv1alpha1.ReloadOptions{
+				SQLTrigger: &appsv1alpha1.SQLTrigger{
+					Driver: "mysql",
+				}})))
+		})
+
 		It("TestInvalidTrigger", func() {
 			Expect("").Should(BeEquivalentTo(FromReloadTypeConfig(&appsv1alpha1.ReloadOptions{})))
 		})
@@ -436,10 +450,90 @@ var _ = Describe("Handler Util Test", func() {
 			).ShouldNot(Succeed())
 		})
 
+		It("TestHTTPTrigger", func() {
+			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+				HTTPTrigger: &appsv1alpha1.HTTPTrigger{
+					URL:          "http://127.0.0.1:8080/config",
+					BodyTemplate: `{{ toJson .Parameters }}`,
+				}}, nil, nil),
+			).Should(Succeed())
+
+			By("Test invalid")
+			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+				HTTPTrigger: &appsv1alpha1.HTTPTrigger{}}, nil, nil),
+			).ShouldNot(Succeed())
+			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+				HTTPTrigger: &appsv1alpha1.HTTPTrigger{
+					URL:          "http://127.0.0.1:8080/config",
+					BodyTemplate: `{{ toJson .Parameters `,
+				}}, nil, nil),
+			).ShouldNot(Succeed())
+		})
+
+		It("TestSQLTrigger", func() {
+			for _, driver := range []string{"mysql", "postgresql", "redis", "mongodb"} {
+				Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+					SQLTrigger: &appsv1alpha1.SQLTrigger{
+						Driver: driver,
+					}}, nil, nil),
+				).Should(Succeed())
+			}
+			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+				SQLTrigger: &appsv1alpha1.SQLTrigger{
+					Driver:            "mysql",
+					StatementTemplate: `SET PERSIST {{ .Name }} = {{ sqlValue .Value }}`,
+				}}, nil, nil),
+			).Should(Succeed())
+
+			By("Test invalid")
+			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+				SQLTrigger: &appsv1alpha1.SQLTrigger{
+					Driver: "oracle",
+				}}, nil, nil),
+			).ShouldNot(Succeed())
+			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+				SQLTrigger: &appsv1alpha1.SQLTrigger{
+					Driver: "patroni",
+				}}, nil, nil),
+			).ShouldNot(Succeed())
+			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{
+				SQLTrigger: &appsv1alpha1.SQLTrigger{
+					Driver:            "mysql",
+					StatementTemplate: `SET GLOBAL {{ .Name `,
+				}}, nil, nil),
+			).ShouldNot(Succeed())
+		})
+
 		It("TestInvalidTrigger", func() {
 			Expect(ValidateReloadOptions(&appsv1alpha1.ReloadOptions{}, nil, nil)).ShouldNot(Succeed())
 		})
 	})
+
+	Context("TestValidateTLSReloadOptions", func() {
+		signalTrigger := &appsv1alpha1.UnixSignalTrigger{
+			ProcessName: "test",
+			Signal:      appsv1alpha1.SIGHUP,
+		}
+		shellTrigger := &appsv1alpha1.ShellTrigger{
+			Command: []string{"/bin/true"},
+		}
+
+		It("TestSignalTrigger", func() {
+			Expect(ValidateTLSReloadOptions(&appsv1alpha1.TLSReloadOptions{UnixSignalTrigger: signalTrigger})).Should(Succeed())
+		})
+
+		It("TestShellTrigger", func() {
+			Expect(ValidateTLSReloadOptions(&appsv1alpha1.TLSReloadOptions{ShellTrigger: shellTrigger})).Should(Succeed())
+		})
+
+		It("TestInvalidTrigger", func() {
+			Expect(ValidateTLSReloadOptions(&appsv1alpha1.TLSReloadOptions{})).ShouldNot(Succeed())
+			Expect(ValidateTLSReloadOptions(&appsv1alpha1.TLSReloadOptions{
+				UnixSignalTrigger: signalTrigger,
+				ShellTrigger:      shellTrigger,
+			})).ShouldNot(Succeed())
+		})
+	})
 })
 
 func TestFilterSubPathVolumeMount(t *testing.T) {
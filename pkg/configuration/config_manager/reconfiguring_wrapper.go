@@ -65,15 +65,11 @@ func NewCommandChannel(ctx context.Context, dataType, dsn string) (DynamicParamU
 	}
 
 	logger.V(1).Info(fmt.Sprintf("new command channel. [%s]", dataType))
-	switch strings.ToLower(dataType) {
-	case mysql:
-		return newMysqlConnection(ctx, dsn)
-	case patroni:
-		return newPGPatroniConnection(dsn)
-	default:
-		// TODO mock db begin support dapper
-	}
-	return nil, cfgcore.MakeError("not supported type[%s]", dataType)
+	driver, ok := GetCommandChannelDriver(dataType)
+	if !ok {
+		return nil, cfgcore.MakeError("not supported type[%s]", dataType)
+	}
+	return driver.NewChannel(ctx, dsn)
 }
 
 func newMysqlDB(ctx context.Context, dsn string) (*sql.DB, error) {
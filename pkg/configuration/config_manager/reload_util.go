@@ -21,13 +21,17 @@ package configmanager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 	"text/template/parse"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -126,6 +130,95 @@ func constructReloadBuiltinFuncs(ctx context.Context, cc DynamicParamUpdater, fo
 	}
 }
 
+const (
+	builtInSQLValueFunctionName  = "sqlValue"
+	builtInJSONValueFunctionName = "jsonValue"
+
+	builtInMysqlParamNameFunctionName  = "mysqlParamName"
+	builtInMysqlParamValueFunctionName = "mysqlParamValue"
+
+	triggerParametersObjectName = "Parameters"
+	triggerParamNameObjectName  = "Name"
+	triggerParamValueObjectName = "Value"
+)
+
+func renderTriggerTemplate(name string, tplContent string, values gotemplate.TplValues) (string, error) {
+	engine := gotemplate.NewTplEngine(&values, &gotemplate.BuiltInObjectsFunc{
+		builtInSQLValueFunctionName:  sqlValue,
+		builtInJSONValueFunctionName: jsonValue,
+
+		builtInMysqlParamNameFunctionName:  mysqlParamName,
+		builtInMysqlParamValueFunctionName: mysqlParamValue,
+	}, name, nil, nil)
+	return engine.Render(tplContent)
+}
+
+// renderHTTPTriggerBody renders the request body of the http trigger, the updated parameters are sent as a JSON object by default.
+func renderHTTPTriggerBody(trigger *appsv1alpha1.HTTPTrigger, updatedParams map[string]string) (string, error) {
+	if trigger.BodyTemplate == "" {
+		b, err := json.Marshal(updatedParams)
+		return string(b), err
+	}
+	return renderTriggerTemplate("http-trigger", trigger.BodyTemplate, gotemplate.TplValues{
+		triggerParametersObjectName: updatedParams,
+	})
+}
+
+// renderSQLTriggerStatements renders a statement for each updated parameter, the statements are ordered by the parameter names.
+func renderSQLTriggerStatements(tplContent string, updatedParams map[string]string) ([]string, error) {
+	names := make([]string, 0, len(updatedParams))
+	for name := range updatedParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	statements := make([]string, 0, len(names))
+	for _, name := range names {
+		statement, err := renderTriggerTemplate("sql-trigger", tplContent, gotemplate.TplValues{
+			triggerParamNameObjectName:  name,
+			triggerParamValueObjectName: updatedParams[name],
+		})
+		if err != nil {
+			return nil, core.WrapError(err, "failed to render statement for parameter: %s", name)
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+func sendHTTPTriggerRequest(ctx context.Context, trigger *appsv1alpha1.HTTPTrigger, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	method := trigger.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, method, os.ExpandEnv(trigger.URL), strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range trigger.Headers {
+		req.Header.Set(key, os.ExpandEnv(value))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	logger.V(1).Info(fmt.Sprintf("http trigger: [%s %s], status: [%d], response: [%s]", method, req.URL.String(), resp.StatusCode, response))
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return core.MakeError("failed to reload by http trigger, status: %d, response: %s", resp.StatusCode, response)
+	}
+	return nil
+}
+
 func createUpdatedParamsPatch(newVersion []string, oldVersion []string, formatCfg *appsv1alpha1.FormatterConfig) (map[string]string, error) {
 	patchOption := core.CfgOption{
 		Type:    core.CfgTplType,
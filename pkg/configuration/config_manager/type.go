@@ -45,13 +45,18 @@ type ConfigSpecInfo struct {
 	// config volume mount path
 	MountPoint string `json:"mountPoint"`
 	TPLConfig  string `json:"tplConfig"`
+
+	// the volume contains the TLS certificates rather than the configuration files,
+	// the files are not parsed, and the reload is performed once any of them changes.
+	TLSCertificates bool `json:"tlsCertificates,omitempty"`
 }
 
 type ConfigSpecMeta struct {
 	ConfigSpecInfo `json:",inline"`
 
-	ScriptConfig   []appsv1alpha1.ScriptConfig
-	ToolsImageSpec *appsv1alpha1.ToolsImageSpec
+	ScriptConfig     []appsv1alpha1.ScriptConfig
+	ToolsImageSpec   *appsv1alpha1.ToolsImageSpec
+	TLSReloadOptions *appsv1alpha1.TLSReloadOptions
 }
 
 type TPLScriptConfig struct {
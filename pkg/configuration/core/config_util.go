@@ -147,3 +147,17 @@ func IsWatchModuleForTplTrigger(trigger *appsv1alpha1.TPLScriptTrigger) bool {
 	}
 	return !*trigger.Sync
 }
+
+func IsWatchModuleForHTTPTrigger(trigger *appsv1alpha1.HTTPTrigger) bool {
+	if trigger == nil || trigger.Sync == nil {
+		return true
+	}
+	return !*trigger.Sync
+}
+
+func IsWatchModuleForSQLTrigger(trigger *appsv1alpha1.SQLTrigger) bool {
+	if trigger == nil || trigger.Sync == nil {
+		return true
+	}
+	return !*trigger.Sync
+}
@@ -184,6 +184,11 @@ func GenerateEnvFromName(originName string) string {
 	return strings.Join([]string{originName, "envfrom"}, "-")
 }
 
+// GenerateConfigRevisionName generates the name of the ConfigMap which records the revision of the configuration.
+func GenerateConfigRevisionName(cfgName, revision string) string {
+	return constant.GenerateConfigRevisionName(cfgName, revision)
+}
+
 func GenerateRevisionPhaseKey(revision string) string {
 	return strings.Join([]string{constant.LastConfigurationRevisionPhase, revision}, "-")
 }
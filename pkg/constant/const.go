@@ -46,6 +46,12 @@ const (
 
 	// customized encryption key for encrypting the password of connection credential.
 	CfgKeyDPEncryptionKey = "DP_ENCRYPTION_KEY"
+
+	// CfgKeyLorryTLSEnabled enables TLS and authentication of lorry for the components with TLS enabled.
+	CfgKeyLorryTLSEnabled = "LORRY_TLS_ENABLED"
+
+	// CfgKeyLorryFencingAction specifies the action of lorry to fence the previous leader before promotion.
+	CfgKeyLorryFencingAction = "LORRY_FENCING_ACTION"
 )
 
 const (
@@ -93,6 +99,8 @@ const (
 	AddonProviderLabelKey                    = "kubeblocks.io/provider"          // AddonProviderLabelKey marks the addon provider
 	RoleLabelKey                             = "kubeblocks.io/role"              // RoleLabelKey consensusSet and replicationSet role label key
 	ReadyWithoutPrimaryKey                   = "kubeblocks.io/ready-without-primary"
+	SwitchoverJobLabelKey                    = "kubeblocks.io/switchover-job" // SwitchoverJobLabelKey marks the jobs created by the switchover OpsRequest
+	SwitchoverJobLabelValue                  = "kb-switchover-job"
 	VolumeTypeLabelKey                       = "kubeblocks.io/volume-type"
 	ClusterAccountLabelKey                   = "account.kubeblocks.io/name"
 	KBAppClusterUIDLabelKey                  = "apps.kubeblocks.io/cluster-uid"
@@ -121,6 +129,8 @@ const (
 	AddonNameLabelKey                        = "extensions.kubeblocks.io/addon-name"
 	OpsRequestTypeLabelKey                   = "ops.kubeblocks.io/ops-type"
 	OpsRequestNameLabelKey                   = "ops.kubeblocks.io/ops-name"
+	OpsRequestScheduleLabelKey               = "ops.kubeblocks.io/ops-schedule"
+	OpsRequestVolumeAutoExpansionLabelKey    = "ops.kubeblocks.io/volume-auto-expansion"
 	ServiceDescriptorNameLabelKey            = "servicedescriptor.kubeblocks.io/name"
 	RestoreForHScaleLabelKey                 = "apps.kubeblocks.io/restore-for-hscale"
 	ResourceConstraintProviderLabelKey       = "resourceconstraint.kubeblocks.io/provider"
@@ -167,6 +177,24 @@ const (
 	// Multiple components are separated by ','. for example: "kubeblocks.io/enabled-node-port-svc: comp1,comp2"
 	PodOrdinalSvcAnnotationKey = "kubeblocks.io/enabled-pod-ordinal-svc"
 
+	// MaxUpdatedReplicasAnnotationKey specifies the max number of pods of the RSM that can be updated to the update revision,
+	// the rest pods are kept in the current revision until the annotation is removed. it is used by the canary OpsRequest.
+	MaxUpdatedReplicasAnnotationKey = "workloads.kubeblocks.io/max-updated-replicas"
+
+	// OpsRequestRollbackForAnnotationKey is set to the opsRequest created to roll back another opsRequest,
+	// the value is the name of the rolled back opsRequest.
+	OpsRequestRollbackForAnnotationKey = "ops.kubeblocks.io/rollback-for"
+
+	// PasswordRotatedAtAnnotationKey records the time when the password of the account secret is rotated last time.
+	PasswordRotatedAtAnnotationKey = "apps.kubeblocks.io/password-rotated-at"
+	// PasswordRotationRequestAnnotationKey is set to the account secret by the RotatePassword OpsRequest to rotate the password on demand,
+	// the value is the name of the OpsRequest.
+	PasswordRotationRequestAnnotationKey = "apps.kubeblocks.io/password-rotation-request"
+	// PasswordRotatedForAnnotationKey records the rotation request that the last rotation is done for.
+	PasswordRotatedForAnnotationKey = "apps.kubeblocks.io/password-rotated-for"
+	// OldPasswordExpiresAtAnnotationKey records the time when the old password of the account will be discarded.
+	OldPasswordExpiresAtAnnotationKey = "apps.kubeblocks.io/old-password-expires-at"
+
 	// kubeblocks.io well-known finalizers
 	DBClusterFinalizerName             = "cluster.kubeblocks.io/finalizer"
 	DBComponentFinalizerName           = "component.kubeblocks.io/finalizer"
@@ -186,6 +214,16 @@ const (
 	ConfigurationRevision          = "config.kubeblocks.io/configuration-revision"
 	LastConfigurationRevisionPhase = "config.kubeblocks.io/revision-reconcile-phase"
 
+	// LastAppliedOpsRevisionAnnotationKey records the configuration revision generated by the OpsRequest
+	// in the annotation LastAppliedOpsCRAnnotationKey of the Configuration object.
+	LastAppliedOpsRevisionAnnotationKey = "config.kubeblocks.io/last-applied-ops-revision"
+	// ConfigRevisionLabelKey labels the revision ConfigMap with the configuration revision it records.
+	ConfigRevisionLabelKey = "config.kubeblocks.io/revision"
+	// ConfigRevisionUpdatedParamsAnnotationKey records the parameters updated by the revision compared to the previous one.
+	ConfigRevisionUpdatedParamsAnnotationKey = "config.kubeblocks.io/revision-updated-parameters"
+	// ConfigRevisionPhaseAnnotationKey records the reconfiguring phase of the revision.
+	ConfigRevisionPhaseAnnotationKey = "config.kubeblocks.io/revision-phase"
+
 	// Deprecated: only compatible with version 0.6, will be removed in 0.8
 	// CMInsEnableRerenderTemplateKey is used to enable rerender template
 	CMInsEnableRerenderTemplateKey = "config.kubeblocks.io/enable-rerender"
@@ -231,6 +269,7 @@ const (
 	ServiceKind               = "Service"
 	ConfigMapKind             = "ConfigMap"
 	DaemonSetKind             = "DaemonSet"
+	OpsRequestKind            = "OpsRequest"
 )
 
 const (
@@ -250,6 +289,7 @@ const (
 	LorryGRPCPortName                  = "lorry-grpc-port"
 	LorryRoleProbePath                 = "/v1.0/checkrole"
 	LorryVolumeProtectPath             = "/v1.0/volumeprotection"
+	LorryTokenAudience                 = "kubeblocks-lorry"
 	ProbeInitContainerName             = "kb-initprobe"
 	WeSyncerContainerName              = "kb-we-syncer"
 	RoleProbeContainerName             = "kb-checkrole"
@@ -270,6 +310,7 @@ const (
 	PodMinReadySecondsEnv = "POD_MIN_READY_SECONDS"
 	ConfigTemplateType    = "tpl"
 	ConfigInstanceType    = "instance"
+	ConfigRevisionType    = "revision"
 
 	ReconfigureManagerSource  = "manager"
 	ReconfigureUserSource     = "ops"
@@ -291,6 +332,10 @@ const (
 const (
 	AccountNameForSecret   = "username"
 	AccountPasswdForSecret = "password"
+	// AccountOldPasswdForSecret keeps the old password during the grace period of a password rotation.
+	AccountOldPasswdForSecret = "oldPassword"
+	// AccountPendingPasswdForSecret keeps the new password before it is applied to the database.
+	AccountPendingPasswdForSecret = "pendingPassword"
 )
 
 const (
@@ -87,6 +87,7 @@ const (
 	KBEnvServiceUser     = "KB_SERVICE_USER"
 	KBEnvServicePassword = "KB_SERVICE_PASSWORD"
 	KBEnvLorryHTTPPort   = "LORRY_HTTP_PORT"
+	KBEnvLorryScheme     = "LORRY_SCHEME"
 
 	// KBEnvServiceRoles defines the Roles configured in the cluster definition that are visible to users.
 	KBEnvServiceRoles = "KB_SERVICE_ROLES"
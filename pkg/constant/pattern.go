@@ -126,3 +126,8 @@ func GenerateVirtualComponentDefinition(compDefSuffix string) string {
 func GenerateResourceNameWithScalingSuffix(name string) string {
 	return fmt.Sprintf("%s-%s", name, SlashScalingLowerSuffix)
 }
+
+// GenerateConfigRevisionName generates the name of the ConfigMap which records the revision of the configuration.
+func GenerateConfigRevisionName(cfgName, revision string) string {
+	return fmt.Sprintf("%s-rev-%s", cfgName, revision)
+}
@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package constant
 
 const (
-	VolumeName = "tls"
-	CAName     = "ca.crt"
-	CertName   = "tls.crt"
-	KeyName    = "tls.key"
-	MountPath  = "/etc/pki/tls"
+	VolumeName     = "tls"
+	CAName         = "ca.crt"
+	CAKeyName      = "ca.key"
+	CertName       = "tls.crt"
+	KeyName        = "tls.key"
+	ClientCertName = "client.crt"
+	ClientKeyName  = "client.key"
+	MountPath      = "/etc/pki/tls"
 )
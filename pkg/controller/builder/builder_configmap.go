@@ -40,6 +40,7 @@ func (builder *ConfigMapBuilder) PutData(key, value string) *ConfigMapBuilder {
 	data := builder.get().Data
 	if data == nil {
 		data = make(map[string]string, 1)
+		builder.get().Data = data
 	}
 	data[key] = value
 	return builder
@@ -54,6 +55,7 @@ func (builder *ConfigMapBuilder) PutBinaryData(key string, value []byte) *Config
 	data := builder.get().BinaryData
 	if data == nil {
 		data = make(map[string][]byte, 1)
+		builder.get().BinaryData = data
 	}
 	data[key] = value
 	return builder
@@ -53,4 +53,14 @@ var _ = Describe("configmap builder", func() {
 		Expect(cm.Immutable).ShouldNot(BeNil())
 		Expect(*cm.Immutable).Should(BeTrue())
 	})
+
+	It("puts data into an empty configmap", func() {
+		cm := NewConfigMapBuilder("default", "foo").
+			PutData("foo", "bar").
+			PutBinaryData("foo", []byte("bar")).
+			GetObject()
+
+		Expect(cm.Data).Should(Equal(map[string]string{"foo": "bar"}))
+		Expect(cm.BinaryData).Should(Equal(map[string][]byte{"foo": []byte("bar")}))
+	})
 })
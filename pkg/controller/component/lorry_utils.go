@@ -22,7 +22,9 @@ package component
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
@@ -92,14 +94,14 @@ func buildLorryContainers(reqCtx intctrlutil.RequestCtx, synthesizeComp *Synthes
 	if compRoleProbe != nil {
 		reqCtx.Log.V(3).Info("lorry", "settings", compRoleProbe)
 		roleChangedContainer := container.DeepCopy()
-		buildRoleProbeContainer(roleChangedContainer, compRoleProbe, int(lorryHTTPPort))
+		buildRoleProbeContainer(roleChangedContainer, compRoleProbe, int(lorryHTTPPort), lorryProbeScheme(synthesizeComp))
 		lorryContainers = append(lorryContainers, *roleChangedContainer)
 	}
 
 	// inject volume protection probe container
 	if volumeProtectionEnabled(synthesizeComp) {
 		c := container.DeepCopy()
-		buildVolumeProtectionProbeContainer(synthesizeComp.CharacterType, c, int(lorryHTTPPort))
+		buildVolumeProtectionProbeContainer(synthesizeComp.CharacterType, c, int(lorryHTTPPort), lorryProbeScheme(synthesizeComp))
 		lorryContainers = append(lorryContainers, *c)
 	}
 
@@ -142,6 +144,24 @@ func buildLorryServiceContainer(synthesizeComp *SynthesizedComponent, container
 		"--port", strconv.Itoa(lorryHTTPPort),
 		"--grpcport", strconv.Itoa(lorryGRPCPort),
 	}
+	if IsLorryTLSEnabled(synthesizeComp) {
+		// the certificates of the component are mounted to all the containers, see componentTLSTransformer.
+		// the operator authenticates itself with a ServiceAccount token, the kubelet probes are allowed anonymously.
+		container.Command = append(container.Command,
+			"--tls-cert-file", filepath.Join(constant.MountPath, constant.CertName),
+			"--tls-key-file", filepath.Join(constant.MountPath, constant.KeyName),
+			"--auth-serviceaccount",
+			"--auth-admin-serviceaccounts", fmt.Sprintf("system:serviceaccount:%s:%s",
+				viper.GetString(constant.CfgKeyCtrlrMgrNS), viper.GetString(constant.KBServiceAccountName)),
+		)
+	}
+	if fencingAction := viper.GetString(constant.CfgKeyLorryFencingAction); fencingAction != "" {
+		container.Command = append(container.Command, "--fencing-action", fencingAction)
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  constant.KBEnvLorryScheme,
+		Value: strings.ToLower(string(lorryProbeScheme(synthesizeComp))),
+	})
 
 	if len(synthesizeComp.PodSpec.Containers) > 0 {
 		mainContainer := synthesizeComp.PodSpec.Containers[0]
@@ -253,11 +273,12 @@ func buildWeSyncerContainer(weSyncerContainer *corev1.Container, probeSvcHTTPPor
 	weSyncerContainer.StartupProbe.TCPSocket.Port = intstr.FromInt(probeSvcHTTPPort)
 }
 
-func buildRoleProbeContainer(roleChangedContainer *corev1.Container, roleProbe *appsv1alpha1.RoleProbe, probeSvcHTTPPort int) {
+func buildRoleProbeContainer(roleChangedContainer *corev1.Container, roleProbe *appsv1alpha1.RoleProbe, probeSvcHTTPPort int, scheme corev1.URIScheme) {
 	roleChangedContainer.Name = constant.RoleProbeContainerName
 	httpGet := &corev1.HTTPGetAction{}
 	httpGet.Path = constant.LorryRoleProbePath
 	httpGet.Port = intstr.FromInt(probeSvcHTTPPort)
+	httpGet.Scheme = scheme
 	probe := &corev1.Probe{}
 	probe.Exec = nil
 	probe.HTTPGet = httpGet
@@ -268,16 +289,30 @@ func buildRoleProbeContainer(roleChangedContainer *corev1.Container, roleProbe *
 	roleChangedContainer.StartupProbe.TCPSocket.Port = intstr.FromInt(probeSvcHTTPPort)
 }
 
+// IsLorryTLSEnabled checks whether lorry serves with TLS and authentication,
+// it requires the TLS of the component to be enabled, as the certificates are reused.
+func IsLorryTLSEnabled(synthesizeComp *SynthesizedComponent) bool {
+	return viper.GetBool(constant.CfgKeyLorryTLSEnabled) && synthesizeComp.TLSConfig != nil && synthesizeComp.TLSConfig.Enable
+}
+
+func lorryProbeScheme(synthesizeComp *SynthesizedComponent) corev1.URIScheme {
+	if IsLorryTLSEnabled(synthesizeComp) {
+		return corev1.URISchemeHTTPS
+	}
+	return corev1.URISchemeHTTP
+}
+
 func volumeProtectionEnabled(component *SynthesizedComponent) bool {
 	return component.VolumeProtection != nil
 }
 
-func buildVolumeProtectionProbeContainer(characterType string, c *corev1.Container, probeSvcHTTPPort int) {
+func buildVolumeProtectionProbeContainer(characterType string, c *corev1.Container, probeSvcHTTPPort int, scheme corev1.URIScheme) {
 	c.Name = constant.VolumeProtectionProbeContainerName
 	probe := &corev1.Probe{}
 	httpGet := &corev1.HTTPGetAction{}
 	httpGet.Path = constant.LorryVolumeProtectPath
 	httpGet.Port = intstr.FromInt(probeSvcHTTPPort)
+	httpGet.Scheme = scheme
 	probe.HTTPGet = httpGet
 	probe.PeriodSeconds = defaultVolumeProtectionProbe.PeriodSeconds
 	probe.TimeoutSeconds = defaultVolumeProtectionProbe.TimeoutSeconds
@@ -300,27 +335,32 @@ func env4VolumeProtection(spec appsv1alpha1.VolumeProtectionSpec) corev1.EnvVar
 // getBuiltinActionHandler gets the built-in handler.
 // The BuiltinActionHandler within the same synthesizeComp LifecycleActions should be consistent, we can take any one of them.
 func getBuiltinActionHandler(synthesizeComp *SynthesizedComponent) appsv1alpha1.BuiltinActionHandlerType {
-	if synthesizeComp.LifecycleActions == nil {
+	return GetBuiltinActionHandler(synthesizeComp.LifecycleActions)
+}
+
+// GetBuiltinActionHandler returns the builtin handler of the lifecycle actions, the handlers of the actions are consistent.
+func GetBuiltinActionHandler(lifecycleActions *appsv1alpha1.ComponentLifecycleActions) appsv1alpha1.BuiltinActionHandlerType {
+	if lifecycleActions == nil {
 		return appsv1alpha1.UnknownBuiltinActionHandler
 	}
 
-	if synthesizeComp.LifecycleActions.RoleProbe != nil && synthesizeComp.LifecycleActions.RoleProbe.BuiltinHandler != nil {
-		return *synthesizeComp.LifecycleActions.RoleProbe.BuiltinHandler
+	if lifecycleActions.RoleProbe != nil && lifecycleActions.RoleProbe.BuiltinHandler != nil {
+		return *lifecycleActions.RoleProbe.BuiltinHandler
 	}
 
 	actions := []struct {
 		LifeCycleActionHandlers *appsv1alpha1.LifecycleActionHandler
 	}{
-		{synthesizeComp.LifecycleActions.PostProvision},
-		{synthesizeComp.LifecycleActions.PreTerminate},
-		{synthesizeComp.LifecycleActions.MemberJoin},
-		{synthesizeComp.LifecycleActions.MemberLeave},
-		{synthesizeComp.LifecycleActions.Readonly},
-		{synthesizeComp.LifecycleActions.Readwrite},
-		{synthesizeComp.LifecycleActions.DataPopulate},
-		{synthesizeComp.LifecycleActions.DataAssemble},
-		{synthesizeComp.LifecycleActions.Reconfigure},
-		{synthesizeComp.LifecycleActions.AccountProvision},
+		{lifecycleActions.PostProvision},
+		{lifecycleActions.PreTerminate},
+		{lifecycleActions.MemberJoin},
+		{lifecycleActions.MemberLeave},
+		{lifecycleActions.Readonly},
+		{lifecycleActions.Readwrite},
+		{lifecycleActions.DataPopulate},
+		{lifecycleActions.DataAssemble},
+		{lifecycleActions.Reconfigure},
+		{lifecycleActions.AccountProvision},
 	}
 
 	for _, action := range actions {
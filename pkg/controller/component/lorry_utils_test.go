@@ -119,6 +119,62 @@ var _ = Describe("Lorry Utils", func() {
 			Expect(component.PodSpec.Containers[0].Name).Should(Equal(constant.RoleProbeContainerName))
 		})
 
+		It("build lorry containers with TLS enabled", func() {
+			reqCtx := intctrlutil.RequestCtx{
+				Ctx: ctx,
+				Log: logger,
+			}
+			viper.Set(constant.CfgKeyLorryTLSEnabled, true)
+			defer viper.Set(constant.CfgKeyLorryTLSEnabled, false)
+			defaultBuiltInHandler := appsv1alpha1.MySQLBuiltinActionHandler
+			component.LifecycleActions = &appsv1alpha1.ComponentLifecycleActions{
+				RoleProbe: &appsv1alpha1.RoleProbe{
+					LifecycleActionHandler: appsv1alpha1.LifecycleActionHandler{
+						BuiltinHandler: &defaultBuiltInHandler,
+					},
+				},
+			}
+
+			By("lorry serves with plain HTTP if the TLS of component is disabled")
+			Expect(buildLorryContainers(reqCtx, component, nil)).Should(Succeed())
+			Expect(component.PodSpec.Containers).Should(HaveLen(1))
+			lorryContainer := component.PodSpec.Containers[0]
+			Expect(lorryContainer.ReadinessProbe.HTTPGet.Scheme).Should(Equal(corev1.URISchemeHTTP))
+			Expect(lorryContainer.Command).ShouldNot(ContainElement("--tls-cert-file"))
+			Expect(lorryContainer.Env).Should(ContainElement(corev1.EnvVar{Name: constant.KBEnvLorryScheme, Value: "http"}))
+
+			By("lorry serves with HTTPS and authentication if the TLS of component is enabled")
+			component.PodSpec.Containers = nil
+			component.TLSConfig = &appsv1alpha1.TLSConfig{Enable: true}
+			Expect(buildLorryContainers(reqCtx, component, nil)).Should(Succeed())
+			Expect(component.PodSpec.Containers).Should(HaveLen(1))
+			lorryContainer = component.PodSpec.Containers[0]
+			Expect(lorryContainer.ReadinessProbe.HTTPGet.Scheme).Should(Equal(corev1.URISchemeHTTPS))
+			Expect(lorryContainer.Command).Should(ContainElements("--tls-cert-file", "--tls-key-file", "--auth-serviceaccount"))
+			Expect(lorryContainer.Env).Should(ContainElement(corev1.EnvVar{Name: constant.KBEnvLorryScheme, Value: "https"}))
+			Expect(lorryContainer.Ports).Should(ContainElement(HaveField("Name", constant.LorryHTTPPortName)))
+		})
+
+		It("build lorry containers with the fencing action", func() {
+			reqCtx := intctrlutil.RequestCtx{
+				Ctx: ctx,
+				Log: logger,
+			}
+			viper.Set(constant.CfgKeyLorryFencingAction, "label")
+			defer viper.Set(constant.CfgKeyLorryFencingAction, "")
+			defaultBuiltInHandler := appsv1alpha1.MySQLBuiltinActionHandler
+			component.LifecycleActions = &appsv1alpha1.ComponentLifecycleActions{
+				RoleProbe: &appsv1alpha1.RoleProbe{
+					LifecycleActionHandler: appsv1alpha1.LifecycleActionHandler{
+						BuiltinHandler: &defaultBuiltInHandler,
+					},
+				},
+			}
+			Expect(buildLorryContainers(reqCtx, component, nil)).Should(Succeed())
+			Expect(component.PodSpec.Containers).Should(HaveLen(1))
+			Expect(component.PodSpec.Containers[0].Command).Should(ContainElements("--fencing-action", "label"))
+		})
+
 		It("should build role service container", func() {
 			buildLorryServiceContainer(component, container, probeServiceHTTPPort, probeServiceGRPCPort, nil)
 			Expect(container.Command).ShouldNot(BeEmpty())
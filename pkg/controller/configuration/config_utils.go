@@ -113,7 +113,8 @@ func buildConfigManagerWithComponent(podSpec *corev1.PodSpec, configSpecs []apps
 }
 
 func checkAndUpdateSharProcessNamespace(podSpec *corev1.PodSpec, buildParams *cfgcm.CfgManagerBuildParams, configSpecMetas []cfgcm.ConfigSpecMeta) {
-	shared := cfgcm.NeedSharedProcessNamespace(configSpecMetas)
+	shared := cfgcm.NeedSharedProcessNamespace(configSpecMetas) ||
+		buildParams.TLSReloadSpec != nil && buildParams.TLSReloadSpec.ReloadType == appsv1alpha1.UnixSignalType
 	if shared {
 		podSpec.ShareProcessNamespace = func() *bool { b := true; return &b }()
 	}
@@ -215,6 +216,7 @@ func buildConfigManagerParams(cli client.Client, ctx context.Context, cluster *a
 		ConfigSpecsBuildParams:    configSpecBuildParams,
 		ConfigLazyRenderedVolumes: make(map[string]corev1.VolumeMount),
 		ContainerPort:             viper.GetInt32(constant.ConfigManagerGPRCPortEnv),
+		TLSVolume:                 getTLSVolumeMount(podSpec, comp),
 	}
 
 	if podSpec.HostNetwork {
@@ -234,6 +236,19 @@ func buildConfigManagerParams(cli client.Client, ctx context.Context, cluster *a
 	return cfgManagerParams, nil
 }
 
+// getTLSVolumeMount gets the volume mount of the TLS certificates if TLS is enabled for the component.
+func getTLSVolumeMount(podSpec *corev1.PodSpec, comp *component.SynthesizedComponent) *corev1.VolumeMount {
+	if comp.TLSConfig == nil || !comp.TLSConfig.Enable {
+		return nil
+	}
+	for _, container := range intctrlutil.GetPodContainerWithVolumeMount(podSpec, constant.VolumeName) {
+		if volume := intctrlutil.GetVolumeMountByVolume(container, constant.VolumeName); volume != nil {
+			return volume.DeepCopy()
+		}
+	}
+	return nil
+}
+
 func GetConfigManagerGRPCPort(containers []corev1.Container) (int32, error) {
 	for _, container := range containers {
 		if found := foundPortByConfigManagerPortName(container); found != nil {
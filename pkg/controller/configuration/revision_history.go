@@ -0,0 +1,219 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cast"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+// BuildConfigRevision builds the immutable ConfigMap which records the configuration revision of the config ConfigMap,
+// the rendered configuration files are kept in the data, and the applied config item is kept in the annotations.
+func BuildConfigRevision(configMap *corev1.ConfigMap, opsName string, previous *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	revision := configMap.Annotations[constant.ConfigurationRevision]
+	if revision == "" {
+		return nil, core.MakeError("the revision of configmap %s is empty", configMap.Name)
+	}
+	item, err := GetConfigRevisionItem(configMap)
+	if err != nil {
+		return nil, err
+	}
+	var updated map[string]appsv1alpha1.UpdatedParameters
+	if previous != nil {
+		base, err := GetConfigRevisionItem(previous)
+		if err != nil {
+			return nil, err
+		}
+		updated = diffConfigFileParams(base.ConfigFileParams, item.ConfigFileParams)
+	} else {
+		updated = diffConfigFileParams(nil, item.ConfigFileParams)
+	}
+	b, err := json.Marshal(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	cmBuilder := builder.NewConfigMapBuilder(configMap.Namespace, core.GenerateConfigRevisionName(configMap.Name, revision)).
+		AddLabelsInMap(configMap.Labels).
+		AddLabels(constant.CMConfigurationTypeLabelKey, constant.ConfigRevisionType).
+		AddLabels(constant.ConfigRevisionLabelKey, revision).
+		AddAnnotations(constant.ConfigurationRevision, revision).
+		AddAnnotations(constant.ConfigAppliedVersionAnnotationKey, configMap.Annotations[constant.ConfigAppliedVersionAnnotationKey]).
+		AddAnnotations(constant.ConfigRevisionUpdatedParamsAnnotationKey, string(b)).
+		SetData(configMap.Data).
+		SetImmutable(true)
+	if opsName != "" {
+		cmBuilder.AddAnnotations(constant.LastAppliedOpsCRAnnotationKey, opsName)
+	}
+	revisionCM := cmBuilder.GetObject()
+	delete(revisionCM.Labels, constant.CMInsConfigurationHashLabelKey)
+	delete(revisionCM.Labels, constant.CMInsLastReconfigurePhaseKey)
+	return revisionCM, nil
+}
+
+// GetConfigRevisionItem returns the config item applied in the revision.
+func GetConfigRevisionItem(configMap *corev1.ConfigMap) (*appsv1alpha1.ConfigurationItemDetail, error) {
+	item := &appsv1alpha1.ConfigurationItemDetail{}
+	data := configMap.Annotations[constant.ConfigAppliedVersionAnnotationKey]
+	if data == "" {
+		return item, nil
+	}
+	if err := json.Unmarshal([]byte(data), item); err != nil {
+		return nil, core.WrapError(err, "failed to parse the applied config item of configmap %s", configMap.Name)
+	}
+	return item, nil
+}
+
+// ListConfigRevisions lists the revisions of the config ConfigMap, which are sorted by the revision.
+func ListConfigRevisions(ctx context.Context, cli client.Reader, configMap *corev1.ConfigMap) ([]corev1.ConfigMap, error) {
+	cmList := &corev1.ConfigMapList{}
+	if err := cli.List(ctx, cmList, client.InNamespace(configMap.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:                 configMap.Labels[constant.AppInstanceLabelKey],
+		constant.KBAppComponentLabelKey:              configMap.Labels[constant.KBAppComponentLabelKey],
+		constant.CMConfigurationSpecProviderLabelKey: configMap.Labels[constant.CMConfigurationSpecProviderLabelKey],
+		constant.CMConfigurationTypeLabelKey:         constant.ConfigRevisionType,
+	}); err != nil {
+		return nil, err
+	}
+	revisions := make([]corev1.ConfigMap, 0, len(cmList.Items))
+	for _, cm := range cmList.Items {
+		if _, err := strconv.ParseInt(cm.Labels[constant.ConfigRevisionLabelKey], 10, 64); err == nil {
+			revisions = append(revisions, cm)
+		}
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		ri, _ := strconv.ParseInt(revisions[i].Labels[constant.ConfigRevisionLabelKey], 10, 64)
+		rj, _ := strconv.ParseInt(revisions[j].Labels[constant.ConfigRevisionLabelKey], 10, 64)
+		return ri < rj
+	})
+	return revisions, nil
+}
+
+// RollbackConfigFileParams returns the config file params which restore the configuration to the revision.
+//
+// the params are merged into the current configuration files, so the params which are updated after the revision
+// are restored to the values in the configuration files of the revision, or deleted if they are absent in the revision.
+func RollbackConfigFileParams(current map[string]appsv1alpha1.ConfigParams, revisionCM *corev1.ConfigMap,
+	formatter *appsv1alpha1.FormatterConfig) (map[string]appsv1alpha1.ConfigParams, error) {
+	revisionItem, err := GetConfigRevisionItem(revisionCM)
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]appsv1alpha1.ConfigParams, len(current))
+	for file, param := range revisionItem.ConfigFileParams {
+		params[file] = *param.DeepCopy()
+	}
+	for file, param := range current {
+		data, ok := revisionCM.Data[file]
+		if !ok {
+			continue
+		}
+		target := params[file]
+		if param.Content != nil && target.Content == nil {
+			target.Content = &data
+		}
+		if len(param.Parameters) > 0 && formatter != nil {
+			configObj, err := core.FromConfigObject(file, data, formatter)
+			if err != nil {
+				return nil, err
+			}
+			for key := range param.Parameters {
+				if _, ok := target.Parameters[key]; ok {
+					continue
+				}
+				if target.Parameters == nil {
+					target.Parameters = make(map[string]*string)
+				}
+				var value *string
+				if v := configObj.Get(key); v != nil {
+					value = cfgutil.ToPointer(cast.ToString(v))
+				}
+				target.Parameters[key] = value
+			}
+		}
+		params[file] = target
+	}
+	return params, nil
+}
+
+// diffConfigFileParams returns the parameters updated from base to updated for each config file.
+func diffConfigFileParams(base, updated map[string]appsv1alpha1.ConfigParams) map[string]appsv1alpha1.UpdatedParameters {
+	toString := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+
+	result := make(map[string]appsv1alpha1.UpdatedParameters)
+	for file, param := range updated {
+		diff := appsv1alpha1.UpdatedParameters{}
+		baseParams := base[file].Parameters
+		for key, value := range param.Parameters {
+			old, ok := baseParams[key]
+			switch {
+			case !ok:
+				if diff.AddedKeys == nil {
+					diff.AddedKeys = make(map[string]string)
+				}
+				diff.AddedKeys[key] = toString(value)
+			case toString(old) != toString(value) || (old == nil) != (value == nil):
+				if diff.UpdatedKeys == nil {
+					diff.UpdatedKeys = make(map[string]string)
+				}
+				diff.UpdatedKeys[key] = toString(value)
+			}
+		}
+		for key, value := range baseParams {
+			if _, ok := param.Parameters[key]; !ok {
+				if diff.DeletedKeys == nil {
+					diff.DeletedKeys = make(map[string]string)
+				}
+				diff.DeletedKeys[key] = toString(value)
+			}
+		}
+		if diff.AddedKeys != nil || diff.UpdatedKeys != nil || diff.DeletedKeys != nil {
+			result[file] = diff
+		}
+	}
+	for file, param := range base {
+		if _, ok := updated[file]; ok || len(param.Parameters) == 0 {
+			continue
+		}
+		diff := appsv1alpha1.UpdatedParameters{DeletedKeys: make(map[string]string)}
+		for key, value := range param.Parameters {
+			diff.DeletedKeys[key] = toString(value)
+		}
+		result[file] = diff
+	}
+	return result
+}
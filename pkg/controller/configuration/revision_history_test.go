@@ -0,0 +1,123 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("ConfigRevisionHistoryTest", func() {
+	const (
+		cmName     = "test-mysql-mysql-config"
+		configFile = "my.cnf"
+	)
+
+	formatter := &appsv1alpha1.FormatterConfig{
+		Format: appsv1alpha1.Ini,
+		FormatterOptions: appsv1alpha1.FormatterOptions{
+			IniConfig: &appsv1alpha1.IniConfig{
+				SectionName: "mysqld",
+			},
+		},
+	}
+
+	newConfigMap := func(revision string, data string, params map[string]*string) *builder.ConfigMapBuilder {
+		item := appsv1alpha1.ConfigurationItemDetail{
+			Name: "mysql-config",
+			ConfigFileParams: map[string]appsv1alpha1.ConfigParams{
+				configFile: {Parameters: params},
+			},
+		}
+		b, _ := json.Marshal(item)
+		return builder.NewConfigMapBuilder("default", cmName).
+			AddLabels(constant.AppInstanceLabelKey, "test").
+			AddLabels(constant.KBAppComponentLabelKey, "mysql").
+			AddLabels(constant.CMConfigurationSpecProviderLabelKey, "mysql-config").
+			AddLabels(constant.CMConfigurationTypeLabelKey, constant.ConfigInstanceType).
+			AddAnnotations(constant.ConfigurationRevision, revision).
+			AddAnnotations(constant.ConfigAppliedVersionAnnotationKey, string(b)).
+			PutData(configFile, data)
+	}
+
+	Context("build revision", func() {
+		It("records the applied configuration and the updated parameters", func() {
+			previous, err := BuildConfigRevision(newConfigMap("1", "[mysqld]\nmax_connections=1000\n", map[string]*string{
+				"max_connections": cfgutil.ToPointer("1000"),
+			}).GetObject(), "", nil)
+			Expect(err).Should(Succeed())
+
+			configMap := newConfigMap("2", "[mysqld]\nmax_connections=2000\nbinlog_format=ROW\n", map[string]*string{
+				"max_connections": cfgutil.ToPointer("2000"),
+				"binlog_format":   cfgutil.ToPointer("ROW"),
+			}).GetObject()
+			revision, err := BuildConfigRevision(configMap, "reconfigure-ops", previous)
+			Expect(err).Should(Succeed())
+			Expect(revision.Name).Should(Equal(cmName + "-rev-2"))
+			Expect(*revision.Immutable).Should(BeTrue())
+			Expect(revision.Data).Should(Equal(configMap.Data))
+			Expect(revision.Labels[constant.CMConfigurationTypeLabelKey]).Should(Equal(constant.ConfigRevisionType))
+			Expect(revision.Labels[constant.ConfigRevisionLabelKey]).Should(Equal("2"))
+			Expect(revision.Annotations[constant.LastAppliedOpsCRAnnotationKey]).Should(Equal("reconfigure-ops"))
+
+			updated := map[string]appsv1alpha1.UpdatedParameters{}
+			Expect(json.Unmarshal([]byte(revision.Annotations[constant.ConfigRevisionUpdatedParamsAnnotationKey]), &updated)).Should(Succeed())
+			Expect(updated).Should(Equal(map[string]appsv1alpha1.UpdatedParameters{
+				configFile: {
+					AddedKeys:   map[string]string{"binlog_format": "ROW"},
+					UpdatedKeys: map[string]string{"max_connections": "2000"},
+				},
+			}))
+		})
+	})
+
+	Context("rollback to revision", func() {
+		It("restores the parameters updated after the revision", func() {
+			revision, err := BuildConfigRevision(newConfigMap("1", "[mysqld]\nmax_connections=1000\nsql_mode=STRICT\n", map[string]*string{
+				"max_connections": cfgutil.ToPointer("1000"),
+			}).GetObject(), "", nil)
+			Expect(err).Should(Succeed())
+
+			current := map[string]appsv1alpha1.ConfigParams{
+				configFile: {Parameters: map[string]*string{
+					"max_connections": cfgutil.ToPointer("2000"),
+					"sql_mode":        cfgutil.ToPointer("ANSI"),
+					"binlog_format":   cfgutil.ToPointer("ROW"),
+				}},
+			}
+			params, err := RollbackConfigFileParams(current, revision, formatter)
+			Expect(err).Should(Succeed())
+			Expect(params).Should(Equal(map[string]appsv1alpha1.ConfigParams{
+				configFile: {Parameters: map[string]*string{
+					"max_connections": cfgutil.ToPointer("1000"),
+					"sql_mode":        cfgutil.ToPointer("STRICT"),
+					"binlog_format":   nil,
+				}},
+			}))
+		})
+	})
+})
@@ -22,6 +22,7 @@ package plan
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,6 +30,7 @@ import (
 	"github.com/golang/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -44,7 +46,22 @@ var _ = Describe("TLSUtilsTest", func() {
 		It("should work well", func() {
 			clusterName := "bar"
 			componentName := "test"
-			secret, err := ComposeTLSSecret(namespace, clusterName, componentName)
+			caSecret, err := ComposeTLSCASecret(namespace, clusterName)
+			Expect(err).Should(BeNil())
+			Expect(caSecret).ShouldNot(BeNil())
+			Expect(caSecret.Name).Should(Equal(fmt.Sprintf("%s-tls-ca", clusterName)))
+			Expect(caSecret.StringData[constant.CAName]).ShouldNot(BeZero())
+			Expect(caSecret.StringData[constant.CAKeyName]).ShouldNot(BeZero())
+
+			dnsNames := BuildTLSDNSNames(namespace, clusterName, componentName, 2, []string{"bar-test-rw"})
+			Expect(dnsNames).Should(ContainElements(
+				"bar-test.foo.svc",
+				"bar-test-rw.foo.svc.cluster.local",
+				"*.bar-test-headless.foo.svc",
+				"bar-test-1.bar-test-headless.foo.svc.cluster.local",
+			))
+			issuer := &appsv1alpha1.Issuer{Name: appsv1alpha1.IssuerKubeBlocks}
+			secret, err := ComposeTLSSecret(namespace, clusterName, componentName, caSecret, dnsNames, issuer)
 			Expect(err).Should(BeNil())
 			Expect(secret).ShouldNot(BeNil())
 			Expect(secret.Name).Should(Equal(fmt.Sprintf("%s-%s-tls-certs", clusterName, componentName)))
@@ -52,9 +69,56 @@ var _ = Describe("TLSUtilsTest", func() {
 			Expect(secret.Labels[constant.AppInstanceLabelKey]).Should(Equal(clusterName))
 			Expect(secret.Labels[constant.KBManagedByKey]).Should(Equal(constant.AppName))
 			Expect(secret.StringData).ShouldNot(BeNil())
-			Expect(secret.StringData[constant.CAName]).ShouldNot(BeZero())
+			Expect(secret.StringData[constant.CAName]).Should(Equal(caSecret.StringData[constant.CAName]))
 			Expect(secret.StringData[constant.CertName]).ShouldNot(BeZero())
 			Expect(secret.StringData[constant.KeyName]).ShouldNot(BeZero())
+			Expect(secret.StringData).ShouldNot(HaveKey(constant.ClientCertName))
+
+			By("check the certificate is signed by the CA and covers the pods")
+			Expect(IsTLSSecretValid(secret, caSecret, []string{"bar-test-5.bar-test-headless.foo.svc"}, issuer)).Should(BeTrue())
+			Expect(IsTLSSecretValid(secret, caSecret, []string{"bar-test-ro.foo.svc"}, issuer)).Should(BeFalse())
+			clientCertIssuer := &appsv1alpha1.Issuer{Name: appsv1alpha1.IssuerKubeBlocks, EnableClientCert: true}
+			Expect(IsTLSSecretValid(secret, caSecret, dnsNames, clientCertIssuer)).Should(BeFalse())
+			otherCASecret, err := ComposeTLSCASecret(namespace, clusterName)
+			Expect(err).Should(BeNil())
+			Expect(IsTLSSecretValid(secret, otherCASecret, dnsNames, issuer)).Should(BeFalse())
+
+			By("issue the client certificate")
+			secret, err = ComposeTLSSecret(namespace, clusterName, componentName, caSecret, dnsNames, clientCertIssuer)
+			Expect(err).Should(BeNil())
+			Expect(secret.StringData[constant.ClientCertName]).ShouldNot(BeZero())
+			Expect(secret.StringData[constant.ClientKeyName]).ShouldNot(BeZero())
+			Expect(IsTLSSecretValid(secret, caSecret, dnsNames, clientCertIssuer)).Should(BeTrue())
+		})
+
+		It("should renew the certificate in time", func() {
+			caSecret, err := ComposeTLSCASecret(namespace, "bar")
+			Expect(err).Should(BeNil())
+			dnsNames := BuildTLSDNSNames(namespace, "bar", "test", 1, nil)
+
+			By("the certificate is valid for 90 days by default, and renewed 30 days before the expiry")
+			issuer := &appsv1alpha1.Issuer{Name: appsv1alpha1.IssuerKubeBlocks}
+			secret, err := ComposeTLSSecret(namespace, "bar", "test", caSecret, dnsNames, issuer)
+			Expect(err).Should(BeNil())
+			cert, err := ParseTLSCert(secret, constant.CertName)
+			Expect(err).Should(BeNil())
+			Expect(cert.NotAfter).Should(BeTemporally("~", time.Now().Add(appsv1alpha1.MaxTLSCertificateDuration), time.Minute))
+			Expect(GetTLSCertRenewalTime(cert, issuer)).Should(Equal(cert.NotAfter.Add(-appsv1alpha1.DefaultTLSRenewBefore)))
+			Expect(IsTLSSecretValid(secret, caSecret, dnsNames, issuer)).Should(BeTrue())
+
+			By("the certificate is due for renewal")
+			renewIssuer := issuer.DeepCopy()
+			renewIssuer.RenewBefore = &metav1.Duration{Duration: appsv1alpha1.MaxTLSCertificateDuration}
+			Expect(IsTLSSecretValid(secret, caSecret, dnsNames, renewIssuer)).Should(BeFalse())
+
+			By("the certificate lives longer than the certificate duration")
+			shortIssuer := issuer.DeepCopy()
+			shortIssuer.CertificateDuration = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+			shortIssuer.RenewBefore = &metav1.Duration{Duration: 24 * time.Hour}
+			Expect(IsTLSSecretValid(secret, caSecret, dnsNames, shortIssuer)).Should(BeFalse())
+			secret, err = ComposeTLSSecret(namespace, "bar", "test", caSecret, dnsNames, shortIssuer)
+			Expect(err).Should(BeNil())
+			Expect(IsTLSSecretValid(secret, caSecret, dnsNames, shortIssuer)).Should(BeTrue())
 		})
 	})
 
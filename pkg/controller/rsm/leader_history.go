@@ -0,0 +1,169 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rsm
+
+import (
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// maxLeaderHistoryLength is the max number of leader changes kept in the rsm status.
+const maxLeaderHistoryLength = 20
+
+// recordLeaderChange records a leader change in rsm.Status.LeaderHistory if pod becomes the new leader.
+func recordLeaderChange(cli client.Client, reqCtx intctrlutil.RequestCtx, rsm *workloads.ReplicatedStateMachine,
+	pod *corev1.Pod, roleName string, message *probeMessage, observedTime time.Time) error {
+	roleMap := composeRoleMap(*rsm)
+	if role, ok := roleMap[roleName]; !ok || !role.IsLeader {
+		return nil
+	}
+	if role, ok := roleMap[getRoleName(*pod)]; ok && role.IsLeader {
+		return nil
+	}
+	history := rsm.Status.LeaderHistory
+	if len(history) > 0 && history[len(history)-1].NewLeader == pod.Name {
+		return nil
+	}
+
+	oldLeader, err := getOldLeader(cli, reqCtx, rsm, pod, message)
+	if err != nil {
+		return err
+	}
+	// the initial election is not a leader change
+	if oldLeader == "" {
+		return nil
+	}
+	reason, err := getLeaderChangeReason(cli, reqCtx, rsm, oldLeader, message)
+	if err != nil {
+		return err
+	}
+	change := workloads.LeaderChange{
+		OldLeader:      oldLeader,
+		NewLeader:      pod.Name,
+		Reason:         reason,
+		Time:           metav1.NewTime(observedTime),
+		ReplicationLag: message.Lag,
+	}
+	if message.UnavailableSeconds != nil {
+		change.UnavailableDuration = &metav1.Duration{Duration: time.Duration(*message.UnavailableSeconds) * time.Second}
+	}
+	reqCtx.Log.Info("leader changed", "oldLeader", change.OldLeader, "newLeader", change.NewLeader, "reason", change.Reason)
+
+	rsmOrig := rsm.DeepCopy()
+	rsm.Status.LeaderHistory = appendLeaderChange(rsm.Status.LeaderHistory, change)
+	return cli.Status().Patch(reqCtx.Ctx, rsm, client.MergeFromWithOptions(rsmOrig, client.MergeFromWithOptimisticLock{}))
+}
+
+// appendLeaderChange appends change to history and drops the oldest ones beyond maxLeaderHistoryLength.
+func appendLeaderChange(history []workloads.LeaderChange, change workloads.LeaderChange) []workloads.LeaderChange {
+	history = append(history, change)
+	if len(history) > maxLeaderHistoryLength {
+		history = history[len(history)-maxLeaderHistoryLength:]
+	}
+	return history
+}
+
+// getOldLeader finds the previous leader, in order of the role probe report, the members status,
+// the leader history and the role labels of the other pods.
+func getOldLeader(cli client.Client, reqCtx intctrlutil.RequestCtx, rsm *workloads.ReplicatedStateMachine,
+	pod *corev1.Pod, message *probeMessage) (string, error) {
+	if message.PreviousLeader != "" {
+		return message.PreviousLeader, nil
+	}
+	if leader := getLeaderPodName(rsm.Status.MembersStatus); leader != "" && leader != pod.Name {
+		return leader, nil
+	}
+	if history := rsm.Status.LeaderHistory; len(history) > 0 {
+		return history[len(history)-1].NewLeader, nil
+	}
+	sts := builder.NewStatefulSetBuilder(rsm.Namespace, rsm.Name).SetSelector(rsm.Spec.Selector).GetObject()
+	pods, err := getPodsOfStatefulSet(reqCtx.Ctx, cli, sts)
+	if err != nil {
+		return "", err
+	}
+	roleMap := composeRoleMap(*rsm)
+	for _, p := range pods {
+		if p.Name == pod.Name {
+			continue
+		}
+		if role, ok := roleMap[getRoleName(p)]; ok && role.IsLeader {
+			return p.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// getLeaderChangeReason tells why the leader changed:
+// Manual if a Switchover OpsRequest is in progress, the reason reported by the role probe if any,
+// Switchover if a switchover action of the rsm is in progress, Failover if the old leader is not ready.
+func getLeaderChangeReason(cli client.Client, reqCtx intctrlutil.RequestCtx, rsm *workloads.ReplicatedStateMachine,
+	oldLeader string, message *probeMessage) (workloads.LeaderChangeReason, error) {
+	if clusterName, ok := rsm.Labels[constant.AppInstanceLabelKey]; ok {
+		jobs := &batchv1.JobList{}
+		if err := cli.List(reqCtx.Ctx, jobs, client.InNamespace(rsm.Namespace), client.MatchingLabels{
+			constant.AppInstanceLabelKey:    clusterName,
+			constant.KBAppComponentLabelKey: rsm.Labels[constant.KBAppComponentLabelKey],
+			constant.SwitchoverJobLabelKey:  constant.SwitchoverJobLabelValue,
+		}); err != nil {
+			return "", err
+		}
+		if len(jobs.Items) > 0 {
+			return workloads.ManualLeaderChangeReason, nil
+		}
+	}
+
+	switch workloads.LeaderChangeReason(message.Reason) {
+	case workloads.FailoverLeaderChangeReason, workloads.SwitchoverLeaderChangeReason:
+		return workloads.LeaderChangeReason(message.Reason), nil
+	}
+
+	actionLabels := getLabels(rsm)
+	actionLabels[jobTypeLabel] = jobTypeSwitchover
+	actionLabels[jobHandledLabel] = jobHandledFalse
+	actions := &batchv1.JobList{}
+	if err := cli.List(reqCtx.Ctx, actions, client.InNamespace(rsm.Namespace), client.MatchingLabels(actionLabels)); err != nil {
+		return "", err
+	}
+	if len(actions.Items) > 0 {
+		return workloads.SwitchoverLeaderChangeReason, nil
+	}
+
+	oldLeaderPod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Namespace: rsm.Namespace, Name: oldLeader}, oldLeaderPod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return workloads.FailoverLeaderChangeReason, nil
+		}
+		return "", err
+	}
+	if !oldLeaderPod.DeletionTimestamp.IsZero() || !intctrlutil.PodIsReady(oldLeaderPod) {
+		return workloads.FailoverLeaderChangeReason, nil
+	}
+	return workloads.SwitchoverLeaderChangeReason, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rsm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	mockclient "github.com/apecloud/kubeblocks/pkg/testutil/k8s/mocks"
+)
+
+var _ = Describe("leader history test", func() {
+	var (
+		reqCtx     intctrlutil.RequestCtx
+		rsmObj     *workloads.ReplicatedStateMachine
+		newLeader  *corev1.Pod
+		leaderRole = workloads.ReplicaRole{Name: "leader", AccessMode: workloads.ReadWriteMode, IsLeader: true, CanVote: true}
+		observed   = time.Now()
+	)
+
+	BeforeEach(func() {
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx, Log: logger}
+		rsmObj = builder.NewReplicatedStateMachineBuilder(namespace, name).
+			AddLabels(constant.AppInstanceLabelKey, "cluster").
+			AddLabels(constant.KBAppComponentLabelKey, "comp").
+			SetRoles([]workloads.ReplicaRole{
+				leaderRole,
+				{Name: "follower", AccessMode: workloads.ReadonlyMode, CanVote: true},
+			}).
+			GetObject()
+		rsmObj.Status.MembersStatus = []workloads.MemberStatus{
+			{PodName: getPodName(name, 0), ReplicaRole: leaderRole},
+		}
+		newLeader = builder.NewPodBuilder(namespace, getPodName(name, 1)).
+			AddLabels(roleLabelKey, "follower").
+			GetObject()
+	})
+
+	expectStatusPatch := func(check func(rsm *workloads.ReplicatedStateMachine)) {
+		statusWriter := mockclient.NewMockStatusWriter(gomock.NewController(GinkgoT()))
+		k8sMock.EXPECT().Status().Return(statusWriter).Times(1)
+		statusWriter.EXPECT().
+			Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, rsm *workloads.ReplicatedStateMachine, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				check(rsm)
+				return nil
+			}).Times(1)
+	}
+
+	expectJobList := func(items ...batchv1.Job) {
+		k8sMock.EXPECT().
+			List(gomock.Any(), &batchv1.JobList{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, list *batchv1.JobList, _ ...client.ListOption) error {
+				list.Items = items
+				return nil
+			}).Times(1)
+	}
+
+	Context("recordLeaderChange function", func() {
+		It("should record the reason and metrics reported by the role probe", func() {
+			message := &probeMessage{
+				Reason:             string(workloads.FailoverLeaderChangeReason),
+				Lag:                pointer.Int64(5),
+				UnavailableSeconds: pointer.Int64(3),
+			}
+			expectJobList()
+			expectStatusPatch(func(rsm *workloads.ReplicatedStateMachine) {
+				Expect(rsm.Status.LeaderHistory).Should(HaveLen(1))
+				change := rsm.Status.LeaderHistory[0]
+				Expect(change.OldLeader).Should(Equal(getPodName(name, 0)))
+				Expect(change.NewLeader).Should(Equal(newLeader.Name))
+				Expect(change.Reason).Should(Equal(workloads.FailoverLeaderChangeReason))
+				Expect(*change.ReplicationLag).Should(BeEquivalentTo(5))
+				Expect(change.UnavailableDuration.Duration).Should(Equal(3 * time.Second))
+			})
+			Expect(recordLeaderChange(k8sMock, reqCtx, rsmObj, newLeader, leaderRole.Name, message, observed)).Should(Succeed())
+		})
+
+		It("should take a Switchover OpsRequest as a manual change", func() {
+			message := &probeMessage{Reason: string(workloads.SwitchoverLeaderChangeReason)}
+			expectJobList(batchv1.Job{})
+			expectStatusPatch(func(rsm *workloads.ReplicatedStateMachine) {
+				Expect(rsm.Status.LeaderHistory).Should(HaveLen(1))
+				Expect(rsm.Status.LeaderHistory[0].Reason).Should(Equal(workloads.ManualLeaderChangeReason))
+			})
+			Expect(recordLeaderChange(k8sMock, reqCtx, rsmObj, newLeader, leaderRole.Name, message, observed)).Should(Succeed())
+		})
+
+		It("should take an unready old leader as a failover", func() {
+			expectJobList()
+			expectJobList()
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &corev1.Pod{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, p *corev1.Pod, _ ...client.GetOptions) error {
+					Expect(objKey.Name).Should(Equal(getPodName(name, 0)))
+					p.Namespace = objKey.Namespace
+					p.Name = objKey.Name
+					return nil
+				}).Times(1)
+			expectStatusPatch(func(rsm *workloads.ReplicatedStateMachine) {
+				Expect(rsm.Status.LeaderHistory).Should(HaveLen(1))
+				Expect(rsm.Status.LeaderHistory[0].Reason).Should(Equal(workloads.FailoverLeaderChangeReason))
+			})
+			Expect(recordLeaderChange(k8sMock, reqCtx, rsmObj, newLeader, leaderRole.Name, &probeMessage{}, observed)).Should(Succeed())
+		})
+
+		It("should ignore the role changes that are not a leader change", func() {
+			By("a non-leader role")
+			Expect(recordLeaderChange(k8sMock, reqCtx, rsmObj, newLeader, "follower", &probeMessage{}, observed)).Should(Succeed())
+
+			By("the pod is the leader already")
+			newLeader.Labels[roleLabelKey] = leaderRole.Name
+			Expect(recordLeaderChange(k8sMock, reqCtx, rsmObj, newLeader, leaderRole.Name, &probeMessage{}, observed)).Should(Succeed())
+
+			By("the change has been recorded")
+			newLeader.Labels[roleLabelKey] = "follower"
+			rsmObj.Status.LeaderHistory = []workloads.LeaderChange{{OldLeader: getPodName(name, 0), NewLeader: newLeader.Name}}
+			Expect(recordLeaderChange(k8sMock, reqCtx, rsmObj, newLeader, leaderRole.Name, &probeMessage{}, observed)).Should(Succeed())
+		})
+	})
+
+	Context("appendLeaderChange function", func() {
+		It("should keep the latest changes only", func() {
+			var history []workloads.LeaderChange
+			for i := 0; i < maxLeaderHistoryLength+2; i++ {
+				history = appendLeaderChange(history, workloads.LeaderChange{NewLeader: fmt.Sprintf("pod-%d", i)})
+			}
+			Expect(history).Should(HaveLen(maxLeaderHistoryLength))
+			Expect(history[0].NewLeader).Should(Equal("pod-2"))
+			Expect(history[maxLeaderHistoryLength-1].NewLeader).Should(Equal(fmt.Sprintf("pod-%d", maxLeaderHistoryLength+1)))
+		})
+	})
+})
@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
@@ -52,6 +53,12 @@ type probeMessage struct {
 	Message      string         `json:"message,omitempty"`
 	OriginalRole string         `json:"originalRole,omitempty"`
 	Role         string         `json:"role,omitempty"`
+
+	// the following fields are reported along with the role change by the lorry HA if the pod is promoted.
+	PreviousLeader     string `json:"previousLeader,omitempty"`
+	Reason             string `json:"reason,omitempty"`
+	Lag                *int64 `json:"lag,omitempty"`
+	UnavailableSeconds *int64 `json:"unavailableSeconds,omitempty"`
 }
 
 const (
@@ -135,11 +142,21 @@ func handleRoleChangedEvent(cli client.Client, reqCtx intctrlutil.RequestCtx, re
 		}
 
 		name, _ := intctrlutil.GetParentNameAndOrdinal(pod)
-		rsm := &workloads.ReplicatedStateMachine{}
-		if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: pod.Namespace, Name: name}, rsm); err != nil {
+		var rsm *workloads.ReplicatedStateMachine
+		reqCtx.Log.V(1).Info("handle role change event", "pod", pod.Name, "role", role, "originalRole", message.OriginalRole)
+
+		// the leader change must be recorded before the role label is updated, the role label is
+		// what tells a leader change, the change would be lost once the label is updated.
+		// the rsm is re-got on conflicts, as its status is updated by the rsm controller concurrently.
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			rsm = &workloads.ReplicatedStateMachine{}
+			if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: pod.Namespace, Name: name}, rsm); err != nil {
+				return err
+			}
+			return recordLeaderChange(cli, reqCtx, rsm, pod, pair.RoleName, message, getEventTime(event))
+		}); err != nil {
 			return "", err
 		}
-		reqCtx.Log.V(1).Info("handle role change event", "pod", pod.Name, "role", role, "originalRole", message.OriginalRole)
 
 		if err := updatePodRoleLabel(cli, reqCtx, *rsm, pod, pair.RoleName, snapshot.Version); err != nil {
 			return "", err
@@ -148,6 +165,17 @@ func handleRoleChangedEvent(cli client.Client, reqCtx intctrlutil.RequestCtx, re
 	return role, nil
 }
 
+// getEventTime returns the time when the event was observed.
+func getEventTime(event *corev1.Event) time.Time {
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	return time.Now()
+}
+
 func parseGlobalRoleSnapshot(role string, event *corev1.Event) *common.GlobalRoleSnapshot {
 	snapshot := &common.GlobalRoleSnapshot{}
 	if err := json.Unmarshal([]byte(role), snapshot); err == nil {
@@ -28,6 +28,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -35,6 +37,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	mockclient "github.com/apecloud/kubeblocks/pkg/testutil/k8s/mocks"
 )
 
 var _ = Describe("pod role label event handler test", func() {
@@ -86,6 +89,9 @@ var _ = Describe("pod role label event handler test", func() {
 					rsm.Spec.Roles = []workloads.ReplicaRole{role}
 					return nil
 				}).Times(1)
+			k8sMock.EXPECT().
+				List(gomock.Any(), &corev1.PodList{}, gomock.Any()).
+				Return(nil).Times(1)
 			k8sMock.EXPECT().
 				Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				DoAndReturn(func(_ context.Context, pd *corev1.Pod, patch client.Patch, _ ...client.PatchOption) error {
@@ -121,6 +127,77 @@ var _ = Describe("pod role label event handler test", func() {
 				}).Times(1)
 			Expect(handler.Handle(cli, reqCtx, nil, event)).Should(Succeed())
 		})
+
+		It("should retry recording the leader change on conflicts", func() {
+			reqCtx := intctrlutil.RequestCtx{
+				Ctx: ctx,
+				Log: logger,
+			}
+			pod := builder.NewPodBuilder(namespace, getPodName(name, 1)).SetUID(uid).GetObject()
+			objectRef := corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Namespace:  pod.Namespace,
+				Name:       pod.Name,
+				UID:        pod.UID,
+				FieldPath:  readinessProbeEventFieldPath,
+			}
+			role := workloads.ReplicaRole{
+				Name:       "leader",
+				AccessMode: workloads.ReadWriteMode,
+				IsLeader:   true,
+				CanVote:    true,
+			}
+			message := fmt.Sprintf("{\"event\":\"Success\",\"role\":\"%s\",\"previousLeader\":\"%s\",\"reason\":\"%s\"}",
+				role.Name, getPodName(name, 0), workloads.FailoverLeaderChangeReason)
+			event := builder.NewEventBuilder(namespace, "foo").
+				SetInvolvedObject(objectRef).
+				SetMessage(message).
+				GetObject()
+
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &corev1.Pod{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, p *corev1.Pod, _ ...client.GetOptions) error {
+					p.Namespace = objKey.Namespace
+					p.Name = objKey.Name
+					p.UID = pod.UID
+					p.Labels = map[string]string{constant.AppInstanceLabelKey: name}
+					return nil
+				}).Times(1)
+			// the rsm is re-got after the conflict
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&workloads.ReplicatedStateMachine{}), gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, rsm *workloads.ReplicatedStateMachine, _ ...client.GetOptions) error {
+					rsm.Namespace = objKey.Namespace
+					rsm.Name = objKey.Name
+					rsm.Spec.Roles = []workloads.ReplicaRole{role}
+					return nil
+				}).Times(2)
+			statusWriter := mockclient.NewMockStatusWriter(gomock.NewController(GinkgoT()))
+			k8sMock.EXPECT().Status().Return(statusWriter).Times(2)
+			conflict := apierrors.NewConflict(schema.GroupResource{Resource: "replicatedstatemachines"}, name, fmt.Errorf("conflict"))
+			gomock.InOrder(
+				statusWriter.EXPECT().
+					Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(conflict).Times(1),
+				statusWriter.EXPECT().
+					Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, rsm *workloads.ReplicatedStateMachine, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						Expect(rsm.Status.LeaderHistory).Should(HaveLen(1))
+						Expect(rsm.Status.LeaderHistory[0].OldLeader).Should(Equal(getPodName(name, 0)))
+						Expect(rsm.Status.LeaderHistory[0].NewLeader).Should(Equal(pod.Name))
+						return nil
+					}).Times(1),
+			)
+			k8sMock.EXPECT().
+				Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, pd *corev1.Pod, patch client.Patch, _ ...client.PatchOption) error {
+					Expect(pd.Labels[roleLabelKey]).Should(Equal(role.Name))
+					return nil
+				}).Times(1)
+			_, err := handleRoleChangedEvent(k8sMock, reqCtx, nil, event)
+			Expect(err).Should(Succeed())
+		})
 	})
 
 	Context("parseProbeEventMessage function", func() {
@@ -21,10 +21,12 @@ package rsm
 
 import (
 	"errors"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -43,6 +45,9 @@ type realUpdatePlan struct {
 	pods            []corev1.Pod
 	dag             *graph.DAG
 	podsToBeUpdated []*corev1.Pod
+	// maxUpdatedReplicas limits the number of pods in the update revision, nil means no limit.
+	maxUpdatedReplicas *int
+	updatedReplicas    int
 }
 
 var _ updatePlan = &realUpdatePlan{}
@@ -79,6 +84,11 @@ func (p *realUpdatePlan) planWalkFunc(vertex graph.Vertex) error {
 		}
 	}
 
+	// keep the pod in the current revision if the updated pods reach the limit
+	if p.maxUpdatedReplicas != nil && p.updatedReplicas+len(p.podsToBeUpdated) >= *p.maxUpdatedReplicas {
+		return ErrWait
+	}
+
 	// delete the pod to trigger associate StatefulSet to re-create it
 	p.podsToBeUpdated = append(p.podsToBeUpdated, pod)
 	return ErrStop
@@ -184,6 +194,7 @@ func (p *realUpdatePlan) buildSerialUpdatePlan() {
 
 func (p *realUpdatePlan) execute() ([]*corev1.Pod, error) {
 	p.build()
+	p.buildUpdatedReplicasLimit()
 	if err := p.dag.WalkBFS(p.planWalkFunc); err != ErrContinue && err != ErrWait && err != ErrStop {
 		return nil, err
 	}
@@ -191,6 +202,25 @@ func (p *realUpdatePlan) execute() ([]*corev1.Pod, error) {
 	return p.podsToBeUpdated, nil
 }
 
+// buildUpdatedReplicasLimit builds the limit of updated pods from the annotation of rsm
+func (p *realUpdatePlan) buildUpdatedReplicasLimit() {
+	value, ok := p.rsm.Annotations[constant.MaxUpdatedReplicasAnnotationKey]
+	if !ok {
+		return
+	}
+	maxUpdatedReplicas, err := strconv.Atoi(value)
+	if err != nil || maxUpdatedReplicas < 0 {
+		return
+	}
+	p.maxUpdatedReplicas = &maxUpdatedReplicas
+	p.updatedReplicas = 0
+	for i := range p.pods {
+		if intctrlutil.GetPodRevision(&p.pods[i]) == p.rsm.Status.UpdateRevision {
+			p.updatedReplicas++
+		}
+	}
+}
+
 func newUpdatePlan(rsm workloads.ReplicatedStateMachine, pods []corev1.Pod) updatePlan {
 	return &realUpdatePlan{
 		rsm:  rsm,
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 )
 
@@ -145,6 +146,28 @@ var _ = Describe("update plan test.", func() {
 			checkPlan(expectedPlan)
 		})
 
+		It("should stop updating when the updated pods reach the limit", func() {
+			By("build a serial plan with max updated replicas")
+			strategy := workloads.SerialUpdateStrategy
+			rsm.Spec.MemberUpdateStrategy = &strategy
+			rsm.Annotations = map[string]string{constant.MaxUpdatedReplicasAnnotationKey: "2"}
+			expectedPlan := [][]*corev1.Pod{
+				{pod4},
+				{pod2},
+				{},
+			}
+			checkPlan(expectedPlan)
+
+			By("build a parallel plan with max updated replicas")
+			resetPods()
+			strategy = workloads.ParallelUpdateStrategy
+			rsm.Spec.MemberUpdateStrategy = &strategy
+			plan := newUpdatePlan(*rsm, buildPodList())
+			podUpdateList, err := plan.execute()
+			Expect(err).Should(BeNil())
+			Expect(podUpdateList).Should(HaveLen(2))
+		})
+
 		It("should work well in a best effort parallel", func() {
 			By("build a best effort parallel plan")
 			strategy := workloads.BestEffortParallelUpdateStrategy
@@ -0,0 +1,289 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dperrors "github.com/apecloud/kubeblocks/pkg/dataprotection/errors"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// IsChainedBackupType returns true if the backup of the type depends on a
+// parent backup.
+func IsChainedBackupType(backupType dpv1alpha1.BackupType) bool {
+	return backupType == dpv1alpha1.BackupTypeIncremental ||
+		backupType == dpv1alpha1.BackupTypeDifferential
+}
+
+// ResolveParentBackup resolves the parent backup of the incremental or differential
+// backup. If spec.parentBackupName is specified, it will be used as the parent,
+// otherwise the latest valid parent will be selected from the backups of the same
+// backup policy. A nil parent means the backup starts a new backup chain.
+func (r *Request) ResolveParentBackup() error {
+	if r.ActionSet == nil || !IsChainedBackupType(r.ActionSet.Spec.BackupType) {
+		return nil
+	}
+
+	// the parent has been resolved when the backup is handled in new phase,
+	// just get it. The pending backup resolves its parent when it is started.
+	if r.Status.Phase != "" && r.Status.Phase != dpv1alpha1.BackupPhaseNew &&
+		r.Status.Phase != dpv1alpha1.BackupPhasePending {
+		if r.Status.ParentBackupName == "" {
+			return nil
+		}
+		parent, err := r.getBackup(r.Status.ParentBackupName)
+		if err != nil {
+			return err
+		}
+		r.ParentBackup = parent
+		return nil
+	}
+
+	if r.Spec.ParentBackupName != "" {
+		parent, err := r.getBackup(r.Spec.ParentBackupName)
+		if err != nil {
+			return err
+		}
+		if parent.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			return fmt.Errorf(`parent backup "%s" is not completed`, parent.Name)
+		}
+		r.setParentBackup(parent)
+		return nil
+	}
+
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(r.Ctx, backupList, client.InNamespace(r.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: r.Spec.BackupPolicyName}); err != nil {
+		return err
+	}
+	if running := getPrecedingRunningBackup(r.Backup, backupList.Items); running != nil {
+		return dperrors.NewParentBackupNotReady(running.Name)
+	}
+	r.setParentBackup(SelectParentBackup(r.Backup, r.ActionSet.Spec.BackupType,
+		r.getBackupRepoName(), r.getMaxChainLength(), backupList.Items))
+	return nil
+}
+
+func (r *Request) setParentBackup(parent *dpv1alpha1.Backup) {
+	r.ParentBackup = parent
+	if parent == nil {
+		r.Status.ParentBackupName = ""
+		r.Status.BaseBackupName = r.Name
+		return
+	}
+	r.Status.ParentBackupName = parent.Name
+	r.Status.BaseBackupName = getBaseBackupName(parent)
+}
+
+func (r *Request) getBackup(name string) (*dpv1alpha1.Backup, error) {
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Client.Get(r.Ctx, client.ObjectKey{Namespace: r.Namespace, Name: name}, backup); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+func (r *Request) getBackupRepoName() string {
+	if r.BackupRepo != nil {
+		return r.BackupRepo.Name
+	}
+	return ""
+}
+
+// getMaxChainLength gets the max chain length from the backup schedule that
+// creates the backup, zero means unlimited.
+func (r *Request) getMaxChainLength() int32 {
+	scheduleName := r.Labels[dptypes.BackupScheduleLabelKey]
+	if scheduleName == "" {
+		return 0
+	}
+	backupSchedule := &dpv1alpha1.BackupSchedule{}
+	if err := r.Client.Get(r.Ctx, client.ObjectKey{Namespace: r.Namespace, Name: scheduleName}, backupSchedule); err != nil {
+		r.Log.V(1).Info("failed to get backup schedule", "backupSchedule", scheduleName, "error", err.Error())
+		return 0
+	}
+	for _, sp := range backupSchedule.Spec.Schedules {
+		if sp.BackupMethod == r.Spec.BackupMethod && sp.MaxChainLength != nil {
+			return *sp.MaxChainLength
+		}
+	}
+	return 0
+}
+
+// buildParentBackupEnv builds the environment variables of the parent backup.
+func (r *Request) buildParentBackupEnv() []corev1.EnvVar {
+	if r.ActionSet == nil || !IsChainedBackupType(r.ActionSet.Spec.BackupType) {
+		return nil
+	}
+	envVars := []corev1.EnvVar{
+		{
+			Name:  dptypes.DPBaseBackupName,
+			Value: r.Status.BaseBackupName,
+		},
+	}
+	parent := r.ParentBackup
+	if parent == nil {
+		return envVars
+	}
+	var startTime, stopTime string
+	if t := parent.GetStartTime(); !t.IsZero() {
+		startTime = t.UTC().Format(time.RFC3339)
+	}
+	if t := parent.GetEndTime(); !t.IsZero() {
+		stopTime = t.UTC().Format(time.RFC3339)
+	}
+	var extras string
+	if len(parent.Status.Extras) > 0 {
+		if b, err := json.Marshal(parent.Status.Extras); err == nil {
+			extras = string(b)
+		}
+	}
+	return append(envVars,
+		corev1.EnvVar{Name: dptypes.DPParentBackupName, Value: parent.Name},
+		corev1.EnvVar{Name: dptypes.DPParentBackupBasePath, Value: parent.Status.Path},
+		corev1.EnvVar{Name: dptypes.DPParentBackupStartTime, Value: startTime},
+		corev1.EnvVar{Name: dptypes.DPParentBackupStopTime, Value: stopTime},
+		corev1.EnvVar{Name: dptypes.DPParentBackupExtras, Value: extras},
+	)
+}
+
+// SelectParentBackup selects the latest valid parent backup for the incremental
+// or differential backup from the backups of the same backup policy.
+// A valid parent backup is a completed backup stored in the same backup repo,
+// for incremental backup, it is a full backup or a backup of the same method,
+// for differential backup, it must be the base of a backup chain.
+// If the chain of the selected parent has reached maxChainLength, nil is returned
+// to start a new backup chain.
+func SelectParentBackup(backup *dpv1alpha1.Backup,
+	backupType dpv1alpha1.BackupType,
+	backupRepoName string,
+	maxChainLength int32,
+	backups []dpv1alpha1.Backup) *dpv1alpha1.Backup {
+	isBase := func(b *dpv1alpha1.Backup) bool {
+		return b.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeFull) ||
+			b.Status.ParentBackupName == ""
+	}
+	isValidParent := func(b *dpv1alpha1.Backup) bool {
+		if b.Name == backup.Name || !b.DeletionTimestamp.IsZero() ||
+			b.Status.Phase != dpv1alpha1.BackupPhaseCompleted ||
+			b.Status.BackupRepoName != backupRepoName {
+			return false
+		}
+		isFull := b.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeFull)
+		if !isFull && getBackupMethodName(b) != backup.Spec.BackupMethod {
+			return false
+		}
+		if backupType == dpv1alpha1.BackupTypeDifferential {
+			return isBase(b)
+		}
+		return true
+	}
+
+	var parent *dpv1alpha1.Backup
+	for i := range backups {
+		b := &backups[i]
+		if !isValidParent(b) {
+			continue
+		}
+		if parent == nil || compareBackupStopTime(parent, b) {
+			parent = b
+		}
+	}
+	if parent == nil || maxChainLength <= 0 {
+		return parent
+	}
+
+	// count the backups in the chain of the parent, including the base backup.
+	baseName := getBaseBackupName(parent)
+	chainLength := int32(1)
+	for i := range backups {
+		b := &backups[i]
+		if b.Name == baseName || b.Name == backup.Name || !b.DeletionTimestamp.IsZero() ||
+			b.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		if b.Status.BaseBackupName == baseName {
+			chainLength++
+		}
+	}
+	if chainLength >= maxChainLength {
+		return nil
+	}
+	return parent
+}
+
+// getPrecedingRunningBackup gets the backup of the same method that is created
+// before the backup and still running, the backup should wait for it to
+// complete to build the backup chain. Backups that are not running yet are
+// not waited for, as they may never be scheduled and would block the chain.
+func getPrecedingRunningBackup(backup *dpv1alpha1.Backup, backups []dpv1alpha1.Backup) *dpv1alpha1.Backup {
+	for i := range backups {
+		b := &backups[i]
+		if b.Name == backup.Name || b.Spec.BackupMethod != backup.Spec.BackupMethod ||
+			!b.DeletionTimestamp.IsZero() || IsReplica(b) {
+			continue
+		}
+		if b.Status.Phase != dpv1alpha1.BackupPhaseRunning {
+			continue
+		}
+		if b.CreationTimestamp.Before(&backup.CreationTimestamp) {
+			return b
+		}
+	}
+	return nil
+}
+
+// getBaseBackupName gets the base backup name of the backup chain that the
+// backup belongs to.
+func getBaseBackupName(backup *dpv1alpha1.Backup) string {
+	if backup.Status.BaseBackupName != "" {
+		return backup.Status.BaseBackupName
+	}
+	return backup.Name
+}
+
+func getBackupMethodName(backup *dpv1alpha1.Backup) string {
+	if backup.Status.BackupMethod != nil {
+		return backup.Status.BackupMethod.Name
+	}
+	return backup.Spec.BackupMethod
+}
+
+// compareBackupStopTime returns true if the backup b2 stops later than b1.
+func compareBackupStopTime(b1, b2 *dpv1alpha1.Backup) bool {
+	t1, t2 := b1.GetEndTime(), b2.GetEndTime()
+	if t1.IsZero() {
+		return !t2.IsZero() || b1.CreationTimestamp.Before(&b2.CreationTimestamp)
+	}
+	if t2.IsZero() {
+		return false
+	}
+	if t1.Equal(t2) {
+		return b1.CreationTimestamp.Before(&b2.CreationTimestamp)
+	}
+	return t1.Before(t2)
+}
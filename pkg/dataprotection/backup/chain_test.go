@@ -0,0 +1,132 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Backup Chain Test", func() {
+	now := time.Now()
+
+	Context("select parent backup", func() {
+		const (
+			repo     = "repo"
+			fullMeth = "full"
+			incrMeth = "incr"
+		)
+
+		newBackup := func(name, method string, backupType dpv1alpha1.BackupType, parent, base string,
+			phase dpv1alpha1.BackupPhase, stopAt time.Duration) dpv1alpha1.Backup {
+			return dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Labels:            map[string]string{dptypes.BackupTypeLabelKey: string(backupType)},
+					CreationTimestamp: metav1.Time{Time: now.Add(stopAt - time.Minute)},
+				},
+				Spec: dpv1alpha1.BackupSpec{BackupMethod: method},
+				Status: dpv1alpha1.BackupStatus{
+					Phase:               phase,
+					BackupRepoName:      repo,
+					ParentBackupName:    parent,
+					BaseBackupName:      base,
+					CompletionTimestamp: &metav1.Time{Time: now.Add(stopAt)},
+				},
+			}
+		}
+
+		var (
+			current   = newBackup("current", incrMeth, dpv1alpha1.BackupTypeIncremental, "", "", dpv1alpha1.BackupPhaseNew, 0)
+			full      = newBackup("full", fullMeth, dpv1alpha1.BackupTypeFull, "", "", dpv1alpha1.BackupPhaseCompleted, -3*time.Hour)
+			incr1     = newBackup("incr1", incrMeth, dpv1alpha1.BackupTypeIncremental, "full", "full", dpv1alpha1.BackupPhaseCompleted, -2*time.Hour)
+			incr2     = newBackup("incr2", incrMeth, dpv1alpha1.BackupTypeIncremental, "incr1", "full", dpv1alpha1.BackupPhaseCompleted, -time.Hour)
+			failed    = newBackup("failed", incrMeth, dpv1alpha1.BackupTypeIncremental, "incr2", "full", dpv1alpha1.BackupPhaseFailed, -time.Minute)
+			otherRepo = newBackup("other-repo", fullMeth, dpv1alpha1.BackupTypeFull, "", "", dpv1alpha1.BackupPhaseCompleted, -2*time.Minute)
+		)
+		otherRepo.Status.BackupRepoName = "other"
+
+		selectParent := func(backupType dpv1alpha1.BackupType, maxChainLength int32, backups ...dpv1alpha1.Backup) string {
+			parent := SelectParentBackup(&current, backupType, repo, maxChainLength, backups)
+			if parent == nil {
+				return ""
+			}
+			return parent.Name
+		}
+
+		It("should not select a parent if there is no valid one", func() {
+			Expect(selectParent(dpv1alpha1.BackupTypeIncremental, 0, current, failed, otherRepo)).Should(BeEmpty())
+		})
+
+		It("should select the latest backup of the chain for incremental backups", func() {
+			Expect(selectParent(dpv1alpha1.BackupTypeIncremental, 0, current, full, incr1, incr2, failed, otherRepo)).Should(Equal("incr2"))
+		})
+
+		It("should select the base backup for differential backups", func() {
+			Expect(selectParent(dpv1alpha1.BackupTypeDifferential, 0, current, full, incr1, incr2)).Should(Equal("full"))
+		})
+
+		It("should start a new chain once the chain reaches the max length", func() {
+			By("the chain is not full")
+			Expect(selectParent(dpv1alpha1.BackupTypeIncremental, 4, current, full, incr1, incr2)).Should(Equal("incr2"))
+
+			By("the chain reaches the max length")
+			Expect(selectParent(dpv1alpha1.BackupTypeIncremental, 3, current, full, incr1, incr2)).Should(BeEmpty())
+		})
+	})
+
+	Context("get preceding running backup", func() {
+		newBackup := func(name string, phase dpv1alpha1.BackupPhase, createdAt time.Duration) dpv1alpha1.Backup {
+			return dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.Time{Time: now.Add(createdAt)},
+				},
+				Spec:   dpv1alpha1.BackupSpec{BackupMethod: "incr"},
+				Status: dpv1alpha1.BackupStatus{Phase: phase},
+			}
+		}
+
+		It("should find the preceding running backup of the same method", func() {
+			current := newBackup("current", dpv1alpha1.BackupPhaseNew, 0)
+			completed := newBackup("completed", dpv1alpha1.BackupPhaseCompleted, -2*time.Hour)
+			later := newBackup("later", dpv1alpha1.BackupPhaseNew, time.Minute)
+			Expect(getPrecedingRunningBackup(&current, []dpv1alpha1.Backup{current, completed, later})).Should(BeNil())
+
+			By("the preceding backups that are never scheduled should not block the chain")
+			unscheduled := newBackup("unscheduled", "", -3*time.Hour)
+			pending := newBackup("pending", dpv1alpha1.BackupPhaseNew, -90*time.Minute)
+			Expect(getPrecedingRunningBackup(&current, []dpv1alpha1.Backup{current, unscheduled, pending, completed, later})).Should(BeNil())
+
+			By("a preceding running backup blocks the chain")
+			running := newBackup("running", dpv1alpha1.BackupPhaseRunning, -time.Hour)
+			preceding := getPrecedingRunningBackup(&current, []dpv1alpha1.Backup{current, completed, running, later})
+			Expect(preceding).ShouldNot(BeNil())
+			Expect(preceding.Name).Should(Equal("running"))
+		})
+	})
+})
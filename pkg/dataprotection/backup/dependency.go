@@ -0,0 +1,221 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// DependencyGraph describes the dependencies between the backups of the same
+// backup policy. A backup depends on another one if it can not be restored
+// without it, e.g. an incremental backup depends on its parent backup, and a
+// continuous backup depends on the latest full backup in its time range.
+// Only the alive backups, which are neither failed nor being deleted, are
+// included in the graph.
+type DependencyGraph struct {
+	backups    map[string]*dpv1alpha1.Backup
+	parents    map[string]string
+	dependents map[string][]string
+}
+
+// GetBackupDependencyGraph builds the dependency graph of the backups that
+// have the same backup policy as the specified backup.
+func GetBackupDependencyGraph(ctx context.Context, cli client.Client, backup *dpv1alpha1.Backup) (*DependencyGraph, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(ctx, backupList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backup.Spec.BackupPolicyName}); err != nil {
+		return nil, err
+	}
+	return NewDependencyGraph(backupList.Items), nil
+}
+
+// NewDependencyGraph builds the dependency graph of the backups.
+func NewDependencyGraph(backups []dpv1alpha1.Backup) *DependencyGraph {
+	g := &DependencyGraph{
+		backups:    map[string]*dpv1alpha1.Backup{},
+		parents:    map[string]string{},
+		dependents: map[string][]string{},
+	}
+	for i := range backups {
+		b := &backups[i]
+		if !IsBackupAlive(b) {
+			continue
+		}
+		g.backups[b.Name] = b
+	}
+
+	// the dependents of a backup that is not alive are also recorded, so that
+	// the backup being deleted can find its alive dependents.
+	addEdge := func(parent, dependent string) {
+		if g.Contains(parent) {
+			g.parents[dependent] = parent
+		}
+		g.dependents[parent] = append(g.dependents[parent], dependent)
+	}
+	for _, name := range g.sortedNames() {
+		b := g.backups[name]
+		if parent := dputils.GetParentBackupName(b); parent != "" {
+			addEdge(parent, name)
+			continue
+		}
+		if b.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+			if base := g.getBaseOfContinuousBackup(b); base != "" {
+				addEdge(base, name)
+			}
+		}
+	}
+	return g
+}
+
+// getBaseOfContinuousBackup gets the latest completed full backup that stops
+// in the time range of the continuous backup, it is the base to restore the
+// continuous backup to the latest time.
+func (g *DependencyGraph) getBaseOfContinuousBackup(backup *dpv1alpha1.Backup) string {
+	start, end := backup.GetStartTime(), backup.GetEndTime()
+	if start.IsZero() {
+		return ""
+	}
+	var base *dpv1alpha1.Backup
+	for _, name := range g.sortedNames() {
+		b := g.backups[name]
+		if b.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeFull) ||
+			b.Status.Phase != dpv1alpha1.BackupPhaseCompleted ||
+			b.Status.BackupRepoName != backup.Status.BackupRepoName {
+			continue
+		}
+		stopTime := b.GetEndTime()
+		if stopTime.IsZero() || stopTime.Before(start) || (!end.IsZero() && end.Before(stopTime)) {
+			continue
+		}
+		if base == nil || compareBackupStopTime(base, b) {
+			base = b
+		}
+	}
+	if base == nil {
+		return ""
+	}
+	return base.Name
+}
+
+// Contains returns true if the backup is in the graph.
+func (g *DependencyGraph) Contains(name string) bool {
+	_, ok := g.backups[name]
+	return ok
+}
+
+// GetDependents gets the backups that directly depend on the backup.
+func (g *DependencyGraph) GetDependents(name string) []*dpv1alpha1.Backup {
+	var dependents []*dpv1alpha1.Backup
+	for _, n := range g.dependents[name] {
+		dependents = append(dependents, g.backups[n])
+	}
+	return dependents
+}
+
+// GetChain gets all backups in the backup chain that the backup belongs to,
+// including the base backup and all backups depending on it directly or
+// indirectly. The base backup is the first one.
+func (g *DependencyGraph) GetChain(name string) []*dpv1alpha1.Backup {
+	if !g.Contains(name) {
+		return nil
+	}
+	root := name
+	visited := map[string]bool{root: true}
+	for {
+		parent, ok := g.parents[root]
+		if !ok || visited[parent] {
+			break
+		}
+		visited[parent] = true
+		root = parent
+	}
+
+	chain := []*dpv1alpha1.Backup{g.backups[root]}
+	seen := map[string]bool{root: true}
+	queue := []string{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, d := range g.dependents[n] {
+			if seen[d] {
+				continue
+			}
+			seen[d] = true
+			chain = append(chain, g.backups[d])
+			queue = append(queue, d)
+		}
+	}
+	return chain
+}
+
+func (g *DependencyGraph) sortedNames() []string {
+	names := make([]string, 0, len(g.backups))
+	for n := range g.backups {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsBackupAlive returns true if the backup is neither failed nor being deleted.
+func IsBackupAlive(backup *dpv1alpha1.Backup) bool {
+	return backup.DeletionTimestamp.IsZero() &&
+		backup.Status.Phase != dpv1alpha1.BackupPhaseFailed &&
+		backup.Status.Phase != dpv1alpha1.BackupPhaseDeleting
+}
+
+// IsBackupExpired returns true if the backup has expired.
+func IsBackupExpired(backup *dpv1alpha1.Backup, now time.Time) bool {
+	return backup.Status.Expiration != nil && !backup.Status.Expiration.After(now)
+}
+
+// SetRetainedCondition sets the retained condition of the backup, returns true
+// if the condition is changed.
+func SetRetainedCondition(backup *dpv1alpha1.Backup, status metav1.ConditionStatus, reason, message string) bool {
+	return setBackupCondition(backup, ConditionTypeRetained, status, reason, message)
+}
+
+// setBackupCondition sets the condition of the backup, returns true if the
+// condition is changed.
+func setBackupCondition(backup *dpv1alpha1.Backup, condType string,
+	status metav1.ConditionStatus, reason, message string) bool {
+	cond := meta.FindStatusCondition(backup.Status.Conditions, condType)
+	if cond != nil && cond.Status == status && cond.Reason == reason && cond.Message == message {
+		return false
+	}
+	meta.SetStatusCondition(&backup.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		ObservedGeneration: backup.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	return true
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Backup Dependency Graph Test", func() {
+	now := time.Now()
+
+	newBackup := func(name string, backupType dpv1alpha1.BackupType, parent string,
+		phase dpv1alpha1.BackupPhase, start, stop time.Duration) dpv1alpha1.Backup {
+		return dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{dptypes.BackupTypeLabelKey: string(backupType)},
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:               phase,
+				ParentBackupName:    parent,
+				StartTimestamp:      &metav1.Time{Time: now.Add(start)},
+				CompletionTimestamp: &metav1.Time{Time: now.Add(stop)},
+			},
+		}
+	}
+
+	names := func(backups []*dpv1alpha1.Backup) []string {
+		var res []string
+		for _, b := range backups {
+			res = append(res, b.Name)
+		}
+		return res
+	}
+
+	var (
+		full1 = newBackup("full1", dpv1alpha1.BackupTypeFull, "", dpv1alpha1.BackupPhaseCompleted, -10*time.Hour, -9*time.Hour)
+		incr1 = newBackup("incr1", dpv1alpha1.BackupTypeIncremental, "full1", dpv1alpha1.BackupPhaseCompleted, -8*time.Hour, -8*time.Hour)
+		incr2 = newBackup("incr2", dpv1alpha1.BackupTypeIncremental, "incr1", dpv1alpha1.BackupPhaseCompleted, -7*time.Hour, -7*time.Hour)
+	)
+
+	It("should build the chains of the incremental and continuous backups", func() {
+		failed := newBackup("failed", dpv1alpha1.BackupTypeIncremental, "incr2", dpv1alpha1.BackupPhaseFailed, -6*time.Hour, -6*time.Hour)
+		full2 := newBackup("full2", dpv1alpha1.BackupTypeFull, "", dpv1alpha1.BackupPhaseCompleted, -5*time.Hour, -4*time.Hour)
+		full3 := newBackup("full3", dpv1alpha1.BackupTypeFull, "", dpv1alpha1.BackupPhaseCompleted, -3*time.Hour, -2*time.Hour)
+		continuous := newBackup("continuous", dpv1alpha1.BackupTypeContinuous, "", dpv1alpha1.BackupPhaseRunning, -6*time.Hour, -time.Hour)
+		continuous.Status.CompletionTimestamp = nil
+		continuous.Status.TimeRange = &dpv1alpha1.BackupTimeRange{
+			Start: &metav1.Time{Time: now.Add(-6 * time.Hour)},
+			End:   &metav1.Time{Time: now.Add(-time.Hour)},
+		}
+
+		g := NewDependencyGraph([]dpv1alpha1.Backup{full1, incr1, incr2, failed, full2, full3, continuous})
+		Expect(g.Contains("failed")).Should(BeFalse())
+		Expect(names(g.GetDependents("full1"))).Should(Equal([]string{"incr1"}))
+		Expect(g.GetDependents("incr2")).Should(BeEmpty())
+		Expect(names(g.GetChain("incr1"))).Should(Equal([]string{"full1", "incr1", "incr2"}))
+
+		By("the continuous backup depends on the latest full backup in its time range")
+		Expect(g.GetDependents("full2")).Should(BeEmpty())
+		Expect(names(g.GetDependents("full3"))).Should(Equal([]string{"continuous"}))
+		Expect(names(g.GetChain("continuous"))).Should(Equal([]string{"full3", "continuous"}))
+		Expect(names(g.GetChain("full2"))).Should(Equal([]string{"full2"}))
+	})
+
+	It("should find the alive dependents of the backup being deleted", func() {
+		deleting := full1.DeepCopy()
+		deleting.Status.Phase = dpv1alpha1.BackupPhaseDeleting
+		g := NewDependencyGraph([]dpv1alpha1.Backup{*deleting, incr1, incr2})
+		Expect(g.Contains("full1")).Should(BeFalse())
+		Expect(names(g.GetDependents("full1"))).Should(Equal([]string{"incr1"}))
+		Expect(names(g.GetChain("incr2"))).Should(Equal([]string{"incr1", "incr2"}))
+	})
+})
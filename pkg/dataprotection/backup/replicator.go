@@ -0,0 +1,391 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// ReplicateActionName is the name of the action that copies the backup
+	// data to the backup repo of the replica.
+	ReplicateActionName = "replicate"
+
+	// replicaNameMaxLength is the max length of the replica backup name, the
+	// backup name is used as a label value.
+	replicaNameMaxLength = 63
+
+	// replicationDataMountPath is the path to store the backup data that is
+	// downloaded from the source backup repo.
+	replicationDataMountPath = "/replication-data"
+
+	// replicationEmptyDirMaxSize is the max size of the backup data that is
+	// downloaded into an emptyDir volume, the larger backup data is downloaded
+	// into an ephemeral persistent volume to avoid filling the node disk.
+	replicationEmptyDirMaxSize = "10Gi"
+
+	// sourceRepoVolumePrefix is the prefix of the volumes that are used to
+	// access the source backup repo, avoids the conflicts with the volumes of
+	// the destination backup repo.
+	sourceRepoVolumePrefix = "src-"
+)
+
+// IsReplica checks if the backup is a replica of another backup.
+func IsReplica(backup *dpv1alpha1.Backup) bool {
+	return backup.Labels[dptypes.ReplicatedFromLabelKey] != ""
+}
+
+// GetReplications gets the replications of the backup policy that should be
+// applied to the backup. Only the backups whose data is stored in a backup
+// repo without Kopia can be replicated, and the replica backup can not be
+// replicated again.
+func GetReplications(backupPolicy *dpv1alpha1.BackupPolicy,
+	backup *dpv1alpha1.Backup) []dpv1alpha1.BackupReplication {
+	if IsReplica(backup) || backup.Status.BackupRepoName == "" || backup.Status.KopiaRepoPath != "" {
+		return nil
+	}
+	var replications []dpv1alpha1.BackupReplication
+	for _, r := range backupPolicy.Spec.Replications {
+		if r.BackupRepoName == backup.Status.BackupRepoName {
+			continue
+		}
+		if len(r.BackupMethods) > 0 && !slices.Contains(r.BackupMethods, backup.Spec.BackupMethod) {
+			continue
+		}
+		replications = append(replications, r)
+	}
+	return replications
+}
+
+// GenerateReplicaName generates the name of the replica backup that is created
+// by the replication for the source backup.
+func GenerateReplicaName(sourceName, replicationName string) string {
+	name := fmt.Sprintf("%s-%s", sourceName, replicationName)
+	if len(name) <= replicaNameMaxLength {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	prefix := strings.TrimSuffix(sourceName[:replicaNameMaxLength-len(replicationName)-10], "-")
+	return fmt.Sprintf("%s-%s-%08x", prefix, replicationName, h.Sum32())
+}
+
+// BuildReplicaBackup builds the replica backup of the source backup for the
+// replication. The labels that make the source backup managed by the backup
+// schedule are not inherited, so the replica is only deleted according to its
+// own retention period.
+func BuildReplicaBackup(source *dpv1alpha1.Backup,
+	replication *dpv1alpha1.BackupReplication) *dpv1alpha1.Backup {
+	labels := map[string]string{}
+	for k, v := range source.Labels {
+		if k == dptypes.BackupScheduleLabelKey || k == dptypes.AutoBackupLabelKey {
+			continue
+		}
+		labels[k] = v
+	}
+	labels[dptypes.ReplicatedFromLabelKey] = source.Name
+	labels[dptypes.BackupReplicationLabelKey] = replication.Name
+
+	annotations := map[string]string{}
+	for k, v := range source.Annotations {
+		if k == dptypes.VerificationRequestAnnotationKey {
+			continue
+		}
+		annotations[k] = v
+	}
+
+	retentionPeriod := replication.RetentionPeriod
+	if retentionPeriod == "" {
+		retentionPeriod = source.Spec.RetentionPeriod
+	}
+	replica := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GenerateReplicaName(source.Name, replication.Name),
+			Namespace:   source.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: dpv1alpha1.BackupSpec{
+			BackupPolicyName: source.Spec.BackupPolicyName,
+			BackupMethod:     source.Spec.BackupMethod,
+			DeletionPolicy:   source.Spec.DeletionPolicy,
+			RetentionPeriod:  retentionPeriod,
+		},
+	}
+	if source.Status.ParentBackupName != "" {
+		replica.Spec.ParentBackupName = GenerateReplicaName(source.Status.ParentBackupName, replication.Name)
+	}
+	return replica
+}
+
+// Replicator copies the backup data of the source backup to the backup repo
+// of the replica backup.
+type Replicator struct {
+	ctrlutil.RequestCtx
+	Client client.Client
+}
+
+// GetSourceBackup gets the source backup of the replica backup.
+func (r *Replicator) GetSourceBackup(replica *dpv1alpha1.Backup) (*dpv1alpha1.Backup, error) {
+	source := &dpv1alpha1.Backup{}
+	key := client.ObjectKey{Namespace: replica.Namespace, Name: replica.Labels[dptypes.ReplicatedFromLabelKey]}
+	if err := r.Client.Get(r.Ctx, key, source); err != nil {
+		return nil, err
+	}
+	if source.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return nil, fmt.Errorf(`source backup "%s" is not completed`, source.Name)
+	}
+	return source, nil
+}
+
+// GetSourceBackupRepo gets the backup repo that stores the source backup.
+func (r *Replicator) GetSourceBackupRepo(source *dpv1alpha1.Backup) (*dpv1alpha1.BackupRepo, error) {
+	repo := &dpv1alpha1.BackupRepo{}
+	if err := r.Client.Get(r.Ctx, client.ObjectKey{Name: source.Status.BackupRepoName}, repo); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// PrepareEncryptionKeys copies the keys to decrypt the source backup and to
+// encrypt the replica into the namespace of the replica.
+func (r *Replicator) PrepareEncryptionKeys(replica, source *dpv1alpha1.Backup,
+	sourceRepo, repo *dpv1alpha1.BackupRepo) error {
+	if err := utils.EnsureEncryptionKeySecret(r.Ctx, r.Client, replica, sourceRepo, source.Status.Encryption); err != nil {
+		return err
+	}
+	return utils.EnsureEncryptionKeySecret(r.Ctx, r.Client, replica, repo, replica.Status.Encryption)
+}
+
+// BuildReplicaStatus builds the status of the replica backup from the source
+// backup. The backup data is copied to the same path in the backup repo of
+// the replica, so the replica can be restored in the same way as the source.
+func BuildReplicaStatus(replica, source *dpv1alpha1.Backup, repo *dpv1alpha1.BackupRepo) {
+	status := &replica.Status
+	status.FormatVersion = source.Status.FormatVersion
+	status.Path = source.Status.Path
+	status.Target = source.Status.Target
+	status.BackupMethod = source.Status.BackupMethod
+	status.TotalSize = source.Status.TotalSize
+	status.TimeRange = source.Status.TimeRange
+	status.Extras = source.Status.Extras
+	status.BackupRepoName = repo.Name
+	status.Encryption = BuildBackupEncryption(repo)
+	status.ParentBackupName = replica.Spec.ParentBackupName
+	status.BaseBackupName = ""
+	if source.Status.BaseBackupName != "" {
+		status.BaseBackupName = GenerateReplicaName(source.Status.BaseBackupName,
+			replica.Labels[dptypes.BackupReplicationLabelKey])
+	}
+	status.Actions = []dpv1alpha1.ActionStatus{
+		{
+			Name:       ReplicateActionName,
+			Phase:      dpv1alpha1.ActionPhaseNew,
+			ActionType: dpv1alpha1.ActionTypeJob,
+		},
+	}
+}
+
+// BuildReplicateAction builds the job action that copies the backup data from
+// the backup repo of the source backup to the backup repo of the replica.
+func (r *Replicator) BuildReplicateAction(replica, source *dpv1alpha1.Backup,
+	sourceRepo, repo *dpv1alpha1.BackupRepo) action.Action {
+	return &action.JobAction{
+		Name:       ReplicateActionName,
+		ObjectMeta: *buildBackupJobObjMeta(replica, ReplicateActionName),
+		Owner:      replica,
+		PodSpec:    BuildReplicationPodSpec(replica, source, sourceRepo, repo),
+	}
+}
+
+// BuildReplicationPodSpec builds the pod spec of the replication job. The init
+// container downloads the backup data from the source backup repo into the
+// data volume, and the container uploads it to the backup repo of the
+// replica. The backup data is decrypted by the key of the source backup, and
+// encrypted by the current key of the destination backup repo.
+func BuildReplicationPodSpec(replica, source *dpv1alpha1.Backup,
+	sourceRepo, repo *dpv1alpha1.BackupRepo) *corev1.PodSpec {
+	dataVolume := buildReplicationDataVolume(source)
+	dataVolumeMount := corev1.VolumeMount{
+		Name:      dataVolume.Name,
+		MountPath: replicationDataMountPath,
+	}
+	newContainer := func(name, script string) corev1.Container {
+		runAsUser := int64(0)
+		container := corev1.Container{
+			Name:            name,
+			Command:         []string{"sh", "-c"},
+			Args:            []string{script},
+			Image:           viper.GetString(constant.KBToolsImage),
+			ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+			VolumeMounts:    []corev1.VolumeMount{dataVolumeMount},
+			Env: []corev1.EnvVar{
+				{Name: dptypes.DPBackupBasePath, Value: source.Status.Path},
+				{Name: dptypes.DPBackupName, Value: replica.Name},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolptr.False(),
+				RunAsUser:                &runAsUser,
+			},
+		}
+		ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+		return container
+	}
+
+	download := &corev1.PodSpec{
+		Containers: []corev1.Container{newContainer("download", buildDownloadBackupDataScript())},
+	}
+	utils.InjectDatasafed(download, sourceRepo, RepoVolumeMountPath, "")
+	utils.InjectDatasafedEncryption(download, utils.GetEncryptionKeySecretName(replica.Name), sourceRepo, source.Status.Encryption)
+	renameVolumes(download, sourceRepoVolumePrefix)
+
+	upload := &corev1.PodSpec{
+		Containers: []corev1.Container{newContainer("upload", buildUploadBackupDataScript())},
+	}
+	utils.InjectDatasafed(upload, repo, RepoVolumeMountPath, "")
+	utils.InjectDatasafedEncryption(upload, utils.GetEncryptionKeySecretName(replica.Name), repo, replica.Status.Encryption)
+
+	podSpec := &corev1.PodSpec{
+		RestartPolicy:  corev1.RestartPolicyNever,
+		InitContainers: append(append(download.InitContainers, download.Containers...), upload.InitContainers...),
+		Containers:     upload.Containers,
+		Volumes:        append(append(download.Volumes, upload.Volumes...), dataVolume),
+	}
+	_ = utils.AddTolerations(podSpec)
+	return podSpec
+}
+
+// buildReplicationDataVolume builds the volume to store the downloaded backup
+// data. The size of the volume is limited by the total size of the backup, if
+// the backup is too large, an ephemeral persistent volume is used instead of
+// the emptyDir volume, so the node disk is not filled by the backup data.
+func buildReplicationDataVolume(source *dpv1alpha1.Backup) corev1.Volume {
+	volume := corev1.Volume{Name: "dp-replication-data"}
+	maxSize := resource.MustParse(replicationEmptyDirMaxSize)
+	size, err := resource.ParseQuantity(source.Status.TotalSize)
+	if err != nil || size.IsZero() {
+		// the size of the backup is unknown, limit it to the max size of the emptyDir.
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{SizeLimit: &maxSize}
+		return volume
+	}
+	// reserve extra space for the file system overhead.
+	size = *resource.NewQuantity(size.Value()+size.Value()/10, resource.BinarySI)
+	if size.Cmp(maxSize) <= 0 {
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{SizeLimit: &size}
+		return volume
+	}
+	volume.Ephemeral = &corev1.EphemeralVolumeSource{
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+				},
+			},
+		},
+	}
+	return volume
+}
+
+// renameVolumes adds the prefix to the names of the volumes and the init
+// containers of the pod spec, the mount of the replication data is kept.
+func renameVolumes(podSpec *corev1.PodSpec, prefix string) {
+	for i := range podSpec.Volumes {
+		podSpec.Volumes[i].Name = prefix + podSpec.Volumes[i].Name
+	}
+	renameMounts := func(containers []corev1.Container) {
+		for i := range containers {
+			for j := range containers[i].VolumeMounts {
+				if containers[i].VolumeMounts[j].MountPath == replicationDataMountPath {
+					continue
+				}
+				containers[i].VolumeMounts[j].Name = prefix + containers[i].VolumeMounts[j].Name
+			}
+		}
+	}
+	renameMounts(podSpec.InitContainers)
+	renameMounts(podSpec.Containers)
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Name = prefix + podSpec.InitContainers[i].Name
+	}
+}
+
+func buildDownloadBackupDataScript() string {
+	return fmt.Sprintf(`
+set -e
+export PATH="$PATH:$%s"
+dataPath="%s"
+echo "downloading backup files in ${%s}"
+datasafed list -r -f "${%s}" | while read -r file; do
+	if [ -z "${file}" ]; then
+		continue
+	fi
+	mkdir -p "$(dirname "${dataPath}${file}")"
+	datasafed pull "${file}" "${dataPath}${file}"
+done
+`, dptypes.DPDatasafedBinPath, replicationDataMountPath, dptypes.DPBackupBasePath, dptypes.DPBackupBasePath)
+}
+
+func buildUploadBackupDataScript() string {
+	return fmt.Sprintf(`
+set -e
+export PATH="$PATH:$%s"
+dataPath="%s"
+echo "uploading backup files to ${%s}"
+cd "${dataPath}"
+find . -type f | while read -r file; do
+	file="${file#.}"
+	datasafed push "${dataPath}${file}" "${file}"
+done
+`, dptypes.DPDatasafedBinPath, replicationDataMountPath, dptypes.DPBackupBasePath)
+}
+
+// BuildBackupEncryption builds the encryption of the backup whose data is
+// stored in the backup repo, it returns nil if the backup repo is not encrypted.
+func BuildBackupEncryption(repo *dpv1alpha1.BackupRepo) *dpv1alpha1.BackupEncryption {
+	if repo == nil || repo.Spec.Encryption == nil {
+		return nil
+	}
+	algorithm := repo.Spec.Encryption.Algorithm
+	if algorithm == "" {
+		algorithm = dpv1alpha1.EncryptionAlgorithmAES256CFB
+	}
+	return &dpv1alpha1.BackupEncryption{
+		Algorithm: algorithm,
+		KeyID:     repo.Spec.Encryption.CurrentKeyID,
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Backup Replicator Test", func() {
+	Context("build replica backup", func() {
+		var (
+			source       *dpv1alpha1.Backup
+			backupPolicy *dpv1alpha1.BackupPolicy
+		)
+
+		BeforeEach(func() {
+			source = &dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "incr2",
+					Namespace: "default",
+					Labels: map[string]string{
+						dptypes.BackupPolicyLabelKey:   "policy",
+						dptypes.BackupScheduleLabelKey: "schedule",
+					},
+				},
+				Spec: dpv1alpha1.BackupSpec{
+					BackupPolicyName: "policy",
+					BackupMethod:     "incremental",
+					RetentionPeriod:  "7d",
+				},
+				Status: dpv1alpha1.BackupStatus{
+					Phase:            dpv1alpha1.BackupPhaseCompleted,
+					BackupRepoName:   "local",
+					ParentBackupName: "incr1",
+					BaseBackupName:   "full",
+					Path:             "/default/incr2",
+				},
+			}
+			backupPolicy = &dpv1alpha1.BackupPolicy{
+				Spec: dpv1alpha1.BackupPolicySpec{
+					Replications: []dpv1alpha1.BackupReplication{
+						{Name: "dr", BackupRepoName: "remote", RetentionPeriod: "30d"},
+						{Name: "local", BackupRepoName: "local"},
+						{Name: "full", BackupRepoName: "remote", BackupMethods: []string{"full"}},
+					},
+				},
+			}
+		})
+
+		It("should build the replica to other backup repos", func() {
+			By("only the replications to other backup repos for the backup method are applied")
+			replications := GetReplications(backupPolicy, source)
+			Expect(replications).Should(HaveLen(1))
+			Expect(replications[0].Name).Should(Equal("dr"))
+
+			replica := BuildReplicaBackup(source, &replications[0])
+			Expect(replica.Name).Should(Equal("incr2-dr"))
+			Expect(IsReplica(replica)).Should(BeTrue())
+			Expect(replica.Labels[dptypes.ReplicatedFromLabelKey]).Should(Equal("incr2"))
+			Expect(replica.Labels[dptypes.BackupPolicyLabelKey]).Should(Equal("policy"))
+			Expect(replica.Labels[dptypes.BackupScheduleLabelKey]).Should(BeEmpty())
+			Expect(replica.Spec.RetentionPeriod).Should(Equal(dpv1alpha1.RetentionPeriod("30d")))
+			Expect(replica.Spec.ParentBackupName).Should(Equal("incr1-dr"))
+
+			By("the replica is not replicated again")
+			Expect(GetReplications(backupPolicy, replica)).Should(BeEmpty())
+
+			By("the status follows the source backup in the target backup repo")
+			repo := &dpv1alpha1.BackupRepo{ObjectMeta: metav1.ObjectMeta{Name: "remote"}}
+			BuildReplicaStatus(replica, source, repo)
+			Expect(replica.Status.Path).Should(Equal("/default/incr2"))
+			Expect(replica.Status.BackupRepoName).Should(Equal("remote"))
+			Expect(replica.Status.ParentBackupName).Should(Equal("incr1-dr"))
+			Expect(replica.Status.BaseBackupName).Should(Equal("full-dr"))
+			Expect(replica.Status.Encryption).Should(BeNil())
+		})
+
+		It("should truncate the replica name for long backup names", func() {
+			name := GenerateReplicaName(strings.Repeat("a", 63), "dr")
+			Expect(len(name)).Should(BeNumerically("<=", replicaNameMaxLength))
+			Expect(name[:len(name)-9]).Should(HaveSuffix("-dr"))
+		})
+	})
+
+	Context("build replication data volume", func() {
+		newSource := func(totalSize string) *dpv1alpha1.Backup {
+			return &dpv1alpha1.Backup{Status: dpv1alpha1.BackupStatus{TotalSize: totalSize}}
+		}
+
+		It("should limit the size of the emptyDir if the total size is unknown", func() {
+			volume := buildReplicationDataVolume(newSource(""))
+			Expect(volume.EmptyDir).ShouldNot(BeNil())
+			Expect(volume.EmptyDir.SizeLimit.String()).Should(Equal(replicationEmptyDirMaxSize))
+		})
+
+		It("should download the small backup into the emptyDir with the size limit", func() {
+			volume := buildReplicationDataVolume(newSource("1Gi"))
+			Expect(volume.EmptyDir).ShouldNot(BeNil())
+			Expect(volume.EmptyDir.SizeLimit.Value()).Should(Equal(int64(1024 * 1024 * 1024 * 11 / 10)))
+		})
+
+		It("should download the large backup into an ephemeral persistent volume", func() {
+			volume := buildReplicationDataVolume(newSource("100Gi"))
+			Expect(volume.EmptyDir).Should(BeNil())
+			Expect(volume.Ephemeral).ShouldNot(BeNil())
+			request := volume.Ephemeral.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
+			Expect(request.String()).Should(Equal("110Gi"))
+		})
+	})
+})
@@ -61,6 +61,8 @@ type Request struct {
 	BackupRepoPVC    *corev1.PersistentVolumeClaim
 	BackupRepo       *dpv1alpha1.BackupRepo
 	ToolConfigSecret *corev1.Secret
+	// ParentBackup is the parent backup of the incremental or differential backup.
+	ParentBackup *dpv1alpha1.Backup
 }
 
 func (r *Request) GetBackupType() string {
@@ -182,7 +184,8 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 		r.InjectSyncProgressContainer(podSpec, backupDataAct.SyncProgress, r.buildSyncProgressCommand())
 	}
 
-	if r.ActionSet.Spec.BackupType == dpv1alpha1.BackupTypeFull {
+	switch r.ActionSet.Spec.BackupType {
+	case dpv1alpha1.BackupTypeFull, dpv1alpha1.BackupTypeIncremental, dpv1alpha1.BackupTypeDifferential:
 		return &action.JobAction{
 			Name:         name,
 			ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
@@ -190,8 +193,9 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 			PodSpec:      podSpec,
 			BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
 		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported backup type %s", r.ActionSet.Spec.BackupType)
 	}
-	return nil, fmt.Errorf("unsupported backup type %s", r.ActionSet.Spec.BackupType)
 }
 
 func (r *Request) buildCreateVolumeSnapshotAction(targetPod *corev1.Pod, name string) (action.Action, error) {
@@ -332,6 +336,7 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 			},
 		}
 		envVars = append(envVars, utils.BuildEnvByCredential(targetPod, r.BackupPolicy.Spec.Target.ConnectionCredential)...)
+		envVars = append(envVars, r.buildParentBackupEnv()...)
 		if r.ActionSet != nil {
 			envVars = append(envVars, r.ActionSet.Spec.Env...)
 		}
@@ -430,6 +435,7 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 	}
 
 	utils.InjectDatasafed(podSpec, r.BackupRepo, RepoVolumeMountPath, r.Status.KopiaRepoPath)
+	utils.InjectDatasafedEncryption(podSpec, utils.GetEncryptionKeySecretName(r.Backup.Name), r.BackupRepo, r.Status.Encryption)
 	return podSpec, nil
 }
 
@@ -0,0 +1,155 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// GetRetentionPolicy gets the retention policy of the schedule policy for the
+// backup method, it returns nil if the retention policy is not set.
+func GetRetentionPolicy(backupSchedule *dpv1alpha1.BackupSchedule, backupMethod string) *dpv1alpha1.BackupRetentionPolicy {
+	for i := range backupSchedule.Spec.Schedules {
+		if backupSchedule.Spec.Schedules[i].BackupMethod == backupMethod {
+			return backupSchedule.Spec.Schedules[i].RetentionPolicy
+		}
+	}
+	return nil
+}
+
+// GetRetentionCandidates lists the backups of the backup policy and backup
+// method that the retention policy is evaluated across.
+func GetRetentionCandidates(ctx context.Context, cli client.Client,
+	namespace, backupPolicyName, backupMethod string) ([]dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(ctx, backupList, client.InNamespace(namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backupPolicyName}); err != nil {
+		return nil, err
+	}
+	var candidates []dpv1alpha1.Backup
+	for i := range backupList.Items {
+		b := &backupList.Items[i]
+		if b.Spec.BackupMethod == backupMethod && IsRetentionCandidate(b) {
+			candidates = append(candidates, *b)
+		}
+	}
+	return candidates, nil
+}
+
+// IsRetentionCandidate returns true if the backup is evaluated by the retention
+// policy. The replicated backups and the backups that will expire by their
+// retention period are not evaluated.
+func IsRetentionCandidate(backup *dpv1alpha1.Backup) bool {
+	return backup.DeletionTimestamp.IsZero() &&
+		backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted &&
+		backup.Status.Expiration == nil &&
+		backup.Labels[dptypes.ReplicatedFromLabelKey] == ""
+}
+
+// EvaluateRetentionPolicy evaluates the retention policy across the backups,
+// and returns the sorted names of the backups that are not kept by the policy.
+// All backups are kept if none of the rules is set.
+func EvaluateRetentionPolicy(policy *dpv1alpha1.BackupRetentionPolicy, backups []dpv1alpha1.Backup) []string {
+	if policy == nil {
+		return nil
+	}
+	sorted := make([]*dpv1alpha1.Backup, len(backups))
+	for i := range backups {
+		sorted[i] = &backups[i]
+	}
+	// the latest backup first
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getRetentionTime(sorted[i]).After(getRetentionTime(sorted[j]))
+	})
+
+	hasRule := false
+	kept := map[string]bool{}
+	if policy.KeepLast != nil {
+		hasRule = true
+		for i := 0; i < len(sorted) && i < int(*policy.KeepLast); i++ {
+			kept[sorted[i].Name] = true
+		}
+	}
+
+	// the latest backup of each period is kept for the latest periods
+	rules := []struct {
+		count  *int32
+		period func(t time.Time) string
+	}{
+		{policy.KeepDaily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{policy.KeepWeekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{policy.KeepMonthly, func(t time.Time) string { return t.Format("2006-01") }},
+		{policy.KeepYearly, func(t time.Time) string { return t.Format("2006") }},
+	}
+	for _, rule := range rules {
+		if rule.count == nil {
+			continue
+		}
+		hasRule = true
+		var (
+			count      int32
+			lastPeriod string
+		)
+		for _, b := range sorted {
+			if count >= *rule.count {
+				break
+			}
+			period := rule.period(getRetentionTime(b).UTC())
+			if period == lastPeriod {
+				continue
+			}
+			lastPeriod = period
+			count++
+			kept[b.Name] = true
+		}
+	}
+	if !hasRule {
+		return nil
+	}
+
+	var names []string
+	for _, b := range sorted {
+		if !kept[b.Name] {
+			names = append(names, b.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getRetentionTime gets the time of the backup data, which decides the period
+// that the backup belongs to.
+func getRetentionTime(backup *dpv1alpha1.Backup) time.Time {
+	if t := backup.GetEndTime(); t != nil {
+		return t.Time
+	}
+	return backup.CreationTimestamp.Time
+}
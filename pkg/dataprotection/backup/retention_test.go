@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Backup Retention Test", func() {
+	Context("evaluate retention policy", func() {
+		// hourly backups of 60 days, the latest one is taken on Sunday, 2024-03-31.
+		latest := time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)
+		var backups []dpv1alpha1.Backup
+		for i := 0; i < 60*24; i++ {
+			backups = append(backups, dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("backup-%04d", i)},
+				Status: dpv1alpha1.BackupStatus{
+					Phase:               dpv1alpha1.BackupPhaseCompleted,
+					CompletionTimestamp: &metav1.Time{Time: latest.Add(-time.Duration(i) * time.Hour)},
+				},
+			})
+		}
+
+		kept := func(toDelete []string) []string {
+			deleted := map[string]bool{}
+			for _, name := range toDelete {
+				deleted[name] = true
+			}
+			var names []string
+			for _, b := range backups {
+				if !deleted[b.Name] {
+					names = append(names, b.Status.CompletionTimestamp.Format("01-02T15"))
+				}
+			}
+			return names
+		}
+
+		It("should keep the backups by grandfather-father-son rules", func() {
+			policy := &dpv1alpha1.BackupRetentionPolicy{
+				KeepLast:    pointer.Int32(3),
+				KeepDaily:   pointer.Int32(7),
+				KeepWeekly:  pointer.Int32(4),
+				KeepMonthly: pointer.Int32(2),
+			}
+			Expect(kept(EvaluateRetentionPolicy(policy, backups))).Should(Equal([]string{
+				// the latest 3 backups
+				"03-31T23", "03-31T22", "03-31T21",
+				// the latest backups of the latest 7 days
+				"03-30T23", "03-29T23", "03-28T23", "03-27T23", "03-26T23", "03-25T23",
+				// the latest backups of the latest 4 weeks
+				"03-24T23", "03-17T23", "03-10T23",
+				// the latest backups of the latest 2 months
+				"02-29T23",
+			}))
+		})
+
+		It("should keep the latest backup of each year", func() {
+			policy := &dpv1alpha1.BackupRetentionPolicy{KeepYearly: pointer.Int32(5)}
+			Expect(kept(EvaluateRetentionPolicy(policy, backups))).Should(Equal([]string{"03-31T23"}))
+		})
+
+		It("should keep all the backups without rules or in dry run", func() {
+			Expect(EvaluateRetentionPolicy(nil, backups)).Should(BeEmpty())
+			Expect(EvaluateRetentionPolicy(&dpv1alpha1.BackupRetentionPolicy{DryRun: true}, backups)).Should(BeEmpty())
+		})
+
+		It("should keep none if the rules keep nothing", func() {
+			policy := &dpv1alpha1.BackupRetentionPolicy{KeepLast: pointer.Int32(0)}
+			Expect(EvaluateRetentionPolicy(policy, backups)).Should(HaveLen(len(backups)))
+		})
+	})
+
+	Context("retention candidate", func() {
+		newBackup := func() *dpv1alpha1.Backup {
+			return &dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup", Labels: map[string]string{}},
+				Status:     dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseCompleted},
+			}
+		}
+
+		It("should only take the completed backups without expiration", func() {
+			Expect(IsRetentionCandidate(newBackup())).Should(BeTrue())
+
+			running := newBackup()
+			running.Status.Phase = dpv1alpha1.BackupPhaseRunning
+			Expect(IsRetentionCandidate(running)).Should(BeFalse())
+
+			expiring := newBackup()
+			expiring.Status.Expiration = &metav1.Time{Time: time.Now()}
+			Expect(IsRetentionCandidate(expiring)).Should(BeFalse())
+		})
+
+		It("should not take the replicas", func() {
+			replica := newBackup()
+			replica.Labels[dptypes.ReplicatedFromLabelKey] = "source"
+			Expect(IsRetentionCandidate(replica)).Should(BeFalse())
+		})
+	})
+
+	Context("get retention policy", func() {
+		It("should get the policy of the backup method", func() {
+			policy := &dpv1alpha1.BackupRetentionPolicy{KeepLast: pointer.Int32(1)}
+			backupSchedule := &dpv1alpha1.BackupSchedule{
+				Spec: dpv1alpha1.BackupScheduleSpec{
+					Schedules: []dpv1alpha1.SchedulePolicy{
+						{BackupMethod: "volume-snapshot"},
+						{BackupMethod: "xtrabackup", RetentionPolicy: policy},
+					},
+				},
+			}
+			Expect(GetRetentionPolicy(backupSchedule, "xtrabackup")).Should(Equal(policy))
+			Expect(GetRetentionPolicy(backupSchedule, "volume-snapshot")).Should(BeNil())
+			Expect(GetRetentionPolicy(backupSchedule, "unknown")).Should(BeNil())
+		})
+	})
+})
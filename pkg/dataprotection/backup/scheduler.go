@@ -21,7 +21,9 @@ package backup
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,8 +46,16 @@ type Scheduler struct {
 	Scheme         *k8sruntime.Scheme
 	BackupSchedule *dpv1alpha1.BackupSchedule
 	BackupPolicy   *dpv1alpha1.BackupPolicy
+
+	// NextVerificationTime is the earliest next verification schedule time
+	// of the schedule policies, it is set by Schedule.
+	NextVerificationTime *time.Time
 }
 
+// verificationRequestTimeLayout is the layout of the verification request
+// annotation value.
+const verificationRequestTimeLayout = "20060102150405"
+
 func (s *Scheduler) Schedule() error {
 	if err := s.validate(); err != nil {
 		return err
@@ -98,7 +108,19 @@ func (s *Scheduler) handleSchedulePolicy(index int) error {
 	}
 
 	// create/delete/patch cronjob workload
-	return s.reconcileCronJob(schedulePolicy)
+	if err := s.reconcileCronJob(schedulePolicy); err != nil {
+		return err
+	}
+
+	// request the verification of the latest completed backup
+	nextTime, err := s.reconcileVerification(schedulePolicy)
+	if err != nil {
+		return err
+	}
+	if nextTime != nil && (s.NextVerificationTime == nil || nextTime.Before(*s.NextVerificationTime)) {
+		s.NextVerificationTime = nextTime
+	}
+	return nil
 }
 
 // buildCronJob builds cronjob from backup schedule.
@@ -160,6 +182,12 @@ func (s *Scheduler) buildCronJob(
 }
 
 func (s *Scheduler) buildPodSpec(schedulePolicy *dpv1alpha1.SchedulePolicy) (*corev1.PodSpec, error) {
+	// the backups are deleted by the retention policy rather than expiring
+	// after the retention period if the retention policy is set.
+	retentionPeriod := schedulePolicy.RetentionPeriod
+	if schedulePolicy.RetentionPolicy != nil {
+		retentionPeriod = ""
+	}
 	// TODO(ldm): add backup deletionPolicy
 	createBackupCmd := fmt.Sprintf(`
 kubectl create -f - <<EOF
@@ -169,6 +197,7 @@ metadata:
   labels:
     dataprotection.kubeblocks.io/autobackup: "true"
     dataprotection.kubeblocks.io/backup-schedule: "%s"
+    dataprotection.kubeblocks.io/backup-method: "%s"
   name: %s
   namespace: %s
 spec:
@@ -176,9 +205,9 @@ spec:
   backupMethod: %s
   retentionPeriod: %s
 EOF
-`, s.BackupSchedule.Name, s.generateBackupName(), s.BackupSchedule.Namespace,
+`, s.BackupSchedule.Name, schedulePolicy.BackupMethod, s.generateBackupName(), s.BackupSchedule.Namespace,
 		s.BackupPolicy.Name, schedulePolicy.BackupMethod,
-		schedulePolicy.RetentionPeriod)
+		retentionPeriod)
 
 	container := corev1.Container{
 		Name:            "backup-schedule",
@@ -254,6 +283,64 @@ func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy)
 	return s.Client.Patch(s.Ctx, cronJob, patch)
 }
 
+// reconcileVerification requests the verification of the latest completed
+// backup of the backup method when the verification schedule is met, and
+// returns the next verification schedule time.
+func (s *Scheduler) reconcileVerification(schedulePolicy *dpv1alpha1.SchedulePolicy) (*time.Time, error) {
+	verification := schedulePolicy.Verification
+	if !boolptr.IsSetToTrue(schedulePolicy.Enabled) ||
+		verification == nil || !boolptr.IsSetToTrue(verification.Enabled) {
+		return nil, nil
+	}
+	schedule, err := cron.ParseStandard(verification.CronExpression)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("invalid verification cron expression %q of backup method %s: %s",
+			verification.CronExpression, schedulePolicy.BackupMethod, err.Error()))
+	}
+
+	backupList := &dpv1alpha1.BackupList{}
+	if err = s.Client.List(s.Ctx, backupList,
+		client.InNamespace(s.BackupSchedule.Namespace),
+		client.MatchingLabels{
+			dptypes.BackupScheduleLabelKey: s.BackupSchedule.Name,
+			dptypes.BackupMethodLabelKey:   schedulePolicy.BackupMethod,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// only the most recent schedule time that is not met is requested,
+	// the earlier missed schedules are not made up.
+	now := time.Now().UTC()
+	lastRequestTime := s.BackupSchedule.CreationTimestamp.Time
+	var latestBackup *dpv1alpha1.Backup
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		requestTime, parseErr := time.Parse(verificationRequestTimeLayout, backup.Annotations[dptypes.VerificationRequestAnnotationKey])
+		if parseErr == nil && requestTime.After(lastRequestTime) {
+			lastRequestTime = requestTime
+		}
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Status.CompletionTimestamp == nil {
+			continue
+		}
+		if latestBackup == nil || latestBackup.Status.CompletionTimestamp.Before(backup.Status.CompletionTimestamp) {
+			latestBackup = backup
+		}
+	}
+	if latestBackup != nil && !schedule.Next(lastRequestTime.UTC()).After(now) {
+		patch := client.MergeFrom(latestBackup.DeepCopy())
+		if latestBackup.Annotations == nil {
+			latestBackup.Annotations = map[string]string{}
+		}
+		latestBackup.Annotations[dptypes.VerificationRequestAnnotationKey] = now.Format(verificationRequestTimeLayout)
+		if err = s.Client.Patch(s.Ctx, latestBackup, patch); err != nil {
+			return nil, err
+		}
+	}
+	nextTime := schedule.Next(now)
+	return &nextTime, nil
+}
+
 func (s *Scheduler) generateBackupName() string {
 	target := s.BackupPolicy.Spec.Target
 
@@ -20,14 +20,19 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package backup
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
@@ -57,6 +62,8 @@ var _ = Describe("Scheduler Test", func() {
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupScheduleSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.CronJobSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ActionSetSignature, true, ml)
 	}
 
@@ -110,6 +117,37 @@ var _ = Describe("Scheduler Test", func() {
 				}
 				Expect(scheduler.Schedule()).ShouldNot(Succeed())
 			})
+
+			It("should request the verification of the latest completed backup", func() {
+				By("creating a completed backup of the schedule")
+				backup := testdp.NewFakeBackup(&testCtx, func(backup *dpv1alpha1.Backup) {
+					if backup.Labels == nil {
+						backup.Labels = map[string]string{}
+					}
+					backup.Labels[dptypes.BackupScheduleLabelKey] = backupSchedule.Name
+					backup.Labels[dptypes.BackupMethodLabelKey] = testdp.BackupMethodName
+				})
+				Expect(testapps.ChangeObjStatus(&testCtx, backup, func() {
+					backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+					backup.Status.CompletionTimestamp = &metav1.Time{Time: time.Now().UTC()}
+				})).Should(Succeed())
+
+				By("enabling the verification that is due")
+				backupSchedule.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+				backupSchedule.Spec.Schedules[0].Enabled = boolptr.True()
+				backupSchedule.Spec.Schedules[0].Verification = &dpv1alpha1.BackupVerification{
+					Enabled:        boolptr.True(),
+					CronExpression: "0 * * * *",
+				}
+				scheduler.BackupSchedule = backupSchedule
+				scheduler.BackupPolicy = backupPolicy
+				Expect(scheduler.Schedule()).Should(Succeed())
+				Expect(scheduler.NextVerificationTime).ShouldNot(BeNil())
+				Expect(scheduler.NextVerificationTime.After(time.Now())).Should(BeTrue())
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Annotations).Should(HaveKey(dptypes.VerificationRequestAnnotationKey))
+				})).Should(Succeed())
+			})
 		})
 	})
 })
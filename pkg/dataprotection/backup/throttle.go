@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// GetMaxConcurrentBackups gets the max concurrent backups of the backup repo,
+// zero means unlimited.
+func GetMaxConcurrentBackups(repo *dpv1alpha1.BackupRepo) int {
+	if repo == nil || repo.Spec.Throttling == nil || repo.Spec.Throttling.MaxConcurrentBackups == nil {
+		return 0
+	}
+	return int(*repo.Spec.Throttling.MaxConcurrentBackups)
+}
+
+// IsThrottledBackup checks if the backup is limited by the max concurrent
+// backups. The continuous backups are not, as they keep running and never
+// complete, they would hold the slots permanently.
+func IsThrottledBackup(backup *dpv1alpha1.Backup) bool {
+	return backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeContinuous)
+}
+
+// CountBackupsAhead counts the backups in the same backup repo that should
+// run before the backup, including the running backups and the pending
+// backups that are queued before the backup. The backups are queued in the
+// order of their creation time, and the continuous backups are not counted.
+func CountBackupsAhead(backup *dpv1alpha1.Backup, backups []dpv1alpha1.Backup) int {
+	count := 0
+	for i := range backups {
+		b := &backups[i]
+		if b.Namespace == backup.Namespace && b.Name == backup.Name {
+			continue
+		}
+		if !IsThrottledBackup(b) {
+			continue
+		}
+		switch b.Status.Phase {
+		case dpv1alpha1.BackupPhaseRunning:
+			count++
+		case dpv1alpha1.BackupPhasePending:
+			if isQueuedBefore(b, backup) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func isQueuedBefore(a, b *dpv1alpha1.Backup) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// SetThrottledCondition sets the throttled condition of the backup, returns
+// true if the condition is changed.
+func SetThrottledCondition(backup *dpv1alpha1.Backup, status metav1.ConditionStatus, reason, message string) bool {
+	return setBackupCondition(backup, ConditionTypeThrottled, status, reason, message)
+}
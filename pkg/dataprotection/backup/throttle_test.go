@@ -0,0 +1,89 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Backup Throttle Test", func() {
+	now := time.Now()
+
+	newBackup := func(name string, phase dpv1alpha1.BackupPhase, created time.Duration) dpv1alpha1.Backup {
+		return dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: now.Add(created)},
+			},
+			Status: dpv1alpha1.BackupStatus{Phase: phase},
+		}
+	}
+
+	Context("count backups ahead", func() {
+		var backups []dpv1alpha1.Backup
+
+		BeforeEach(func() {
+			backups = []dpv1alpha1.Backup{
+				newBackup("running", dpv1alpha1.BackupPhaseRunning, -3*time.Minute),
+				newBackup("completed", dpv1alpha1.BackupPhaseCompleted, -3*time.Minute),
+				newBackup("pending1", dpv1alpha1.BackupPhasePending, -2*time.Minute),
+				newBackup("pending2", dpv1alpha1.BackupPhasePending, -time.Minute),
+				newBackup("new", dpv1alpha1.BackupPhaseNew, 0),
+			}
+		})
+
+		It("should count the unfinished backups created earlier", func() {
+			Expect(CountBackupsAhead(&backups[2], backups)).Should(Equal(1))
+			Expect(CountBackupsAhead(&backups[3], backups)).Should(Equal(2))
+			Expect(CountBackupsAhead(&backups[4], backups)).Should(Equal(3))
+
+			By("the backups created at the same time are queued by their names")
+			same := newBackup("pending0", dpv1alpha1.BackupPhasePending, -2*time.Minute)
+			Expect(CountBackupsAhead(&same, backups)).Should(Equal(1))
+		})
+
+		It("should not count the running continuous backups, which never complete", func() {
+			continuous := newBackup("continuous", dpv1alpha1.BackupPhaseRunning, -3*time.Minute)
+			continuous.Labels = map[string]string{dptypes.BackupTypeLabelKey: string(dpv1alpha1.BackupTypeContinuous)}
+			Expect(IsThrottledBackup(&continuous)).Should(BeFalse())
+			Expect(IsThrottledBackup(&backups[4])).Should(BeTrue())
+			Expect(CountBackupsAhead(&backups[4], append(backups, continuous))).Should(Equal(3))
+		})
+	})
+
+	Context("max concurrent backups", func() {
+		It("should be unlimited if not specified", func() {
+			repo := &dpv1alpha1.BackupRepo{}
+			Expect(GetMaxConcurrentBackups(repo)).Should(Equal(0))
+			repo.Spec.Throttling = &dpv1alpha1.BackupRepoThrottling{MaxConcurrentBackups: pointer.Int32(2)}
+			Expect(GetMaxConcurrentBackups(repo)).Should(Equal(2))
+		})
+	})
+})
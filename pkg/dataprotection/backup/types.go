@@ -29,3 +29,30 @@ const (
 	// BackupInfoFileName is the backup info file name in the backup path.
 	BackupInfoFileName = "backup.info"
 )
+
+// condition constants of the backup
+const (
+	// ConditionTypeRetained indicates whether the expired backup is retained by
+	// the garbage collector.
+	ConditionTypeRetained = "Retained"
+
+	// ReasonHasDependents means the backup is retained because there are alive
+	// backups depending on it.
+	ReasonHasDependents = "HasDependents"
+	// ReasonChainNotExpired means the backup is retained because other backups
+	// in the same backup chain have not expired.
+	ReasonChainNotExpired = "ChainNotExpired"
+	// ReasonChainExpired means all backups in the backup chain have expired,
+	// and they are deleted together.
+	ReasonChainExpired = "ChainExpired"
+
+	// ConditionTypeThrottled indicates whether the backup is queued by the
+	// throttling of the backup repo.
+	ConditionTypeThrottled = "Throttled"
+
+	// ReasonMaxConcurrentBackupsReached means the backup is queued because the
+	// max concurrent backups of the backup repo is reached.
+	ReasonMaxConcurrentBackupsReached = "MaxConcurrentBackupsReached"
+	// ReasonThrottlingReleased means the queued backup is started.
+	ReasonThrottlingReleased = "ThrottlingReleased"
+)
@@ -0,0 +1,457 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const (
+	// sandboxContainerName is the container name of the sandbox pod.
+	sandboxContainerName = "sandbox"
+
+	// verificationNameMaxLength is the max length of the verification name,
+	// leaves enough room for the suffix of the workloads names.
+	verificationNameMaxLength = 52
+
+	// defaultVerificationTimeout is the max duration of the verification if
+	// the timeout is not specified.
+	defaultVerificationTimeout = 2 * time.Hour
+)
+
+// Verifier verifies the backup is restorable. It restores the backup into
+// throwaway persistent volume claims by a Restore, executes the check action
+// against the restored data, and records the result in the backup status.
+type Verifier struct {
+	ctrlutil.RequestCtx
+	Client           client.Client
+	Scheme           *runtime.Scheme
+	RestClientConfig *rest.Config
+}
+
+// Verify handles the verification request of the backup that is specified by
+// the verification request annotation. It updates the verification status of
+// the backup, and returns true if the verification is still in progress.
+func (v *Verifier) Verify(backup *dpv1alpha1.Backup) (bool, error) {
+	requestID := backup.Annotations[dptypes.VerificationRequestAnnotationKey]
+	if requestID == "" {
+		return false, nil
+	}
+
+	status := backup.Status.Verification
+	if status == nil || status.RequestID != requestID {
+		status = &dpv1alpha1.BackupVerificationStatus{
+			Phase:          dpv1alpha1.BackupVerificationPhaseRunning,
+			RequestID:      requestID,
+			RestoreName:    GenerateVerificationName(backup, requestID),
+			StartTimestamp: &metav1.Time{Time: metav1.Now().UTC()},
+		}
+		backup.Status.Verification = status
+	}
+	if status.Phase != dpv1alpha1.BackupVerificationPhaseRunning {
+		return false, nil
+	}
+
+	phase, reason, err := v.verify(backup)
+	if err != nil {
+		return false, err
+	}
+	if phase == dpv1alpha1.BackupVerificationPhaseRunning {
+		return true, nil
+	}
+
+	// the verification is finished, clean up the sandbox.
+	if err = v.cleanup(backup); err != nil {
+		return false, err
+	}
+	status.Phase = phase
+	status.FailureReason = reason
+	status.CompletionTimestamp = &metav1.Time{Time: metav1.Now().UTC()}
+	return false, nil
+}
+
+func (v *Verifier) verify(backup *dpv1alpha1.Backup) (dpv1alpha1.BackupVerificationPhase, string, error) {
+	verification, err := v.getVerification(backup)
+	if err != nil {
+		return "", "", err
+	}
+	if verification == nil {
+		return dpv1alpha1.BackupVerificationPhaseFailed,
+			"verification is not configured in the backup schedule", nil
+	}
+	if timeout := getVerificationTimeout(verification); isVerificationTimedOut(backup.Status.Verification, timeout, time.Now()) {
+		return dpv1alpha1.BackupVerificationPhaseFailed,
+			fmt.Sprintf("the verification is not finished in %s", timeout), nil
+	}
+
+	restore, err := v.ensureRestore(backup, verification)
+	if err != nil {
+		return "", "", err
+	}
+	switch restore.Status.Phase {
+	case dpv1alpha1.RestorePhaseCompleted:
+	case dpv1alpha1.RestorePhaseFailed:
+		return dpv1alpha1.BackupVerificationPhaseFailed,
+			fmt.Sprintf("failed to restore the backup into the sandbox by restore %s", restore.Name), nil
+	default:
+		return dpv1alpha1.BackupVerificationPhaseRunning, "", nil
+	}
+
+	var sandboxPod *corev1.Pod
+	if verification.Check.Exec != nil {
+		if sandboxPod, err = v.ensureSandboxPod(backup, verification); err != nil {
+			return "", "", err
+		}
+		switch sandboxPod.Status.Phase {
+		case corev1.PodRunning:
+		case corev1.PodSucceeded, corev1.PodFailed:
+			return dpv1alpha1.BackupVerificationPhaseFailed,
+				fmt.Sprintf("sandbox pod %s is terminated in phase %s", sandboxPod.Name, sandboxPod.Status.Phase), nil
+		default:
+			return dpv1alpha1.BackupVerificationPhaseRunning, "", nil
+		}
+	}
+
+	checkAction, err := v.buildCheckAction(backup, verification, sandboxPod)
+	if err != nil {
+		return "", "", err
+	}
+	actionStatus, err := checkAction.Execute(action.Context{
+		Ctx:              v.Ctx,
+		Client:           v.Client,
+		Recorder:         v.Recorder,
+		Scheme:           v.Scheme,
+		RestClientConfig: v.RestClientConfig,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	switch actionStatus.Phase {
+	case dpv1alpha1.ActionPhaseCompleted:
+		return dpv1alpha1.BackupVerificationPhasePassed, "", nil
+	case dpv1alpha1.ActionPhaseFailed:
+		return dpv1alpha1.BackupVerificationPhaseFailed,
+			fmt.Sprintf("check action failed: %s", actionStatus.FailureReason), nil
+	default:
+		return dpv1alpha1.BackupVerificationPhaseRunning, "", nil
+	}
+}
+
+func getVerificationTimeout(verification *dpv1alpha1.BackupVerification) time.Duration {
+	if verification.Timeout == nil || verification.Timeout.Duration <= 0 {
+		return defaultVerificationTimeout
+	}
+	return verification.Timeout.Duration
+}
+
+// isVerificationTimedOut checks if the verification is not finished in the
+// timeout since it is started.
+func isVerificationTimedOut(status *dpv1alpha1.BackupVerificationStatus, timeout time.Duration, now time.Time) bool {
+	if status == nil || status.StartTimestamp == nil {
+		return false
+	}
+	return now.After(status.StartTimestamp.Add(timeout))
+}
+
+// getVerification gets the verification of the backup schedule that creates
+// the backup.
+func (v *Verifier) getVerification(backup *dpv1alpha1.Backup) (*dpv1alpha1.BackupVerification, error) {
+	scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]
+	if scheduleName == "" {
+		return nil, nil
+	}
+	backupSchedule := &dpv1alpha1.BackupSchedule{}
+	if err := v.Client.Get(v.Ctx, client.ObjectKey{Namespace: backup.Namespace, Name: scheduleName}, backupSchedule); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	schedulePolicy := GetSchedulePolicyByMethod(backupSchedule, backup.Spec.BackupMethod)
+	if schedulePolicy == nil {
+		return nil, nil
+	}
+	return schedulePolicy.Verification, nil
+}
+
+// ensureRestore creates the restore that restores the backup into the
+// throwaway persistent volume claims if not exists.
+func (v *Verifier) ensureRestore(backup *dpv1alpha1.Backup,
+	verification *dpv1alpha1.BackupVerification) (*dpv1alpha1.Restore, error) {
+	restore := &dpv1alpha1.Restore{}
+	key := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Status.Verification.RestoreName}
+	exists, err := ctrlutil.CheckResourceExists(v.Ctx, v.Client, key, restore)
+	if err != nil || exists {
+		return restore, err
+	}
+	restore = BuildVerificationRestore(backup, verification)
+	if err = utils.SetControllerReference(backup, restore, v.Scheme); err != nil {
+		return nil, err
+	}
+	msg := fmt.Sprintf("creating restore %s to verify the backup", restore.Name)
+	v.Recorder.Event(backup, corev1.EventTypeNormal, "CreatingVerificationRestore", msg)
+	return restore, client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, restore))
+}
+
+// buildCheckAction builds the check action, the exec action is executed in
+// the running sandbox pod.
+func (v *Verifier) buildCheckAction(backup *dpv1alpha1.Backup,
+	verification *dpv1alpha1.BackupVerification, sandboxPod *corev1.Pod) (action.Action, error) {
+	name := backup.Status.Verification.RestoreName + "-check"
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: backup.Namespace,
+		Labels:    buildVerificationLabels(backup),
+	}
+	check := verification.Check
+	switch {
+	case check.Job != nil:
+		return &action.JobAction{
+			Name:       name,
+			ObjectMeta: objectMeta,
+			Owner:      backup,
+			PodSpec:    BuildVerificationCheckPodSpec(backup, verification),
+		}, nil
+	case check.Exec != nil:
+		backupPolicy, err := utils.GetBackupPolicyByName(v.RequestCtx, v.Client, backup.Spec.BackupPolicyName)
+		if err != nil {
+			return nil, err
+		}
+		container := check.Exec.Container
+		if container == "" {
+			container = sandboxContainerName
+		}
+		return &action.ExecAction{
+			JobAction: action.JobAction{
+				Name:       name,
+				ObjectMeta: objectMeta,
+				Owner:      backup,
+			},
+			PodName:            sandboxPod.Name,
+			Namespace:          sandboxPod.Namespace,
+			Command:            check.Exec.Command,
+			Container:          container,
+			ServiceAccountName: backupPolicy.Spec.Target.ServiceAccountName,
+			Timeout:            check.Exec.Timeout,
+		}, nil
+	default:
+		return nil, fmt.Errorf("check action of the backup verification is not specified")
+	}
+}
+
+// ensureSandboxPod creates the sandbox pod that mounts the restored volumes
+// if not exists.
+func (v *Verifier) ensureSandboxPod(backup *dpv1alpha1.Backup,
+	verification *dpv1alpha1.BackupVerification) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	key := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Status.Verification.RestoreName + "-sandbox"}
+	exists, err := ctrlutil.CheckResourceExists(v.Ctx, v.Client, key, pod)
+	if err != nil || exists {
+		return pod, err
+	}
+	volumes, volumeMounts := buildVerificationVolumes(backup, verification)
+	container := corev1.Container{
+		Name:            sandboxContainerName,
+		Image:           verification.SandboxImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		// keep the sandbox alive for the check, the entrypoint of the image may exit.
+		Command:      []string{"sh", "-c", "trap : TERM INT; sleep infinity & wait"},
+		VolumeMounts: volumeMounts,
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	pod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    buildVerificationLabels(backup),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers:    []corev1.Container{container},
+			Volumes:       volumes,
+		},
+	}
+	if err = utils.AddTolerations(&pod.Spec); err != nil {
+		return nil, err
+	}
+	if err = utils.SetControllerReference(backup, pod, v.Scheme); err != nil {
+		return nil, err
+	}
+	return pod, client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, pod))
+}
+
+// cleanup deletes the sandbox of the backup verification, including the
+// restore, the check job, the sandbox pod and the throwaway persistent volume
+// claims.
+func (v *Verifier) cleanup(backup *dpv1alpha1.Backup) error {
+	name := backup.Status.Verification.RestoreName
+	objects := []client.Object{
+		&dpv1alpha1.Restore{ObjectMeta: metav1.ObjectMeta{Namespace: backup.Namespace, Name: name}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: backup.Namespace, Name: name + "-check"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: backup.Namespace, Name: name + "-sandbox"}},
+	}
+	for _, obj := range objects {
+		if err := v.Client.Get(v.Ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if _, ok := obj.(*batchv1.Job); ok {
+			if err := utils.RemoveDataProtectionFinalizer(v.Ctx, v.Client, obj); err != nil {
+				return err
+			}
+		}
+		if err := ctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, obj); err != nil {
+			return err
+		}
+	}
+	return v.Client.DeleteAllOf(v.Ctx, &corev1.PersistentVolumeClaim{},
+		client.InNamespace(backup.Namespace), client.MatchingLabels(buildVerificationLabels(backup)))
+}
+
+// BuildVerificationRestore builds the restore that restores the backup into
+// the throwaway persistent volume claims.
+func BuildVerificationRestore(backup *dpv1alpha1.Backup,
+	verification *dpv1alpha1.BackupVerification) *dpv1alpha1.Restore {
+	name := backup.Status.Verification.RestoreName
+	labels := buildVerificationLabels(backup)
+	var claims []dpv1alpha1.RestoreVolumeClaim
+	for _, c := range verification.VolumeClaims {
+		claim := *c.DeepCopy()
+		claim.Name = buildVerificationClaimName(name, c.Name)
+		if claim.Labels == nil {
+			claim.Labels = map[string]string{}
+		}
+		for k, v := range labels {
+			claim.Labels[k] = v
+		}
+		claims = append(claims, claim)
+	}
+	return &dpv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: backup.Namespace,
+			Labels:    labels,
+		},
+		Spec: dpv1alpha1.RestoreSpec{
+			Backup: dpv1alpha1.BackupRef{
+				Name:      backup.Name,
+				Namespace: backup.Namespace,
+			},
+			PrepareDataConfig: &dpv1alpha1.PrepareDataConfig{
+				RestoreVolumeClaims:      claims,
+				VolumeClaimRestorePolicy: dpv1alpha1.VolumeClaimRestorePolicyParallel,
+			},
+		},
+	}
+}
+
+// BuildVerificationCheckPodSpec builds the pod spec of the check job, which
+// mounts the restored volumes at their mount paths.
+func BuildVerificationCheckPodSpec(backup *dpv1alpha1.Backup,
+	verification *dpv1alpha1.BackupVerification) *corev1.PodSpec {
+	volumes, volumeMounts := buildVerificationVolumes(backup, verification)
+	job := verification.Check.Job
+	container := corev1.Container{
+		Name:            "check",
+		Image:           job.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         job.Command,
+		VolumeMounts:    volumeMounts,
+		Env: []corev1.EnvVar{
+			{Name: dptypes.DPBackupName, Value: backup.Name},
+			{Name: constant.KBEnvNamespace, Value: backup.Namespace},
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec := &corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers:    []corev1.Container{container},
+		Volumes:       volumes,
+	}
+	_ = utils.AddTolerations(podSpec)
+	return podSpec
+}
+
+func buildVerificationVolumes(backup *dpv1alpha1.Backup,
+	verification *dpv1alpha1.BackupVerification) ([]corev1.Volume, []corev1.VolumeMount) {
+	var (
+		volumes      []corev1.Volume
+		volumeMounts []corev1.VolumeMount
+	)
+	for _, c := range verification.VolumeClaims {
+		if c.MountPath == "" {
+			continue
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: c.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: buildVerificationClaimName(backup.Status.Verification.RestoreName, c.Name),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      c.Name,
+			MountPath: c.MountPath,
+		})
+	}
+	return volumes, volumeMounts
+}
+
+func buildVerificationLabels(backup *dpv1alpha1.Backup) map[string]string {
+	return map[string]string{
+		dptypes.VerifiedBackupLabelKey: backup.Name,
+		constant.AppManagedByLabelKey:  dptypes.AppName,
+	}
+}
+
+func buildVerificationClaimName(verificationName, claimName string) string {
+	return fmt.Sprintf("%s-%s", verificationName, claimName)
+}
+
+// GenerateVerificationName generates the name of the verification by the
+// backup name and the request ID, it is also the name of the restore.
+func GenerateVerificationName(backup *dpv1alpha1.Backup, requestID string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(requestID))
+	suffix := fmt.Sprintf("-verify-%x", h.Sum32())
+	prefix := backup.Name
+	if len(prefix)+len(suffix) > verificationNameMaxLength {
+		prefix = strings.TrimSuffix(prefix[:verificationNameMaxLength-len(suffix)], "-")
+	}
+	return prefix + suffix
+}
@@ -0,0 +1,128 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Backup Verifier Test", func() {
+	const requestID = "20231101000000"
+
+	var (
+		backup       *dpv1alpha1.Backup
+		verification *dpv1alpha1.BackupVerification
+	)
+
+	BeforeEach(func() {
+		backup = &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		}
+		backup.Status.Verification = &dpv1alpha1.BackupVerificationStatus{
+			RequestID:   requestID,
+			RestoreName: GenerateVerificationName(backup, requestID),
+		}
+		verification = &dpv1alpha1.BackupVerification{
+			CronExpression: "0 0 * * *",
+			VolumeClaims: []dpv1alpha1.RestoreVolumeClaim{
+				{
+					ObjectMeta:   metav1.ObjectMeta{Name: "data"},
+					VolumeConfig: dpv1alpha1.VolumeConfig{VolumeSource: "data", MountPath: "/data"},
+				},
+				{
+					ObjectMeta:   metav1.ObjectMeta{Name: "log"},
+					VolumeConfig: dpv1alpha1.VolumeConfig{VolumeSource: "log"},
+				},
+			},
+			Check: dpv1alpha1.ActionSpec{
+				Job: &dpv1alpha1.JobActionSpec{
+					BaseJobActionSpec: dpv1alpha1.BaseJobActionSpec{
+						Image:   "busybox",
+						Command: []string{"test", "-f", "/data/ok"},
+					},
+				},
+			},
+		}
+	})
+
+	Context("verification name", func() {
+		It("should generate the name by the backup and the request", func() {
+			restoreName := backup.Status.Verification.RestoreName
+			Expect(restoreName).Should(HavePrefix("backup-verify-"))
+			Expect(GenerateVerificationName(backup, requestID)).Should(Equal(restoreName))
+			Expect(GenerateVerificationName(backup, "another")).ShouldNot(Equal(restoreName))
+
+			By("the name is truncated for long backup names")
+			backup.Name = strings.Repeat("a", 80)
+			Expect(len(GenerateVerificationName(backup, requestID))).Should(BeNumerically("<=", verificationNameMaxLength))
+		})
+	})
+
+	Context("build verification", func() {
+		It("should build the restore of the sandbox", func() {
+			restoreName := backup.Status.Verification.RestoreName
+			restore := BuildVerificationRestore(backup, verification)
+			Expect(restore.Name).Should(Equal(restoreName))
+			Expect(restore.Spec.Backup.Name).Should(Equal("backup"))
+			Expect(restore.Spec.Backup.Namespace).Should(Equal("default"))
+			claims := restore.Spec.PrepareDataConfig.RestoreVolumeClaims
+			Expect(claims).Should(HaveLen(2))
+			Expect(claims[0].Name).Should(Equal(restoreName + "-data"))
+			Expect(claims[0].Labels[dptypes.VerifiedBackupLabelKey]).Should(Equal("backup"))
+
+			By("the claims of the verification are not modified")
+			Expect(verification.VolumeClaims[0].Name).Should(Equal("data"))
+		})
+
+		It("should mount only the claims with mount path to the check job", func() {
+			podSpec := BuildVerificationCheckPodSpec(backup, verification)
+			Expect(podSpec.Volumes).Should(HaveLen(1))
+			Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).Should(Equal(backup.Status.Verification.RestoreName + "-data"))
+			Expect(podSpec.Containers[0].VolumeMounts[0].MountPath).Should(Equal("/data"))
+			Expect(podSpec.Containers[0].Command).Should(Equal([]string{"test", "-f", "/data/ok"}))
+		})
+	})
+
+	Context("verification timeout", func() {
+		It("should use the default timeout if not specified", func() {
+			Expect(getVerificationTimeout(verification)).Should(Equal(defaultVerificationTimeout))
+			verification.Timeout = &metav1.Duration{Duration: time.Hour}
+			Expect(getVerificationTimeout(verification)).Should(Equal(time.Hour))
+		})
+
+		It("should time out since the verification started", func() {
+			now := time.Now()
+			status := &dpv1alpha1.BackupVerificationStatus{}
+			Expect(isVerificationTimedOut(status, time.Hour, now)).Should(BeFalse())
+			status.StartTimestamp = &metav1.Time{Time: now.Add(-30 * time.Minute)}
+			Expect(isVerificationTimedOut(status, time.Hour, now)).Should(BeFalse())
+			Expect(isVerificationTimedOut(status, 10*time.Minute, now)).Should(BeTrue())
+		})
+	})
+})
@@ -51,6 +51,8 @@ const (
 	ErrorTypeLogfileScheduleDisabled intctrlutil.ErrorType = "LogfileScheduleDisabled"
 	// ErrorTypeWaitForExternalHandler wait for external handler to handle the Backup or Restore
 	ErrorTypeWaitForExternalHandler intctrlutil.ErrorType = "WaitForExternalHandler"
+	// ErrorTypeParentBackupNotReady the parent backup is not ready
+	ErrorTypeParentBackupNotReady intctrlutil.ErrorType = "ParentBackupNotReady"
 )
 
 // NewBackupNotSupported returns a new Error with ErrorTypeBackupNotSupported.
@@ -97,3 +99,8 @@ func NewBackupScheduleDisabled(backupType, backupPolicyName string) *intctrlutil
 func NewBackupLogfileScheduleDisabled(backupToolName string) *intctrlutil.Error {
 	return intctrlutil.NewErrorf(ErrorTypeLogfileScheduleDisabled, `BackupTool "%s" of the backup relies on logfile. Please enable the logfile scheduling firstly`, backupToolName)
 }
+
+// NewParentBackupNotReady returns a new Error with ErrorTypeParentBackupNotReady.
+func NewParentBackupNotReady(backupName string) *intctrlutil.Error {
+	return intctrlutil.NewErrorf(ErrorTypeParentBackupNotReady, `wait for the previous backup "%s" to complete`, backupName)
+}
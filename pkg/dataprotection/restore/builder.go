@@ -369,6 +369,8 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 		kopiaRepoPath := r.backupSet.Backup.Status.KopiaRepoPath
 		if r.backupRepo != nil {
 			utils.InjectDatasafed(&job.Spec.Template.Spec, r.backupRepo, mountPath, kopiaRepoPath)
+			utils.InjectDatasafedEncryption(&job.Spec.Template.Spec, utils.GetEncryptionKeySecretName(r.restore.Name),
+				r.backupRepo, r.backupSet.Backup.Status.Encryption)
 		} else if pvcName := r.backupSet.Backup.Status.PersistentVolumeClaimName; pvcName != "" {
 			// If the backup object was created in an old version that doesn't have the backupRepo field,
 			// use the PVC name field as a fallback.
@@ -96,7 +96,13 @@ func (r *RestoreManager) GetBackupActionSetByNamespaced(reqCtx intctrlutil.Reque
 
 // BuildDifferentialBackupActionSets builds the backupActionSets for specified incremental backup.
 func (r *RestoreManager) BuildDifferentialBackupActionSets(reqCtx intctrlutil.RequestCtx, cli client.Client, sourceBackupSet BackupActionSet) error {
-	parentBackupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, sourceBackupSet.Backup.Spec.ParentBackupName, sourceBackupSet.Backup.Namespace)
+	parentBackupName := utils.GetParentBackupName(sourceBackupSet.Backup)
+	if parentBackupName == "" {
+		// the backup is the base of the backup chain.
+		r.SetBackupSets(sourceBackupSet)
+		return nil
+	}
+	parentBackupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, parentBackupName, sourceBackupSet.Backup.Namespace)
 	if err != nil || parentBackupSet == nil {
 		return err
 	}
@@ -107,15 +113,17 @@ func (r *RestoreManager) BuildDifferentialBackupActionSets(reqCtx intctrlutil.Re
 // BuildIncrementalBackupActionSets builds the backupActionSets for specified incremental backup.
 func (r *RestoreManager) BuildIncrementalBackupActionSets(reqCtx intctrlutil.RequestCtx, cli client.Client, sourceBackupSet BackupActionSet) error {
 	r.SetBackupSets(sourceBackupSet)
-	if sourceBackupSet.ActionSet != nil && sourceBackupSet.ActionSet.Spec.BackupType == dpv1alpha1.BackupTypeIncremental {
+	parentBackupName := utils.GetParentBackupName(sourceBackupSet.Backup)
+	if sourceBackupSet.ActionSet != nil && sourceBackupSet.ActionSet.Spec.BackupType == dpv1alpha1.BackupTypeIncremental &&
+		parentBackupName != "" {
 		// get the parent BackupActionSet for incremental.
-		backupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, sourceBackupSet.Backup.Spec.ParentBackupName, sourceBackupSet.Backup.Namespace)
+		backupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, parentBackupName, sourceBackupSet.Backup.Namespace)
 		if err != nil || backupSet == nil {
 			return err
 		}
 		return r.BuildIncrementalBackupActionSets(reqCtx, cli, *backupSet)
 	}
-	// if reaches full backup, sort the BackupActionSets and return
+	// if reaches the base backup, sort the BackupActionSets and return
 	sortBackupSets := func(backupSets []BackupActionSet, reverse bool) []BackupActionSet {
 		sort.Slice(backupSets, func(i, j int) bool {
 			if reverse {
@@ -255,6 +263,12 @@ func (r *RestoreManager) prepareBackupRepo(reqCtx intctrlutil.RequestCtx, cli cl
 			}
 			return nil, err
 		}
+		// copy the encryption key of the backup into the namespace of the restore,
+		// which may be different from the namespace of the backup.
+		if err = utils.EnsureEncryptionKeySecret(reqCtx.Ctx, cli, r.Restore, backupRepo,
+			backupSet.Backup.Status.Encryption); err != nil {
+			return nil, err
+		}
 		return backupRepo, nil
 	}
 	return nil, nil
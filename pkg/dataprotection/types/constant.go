@@ -52,6 +52,8 @@ const (
 	ConnectionPasswordAnnotationKey = "dataprotection.kubeblocks.io/connection-password"
 	// GeminiAcknowledgedAnnotationKey indicates whether Gemini has acknowledged the backup.
 	GeminiAcknowledgedAnnotationKey = "dataprotection.kubeblocks.io/gemini-acknowledged"
+	// VerificationRequestAnnotationKey specifies the verification request of the backup.
+	VerificationRequestAnnotationKey = "dataprotection.kubeblocks.io/verification-request"
 )
 
 // label keys
@@ -74,6 +76,12 @@ const (
 	AutoBackupLabelKey = "dataprotection.kubeblocks.io/autobackup"
 	// BackupTargetPodLabelKey specifies the backup target pod label key.
 	BackupTargetPodLabelKey = "dataprotection.kubeblocks.io/target-pod-name"
+	// VerifiedBackupLabelKey specifies the label key of the backup being verified.
+	VerifiedBackupLabelKey = "dataprotection.kubeblocks.io/verified-backup"
+	// ReplicatedFromLabelKey specifies the name of the source backup of the replica backup.
+	ReplicatedFromLabelKey = "dataprotection.kubeblocks.io/replicated-from"
+	// BackupReplicationLabelKey specifies the name of the replication that creates the replica backup.
+	BackupReplicationLabelKey = "dataprotection.kubeblocks.io/backup-replication"
 )
 
 // env names
@@ -108,6 +116,18 @@ const (
 	DPTimeZone = "DP_TIME_ZONE"
 	// DPBackupStopTime backup stop time
 	DPBackupStopTime = "DP_BACKUP_STOP_TIME" // backup stop time
+	// DPParentBackupName the name of the parent backup for incremental or differential backup
+	DPParentBackupName = "DP_PARENT_BACKUP_NAME"
+	// DPParentBackupBasePath the base path of the parent backup data in the storage
+	DPParentBackupBasePath = "DP_PARENT_BACKUP_BASE_PATH"
+	// DPParentBackupStartTime the start time of the parent backup
+	DPParentBackupStartTime = "DP_PARENT_BACKUP_START_TIME"
+	// DPParentBackupStopTime the stop time of the parent backup
+	DPParentBackupStopTime = "DP_PARENT_BACKUP_STOP_TIME"
+	// DPParentBackupExtras the extras of the parent backup, formatted as json
+	DPParentBackupExtras = "DP_PARENT_BACKUP_EXTRAS"
+	// DPBaseBackupName the name of the base backup of the backup chain
+	DPBaseBackupName = "DP_BASE_BACKUP_NAME"
 	// DPDatasafedBinPath the path containing the datasafed binary
 	DPDatasafedBinPath = "DP_DATASAFED_BIN_PATH"
 	// DPDatasafedLocalBackendPath force datasafed to use local backend with the path
@@ -116,6 +136,18 @@ const (
 	// DPDatasafedKopiaRepoRoot specifies the root of the Kopia repository
 	// NOTE: do not add 'DP_' for this constant, it is the datasafed built-in environment.
 	DPDatasafedKopiaRepoRoot = "DATASAFED_KOPIA_REPO_ROOT"
+	// DPDatasafedEncryptionAlgorithm specifies the algorithm to encrypt the backup data
+	// NOTE: do not add 'DP_' for this constant, it is the datasafed built-in environment.
+	DPDatasafedEncryptionAlgorithm = "DATASAFED_ENCRYPTION_ALGORITHM"
+	// DPDatasafedEncryptionPassPhrase specifies the key to encrypt the backup data
+	// NOTE: do not add 'DP_' for this constant, it is the datasafed built-in environment.
+	DPDatasafedEncryptionPassPhrase = "DATASAFED_ENCRYPTION_PASS_PHRASE"
+	// DPDatasafedUploadBandwidthLimit specifies the max bandwidth in bytes per second to upload data
+	// NOTE: do not add 'DP_' for this constant, it is the datasafed built-in environment.
+	DPDatasafedUploadBandwidthLimit = "DATASAFED_UPLOAD_BANDWIDTH_LIMIT"
+	// DPDatasafedDownloadBandwidthLimit specifies the max bandwidth in bytes per second to download data
+	// NOTE: do not add 'DP_' for this constant, it is the datasafed built-in environment.
+	DPDatasafedDownloadBandwidthLimit = "DATASAFED_DOWNLOAD_BANDWIDTH_LIMIT"
 )
 
 const (
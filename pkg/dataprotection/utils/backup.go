@@ -53,3 +53,12 @@ func GetBackupMethodsFromBackupPolicy(backupPolicyList *dpv1alpha1.BackupPolicyL
 	}
 	return defaultBackupMethod, backupMethodsMap
 }
+
+// GetParentBackupName gets the parent backup name of the backup, the parent
+// resolved by the backup controller takes precedence.
+func GetParentBackupName(backup *dpv1alpha1.Backup) string {
+	if backup.Status.ParentBackupName != "" {
+		return backup.Status.ParentBackupName
+	}
+	return backup.Spec.ParentBackupName
+}
@@ -20,9 +20,16 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package utils
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -44,6 +51,30 @@ func InjectDatasafed(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, repoV
 	} else if repo.AccessByTool() {
 		InjectDatasafedWithConfig(podSpec, repo.Status.ToolConfigSecretName, kopiaRepoPath)
 	}
+	injectDatasafedThrottling(podSpec, repo)
+}
+
+// injectDatasafedThrottling injects the environments to limit the bandwidth
+// of datasafed by the throttling of the backup repo.
+func injectDatasafedThrottling(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo) {
+	throttling := repo.Spec.Throttling
+	if throttling == nil {
+		return
+	}
+	var envs []corev1.EnvVar
+	if throttling.MaxUploadBandwidth != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  dptypes.DPDatasafedUploadBandwidthLimit,
+			Value: strconv.FormatInt(throttling.MaxUploadBandwidth.Value(), 10),
+		})
+	}
+	if throttling.MaxDownloadBandwidth != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:  dptypes.DPDatasafedDownloadBandwidthLimit,
+			Value: strconv.FormatInt(throttling.MaxDownloadBandwidth.Value(), 10),
+		})
+	}
+	injectElements(podSpec, nil, nil, envs)
 }
 
 func InjectDatasafedWithPVC(podSpec *corev1.PodSpec, pvcName string, mountPath string, kopiaRepoPath string) {
@@ -103,6 +134,103 @@ func InjectDatasafedWithConfig(podSpec *corev1.PodSpec, configSecretName string,
 	injectDatasafedInstaller(podSpec)
 }
 
+// GetEncryptionKeySecretName returns the name of the secret that holds the
+// encryption keys used by the jobs of the owner, e.g. a backup or a restore.
+func GetEncryptionKeySecretName(ownerName string) string {
+	return fmt.Sprintf("%s-encryption-key", ownerName)
+}
+
+// getEncryptionKeyName returns the key of the encryption key in the secret
+// created by EnsureEncryptionKeySecret. The keys of different backup repos may
+// share the same key ID, so the key is prefixed with the backup repo name.
+func getEncryptionKeyName(repo *dpv1alpha1.BackupRepo, encryption *dpv1alpha1.BackupEncryption) string {
+	return fmt.Sprintf("%s.%s", repo.Name, encryption.KeyID)
+}
+
+// EnsureEncryptionKeySecret copies the encryption key recorded in the backup
+// from the key secret of the backup repo into the secret of the owner, so the
+// jobs in the namespace of the owner can reference it. Only the keys used by
+// the owner are copied, and the secret is deleted with the owner.
+func EnsureEncryptionKeySecret(ctx context.Context, cli client.Client, owner client.Object,
+	repo *dpv1alpha1.BackupRepo, encryption *dpv1alpha1.BackupEncryption) error {
+	if repo == nil || encryption == nil || encryption.KeyID == "" {
+		return nil
+	}
+	// the data is encrypted, it can not be accessed without the key of the backup repo.
+	if repo.Spec.Encryption == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf("the data is encrypted by the key %s, but the encryption of backup repo %s is removed",
+			encryption.KeyID, repo.Name))
+	}
+	keySecretRef := repo.Spec.Encryption.KeySecretRef
+	source := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: keySecretRef.Namespace, Name: keySecretRef.Name}, source); err != nil {
+		return fmt.Errorf("failed to get encryption key secret %s/%s: %w", keySecretRef.Namespace, keySecretRef.Name, err)
+	}
+	key := source.Data[encryption.KeyID]
+	if len(key) == 0 {
+		return intctrlutil.NewFatalError(fmt.Sprintf("key %s not found in the encryption key secret %s/%s",
+			encryption.KeyID, keySecretRef.Namespace, keySecretRef.Name))
+	}
+
+	keyName := getEncryptionKeyName(repo, encryption)
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: owner.GetNamespace(), Name: GetEncryptionKeySecretName(owner.GetName())}
+	err := cli.Get(ctx, secretKey, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretKey.Name,
+				Namespace: secretKey.Namespace,
+			},
+			Data: map[string][]byte{keyName: key},
+		}
+		if err = controllerutil.SetOwnerReference(owner, secret, cli.Scheme()); err != nil {
+			return err
+		}
+		return client.IgnoreAlreadyExists(cli.Create(ctx, secret))
+	}
+	if bytes.Equal(secret.Data[keyName], key) {
+		return nil
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[keyName] = key
+	return cli.Patch(ctx, secret, patch)
+}
+
+// InjectDatasafedEncryption injects the environments to enable the client-side
+// encryption of datasafed. The key is referenced from the secret created by
+// EnsureEncryptionKeySecret by the key ID recorded in the backup.
+func InjectDatasafedEncryption(podSpec *corev1.PodSpec, secretName string,
+	repo *dpv1alpha1.BackupRepo, encryption *dpv1alpha1.BackupEncryption) {
+	if repo == nil || encryption == nil || encryption.KeyID == "" {
+		return
+	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  dptypes.DPDatasafedEncryptionAlgorithm,
+			Value: string(encryption.Algorithm),
+		},
+		{
+			Name: dptypes.DPDatasafedEncryptionPassPhrase,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretName,
+					},
+					Key: getEncryptionKeyName(repo, encryption),
+				},
+			},
+		},
+	}
+	injectElements(podSpec, nil, nil, envs)
+}
+
 func injectDatasafedInstaller(podSpec *corev1.PodSpec) {
 	sharedVolumeName := "dp-datasafed-bin"
 	sharedVolume := corev1.Volume{
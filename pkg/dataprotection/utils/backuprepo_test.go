@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Backup Repo", func() {
+	var repo *dpv1alpha1.BackupRepo
+
+	BeforeEach(func() {
+		repo = &dpv1alpha1.BackupRepo{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo"},
+			Spec: dpv1alpha1.BackupRepoSpec{
+				Encryption: &dpv1alpha1.BackupRepoEncryption{
+					KeySecretRef: corev1.SecretReference{Namespace: "kb-system", Name: "repo-keys"},
+					CurrentKeyID: "key-2",
+				},
+			},
+		}
+	})
+
+	Context("inject datasafed encryption", func() {
+		newPodSpec := func() *corev1.PodSpec {
+			return &corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "backup"}},
+			}
+		}
+
+		It("should inject nothing if the backup is not encrypted", func() {
+			podSpec := newPodSpec()
+			InjectDatasafedEncryption(podSpec, "backup-encryption-key", repo, nil)
+			Expect(podSpec.Containers[0].Env).Should(BeEmpty())
+		})
+
+		It("should inject the algorithm and the key of the backup", func() {
+			podSpec := newPodSpec()
+			InjectDatasafedEncryption(podSpec, "backup-encryption-key", repo, &dpv1alpha1.BackupEncryption{
+				Algorithm: dpv1alpha1.EncryptionAlgorithmAES256CFB,
+				KeyID:     "key-2",
+			})
+			envs := podSpec.Containers[0].Env
+			Expect(envs).Should(HaveLen(2))
+			Expect(envs[0].Name).Should(Equal(dptypes.DPDatasafedEncryptionAlgorithm))
+			Expect(envs[0].Value).Should(Equal(string(dpv1alpha1.EncryptionAlgorithmAES256CFB)))
+			Expect(envs[1].Name).Should(Equal(dptypes.DPDatasafedEncryptionPassPhrase))
+			Expect(envs[1].ValueFrom.SecretKeyRef.Name).Should(Equal("backup-encryption-key"))
+			Expect(envs[1].ValueFrom.SecretKeyRef.Key).Should(Equal("repo.key-2"))
+		})
+	})
+
+	Context("ensure encryption key secret", func() {
+		var (
+			ctx        = context.Background()
+			cli        client.Client
+			restore    *dpv1alpha1.Restore
+			encryption *dpv1alpha1.BackupEncryption
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+			Expect(dpv1alpha1.AddToScheme(scheme)).Should(Succeed())
+			keySecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kb-system", Name: "repo-keys"},
+				Data: map[string][]byte{
+					"key-1": []byte("old-key"),
+					"key-2": []byte("new-key"),
+				},
+			}
+			cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(keySecret).Build()
+			restore = &dpv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "restore", UID: "restore-uid"},
+			}
+			encryption = &dpv1alpha1.BackupEncryption{Algorithm: dpv1alpha1.EncryptionAlgorithmAES256CFB, KeyID: "key-1"}
+		})
+
+		It("should copy the keys used by the backups into the namespace of the owner", func() {
+			Expect(EnsureEncryptionKeySecret(ctx, cli, restore, repo, encryption)).Should(Succeed())
+			secret := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: "other", Name: GetEncryptionKeySecretName("restore")}, secret)).Should(Succeed())
+			Expect(secret.Data).Should(Equal(map[string][]byte{"repo.key-1": []byte("old-key")}))
+			Expect(secret.OwnerReferences).Should(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Name).Should(Equal("restore"))
+
+			By("the keys of other backup repos are added into the same secret")
+			otherRepo := repo.DeepCopy()
+			otherRepo.Name = "other-repo"
+			Expect(EnsureEncryptionKeySecret(ctx, cli, restore, otherRepo, encryption)).Should(Succeed())
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(secret), secret)).Should(Succeed())
+			Expect(secret.Data).Should(HaveLen(2))
+		})
+
+		It("should copy nothing if the backup is not encrypted", func() {
+			Expect(EnsureEncryptionKeySecret(ctx, cli, restore, repo, nil)).Should(Succeed())
+		})
+
+		It("should fail if the key is not available", func() {
+			By("the key is not found in the key secret")
+			encryption.KeyID = "key-3"
+			Expect(EnsureEncryptionKeySecret(ctx, cli, restore, repo, encryption)).ShouldNot(Succeed())
+
+			By("the encryption of the backup repo is removed")
+			encryption.KeyID = "key-1"
+			repo.Spec.Encryption = nil
+			Expect(EnsureEncryptionKeySecret(ctx, cli, restore, repo, encryption)).ShouldNot(Succeed())
+		})
+	})
+
+	Context("inject datasafed throttling", func() {
+		It("should limit the upload bandwidth only", func() {
+			repo.Spec.AccessMethod = dpv1alpha1.AccessMethodTool
+			uploadBandwidth := resource.MustParse("100Mi")
+			repo.Spec.Throttling = &dpv1alpha1.BackupRepoThrottling{
+				MaxUploadBandwidth: &uploadBandwidth,
+			}
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "backup"}},
+			}
+			InjectDatasafed(podSpec, repo, "/backupdata", "")
+			var value string
+			for _, env := range podSpec.Containers[0].Env {
+				Expect(env.Name).ShouldNot(Equal(dptypes.DPDatasafedDownloadBandwidthLimit))
+				if env.Name == dptypes.DPDatasafedUploadBandwidthLimit {
+					value = env.Value
+				}
+			}
+			Expect(value).Should(Equal("104857600"))
+		})
+	})
+})
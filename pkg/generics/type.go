@@ -104,6 +104,8 @@ var OpsDefinitionSignature = func(_ appsv1alpha1.OpsDefinition, _ *appsv1alpha1.
 }
 var OpsRequestSignature = func(_ appsv1alpha1.OpsRequest, _ *appsv1alpha1.OpsRequest, _ appsv1alpha1.OpsRequestList, _ *appsv1alpha1.OpsRequestList) {
 }
+var OpsRequestScheduleSignature = func(_ appsv1alpha1.OpsRequestSchedule, _ *appsv1alpha1.OpsRequestSchedule, _ appsv1alpha1.OpsRequestScheduleList, _ *appsv1alpha1.OpsRequestScheduleList) {
+}
 var ConfigConstraintSignature = func(_ appsv1alpha1.ConfigConstraint, _ *appsv1alpha1.ConfigConstraint, _ appsv1alpha1.ConfigConstraintList, _ *appsv1alpha1.ConfigConstraintList) {
 }
 
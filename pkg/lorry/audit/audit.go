@@ -0,0 +1,178 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+const (
+	ResultSuccess = "Success"
+	ResultFailed  = "Failed"
+
+	eventReason = "LorryAudit"
+	maskedValue = "******"
+)
+
+var (
+	sensitiveKeys = []string{"password", "passwd", "secret", "token", "credential"}
+	// masks the password literals in statements, such as `IDENTIFIED BY 'xxx'` and `PASSWORD 'xxx'`
+	sensitiveStatement = regexp.MustCompile(`(?i)((?:identified\s+(?:with\s+\S+\s+)?by|password)\s*(?:=\s*)?)('[^']*'|"[^"]*")`)
+	// masks the password rules of the redis ACL, such as `ACL SETUSER user on >xxx <xxx #hash !hash`
+	redisACLStatement = regexp.MustCompile(`(?i)\bacl\s+setuser\s`)
+	redisACLPassword  = regexp.MustCompile(`(\s)([<>#!])\S+`)
+)
+
+// Record is an audit record of a lorry operation.
+type Record struct {
+	Time       time.Time      `json:"time"`
+	Caller     string         `json:"caller"`
+	RemoteAddr string         `json:"remoteAddr,omitempty"`
+	Operation  string         `json:"operation"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+	Result     string         `json:"result"`
+	StatusCode int            `json:"statusCode"`
+	Message    string         `json:"message,omitempty"`
+	DurationMs int64          `json:"durationMs"`
+}
+
+type Auditor struct {
+	config Config
+	writer io.Writer
+	// sendEvent is replaced in unit tests.
+	sendEvent func(ctx context.Context, event *corev1.Event) error
+}
+
+// NewAuditor returns an auditor writing the records to the rotating log file.
+func NewAuditor(c Config) *Auditor {
+	a := &Auditor{
+		config:    c,
+		sendEvent: util.SendEvent,
+	}
+	if c.LogPath != "" {
+		a.writer = &lumberjack.Logger{
+			Filename:   c.LogPath,
+			MaxSize:    c.LogMaxSize,
+			MaxBackups: c.LogMaxBackups,
+			MaxAge:     c.LogMaxAge,
+		}
+	}
+	return a
+}
+
+// IsAudited checks whether the operation should be audited.
+func (a *Auditor) IsAudited(operation string, readonly bool) bool {
+	if a == nil {
+		return false
+	}
+	if len(a.config.Operations) == 0 {
+		return !readonly
+	}
+	return containsOperation(a.config.Operations, operation)
+}
+
+func containsOperation(operations []string, operation string) bool {
+	return slices.Contains(operations, allOperations) || slices.Contains(operations, strings.ToLower(operation))
+}
+
+// Log writes the audit record, and emits it as an event if configured.
+func (a *Auditor) Log(ctx context.Context, record *Record) {
+	record.Parameters = SanitizeParameters(record.Parameters)
+	data, err := json.Marshal(record)
+	if err != nil {
+		logger.Error(err, "marshal audit record failed")
+		return
+	}
+
+	if a.writer != nil {
+		if _, err = a.writer.Write(append(data, '\n')); err != nil {
+			logger.Error(err, "write audit record failed", "record", string(data))
+		}
+	} else {
+		logger.Info("audit", "record", string(data))
+	}
+
+	if containsOperation(a.config.EventOperations, record.Operation) {
+		a.emitEvent(ctx, record)
+	}
+}
+
+func (a *Auditor) emitEvent(ctx context.Context, record *Record) {
+	recordMap := map[string]any{}
+	data, _ := json.Marshal(record)
+	_ = json.Unmarshal(data, &recordMap)
+	event, err := util.CreateEvent(eventReason, recordMap)
+	if err != nil {
+		logger.Error(err, "create audit event failed")
+		return
+	}
+	if record.Result == ResultFailed {
+		event.Type = corev1.EventTypeWarning
+	}
+
+	go func() {
+		_ = a.sendEvent(ctx, event)
+	}()
+}
+
+// SanitizeParameters masks the sensitive values in the parameters.
+func SanitizeParameters(parameters map[string]any) map[string]any {
+	if parameters == nil {
+		return nil
+	}
+
+	sanitized := make(map[string]any, len(parameters))
+	for key, value := range parameters {
+		sanitized[key] = sanitizeValue(key, value)
+	}
+	return sanitized
+}
+
+func sanitizeValue(key string, value any) any {
+	lowerKey := strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(lowerKey, sensitiveKey) {
+			return maskedValue
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if redisACLStatement.MatchString(v) {
+			v = redisACLPassword.ReplaceAllString(v, "${1}${2}"+maskedValue)
+		}
+		return sensitiveStatement.ReplaceAllString(v, "${1}'"+maskedValue+"'")
+	case map[string]any:
+		return SanitizeParameters(v)
+	default:
+		return value
+	}
+}
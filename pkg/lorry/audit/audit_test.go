@@ -0,0 +1,135 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSanitizeParameters(t *testing.T) {
+	assert.Nil(t, SanitizeParameters(nil))
+
+	parameters := map[string]any{
+		"userName": "test",
+		"password": "secret-password",
+		"roleName": "readonly",
+		"sql":      "CREATE USER 'test'@'%' IDENTIFIED BY 'secret-password'",
+		"nested": map[string]any{
+			"authToken": "token",
+			"sql":       "ALTER ROLE test WITH PASSWORD 'secret-password'",
+		},
+		"count": 1,
+		"acl":   "ACL SETUSER test on >secret-password <old-password #5e884898da28 ~* +@all",
+		"cmd":   "SET key >value",
+	}
+	sanitized := SanitizeParameters(parameters)
+	assert.Equal(t, "test", sanitized["userName"])
+	assert.Equal(t, maskedValue, sanitized["password"])
+	assert.Equal(t, "readonly", sanitized["roleName"])
+	assert.Equal(t, "CREATE USER 'test'@'%' IDENTIFIED BY '******'", sanitized["sql"])
+	nested := sanitized["nested"].(map[string]any)
+	assert.Equal(t, maskedValue, nested["authToken"])
+	assert.Equal(t, "ALTER ROLE test WITH PASSWORD '******'", nested["sql"])
+	assert.Equal(t, 1, sanitized["count"])
+	assert.Equal(t, "ACL SETUSER test on >****** <****** #****** ~* +@all", sanitized["acl"])
+	assert.Equal(t, "SET key >value", sanitized["cmd"])
+	// the parameters are not modified
+	assert.Equal(t, "secret-password", parameters["password"])
+}
+
+func TestIsAudited(t *testing.T) {
+	var nilAuditor *Auditor
+	assert.False(t, nilAuditor.IsAudited("exec", false))
+
+	auditor := NewAuditor(Config{})
+	assert.True(t, auditor.IsAudited("exec", false))
+	assert.False(t, auditor.IsAudited("listusers", true))
+
+	auditor = NewAuditor(Config{Operations: []string{"createuser", "listusers"}})
+	assert.True(t, auditor.IsAudited("createUser", false))
+	assert.True(t, auditor.IsAudited("listusers", true))
+	assert.False(t, auditor.IsAudited("exec", false))
+
+	auditor = NewAuditor(Config{Operations: []string{allOperations}})
+	assert.True(t, auditor.IsAudited("getrole", true))
+}
+
+func TestLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	auditor := NewAuditor(Config{
+		LogPath:         logPath,
+		LogMaxSize:      1,
+		EventOperations: []string{"deleteuser"},
+	})
+	events := make(chan *corev1.Event, 2)
+	auditor.sendEvent = func(ctx context.Context, event *corev1.Event) error {
+		events <- event
+		return nil
+	}
+
+	auditor.Log(context.Background(), &Record{
+		Time:       time.Now(),
+		Caller:     "token:admin",
+		Operation:  "createuser",
+		Parameters: map[string]any{"userName": "test", "password": "secret-password"},
+		Result:     ResultSuccess,
+		StatusCode: 204,
+	})
+	auditor.Log(context.Background(), &Record{
+		Time:       time.Now(),
+		Caller:     "system:serviceaccount:kb-system:kubeblocks",
+		Operation:  "deleteuser",
+		Parameters: map[string]any{"userName": "test"},
+		Result:     ResultFailed,
+		StatusCode: 500,
+		Message:    "user not found",
+	})
+
+	data, err := os.ReadFile(logPath)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	assert.NotContains(t, string(data), "secret-password")
+	record := &Record{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), record))
+	assert.Equal(t, "deleteuser", record.Operation)
+	assert.Equal(t, "system:serviceaccount:kb-system:kubeblocks", record.Caller)
+	assert.Equal(t, ResultFailed, record.Result)
+
+	// only the deleteuser record is emitted as an event
+	select {
+	case event := <-events:
+		assert.Equal(t, eventReason, event.Reason)
+		assert.Equal(t, corev1.EventTypeWarning, event.Type)
+		assert.Contains(t, event.Message, "user not found")
+	case <-time.After(time.Second):
+		t.Fatal("no audit event emitted")
+	}
+	assert.Len(t, events, 0)
+}
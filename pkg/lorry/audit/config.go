@@ -0,0 +1,63 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package audit
+
+import (
+	"github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const allOperations = "*"
+
+type Config struct {
+	// LogPath is the file the audit records are written to,
+	// the records are written to the lorry log if it's empty.
+	LogPath string
+	// LogMaxSize is the maximum size in megabytes of the audit log file before it gets rotated.
+	LogMaxSize int
+	// LogMaxBackups is the maximum number of rotated audit log files to retain.
+	LogMaxBackups int
+	// LogMaxAge is the maximum number of days to retain the rotated audit log files.
+	LogMaxAge int
+	// Operations are the operations to audit, "*" means all the operations.
+	// The non-readonly operations are audited if it's empty.
+	Operations []string
+	// EventOperations are the operations whose audit records are also emitted as Kubernetes Events on the pod.
+	EventOperations []string
+}
+
+var config Config
+var logger = ctrl.Log.WithName("Audit")
+
+func init() {
+	pflag.StringVar(&config.LogPath, "audit-log-path", "", "The file the audit records of Lorry operations are written to.")
+	pflag.IntVar(&config.LogMaxSize, "audit-log-max-size", 100, "The maximum size in megabytes of the audit log file before it gets rotated.")
+	pflag.IntVar(&config.LogMaxBackups, "audit-log-max-backups", 10, "The maximum number of rotated audit log files to retain.")
+	pflag.IntVar(&config.LogMaxAge, "audit-log-max-age", 30, "The maximum number of days to retain the rotated audit log files.")
+	pflag.StringSliceVar(&config.Operations, "audit-operations", nil,
+		"The operations to audit, '*' means all the operations. The non-readonly operations are audited by default.")
+	pflag.StringSliceVar(&config.EventOperations, "audit-event-operations", nil,
+		"The operations whose audit records are also emitted as Kubernetes Events, '*' means all the audited operations.")
+}
+
+// GetConfig returns the audit config parsed from the command line flags.
+func GetConfig() Config {
+	return config
+}
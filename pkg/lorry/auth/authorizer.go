@@ -0,0 +1,220 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util/kubernetes"
+)
+
+type Permission int
+
+const (
+	PermissionNone Permission = iota
+	PermissionReadOnly
+	PermissionAdmin
+)
+
+const tokenReviewCacheTTL = time.Minute
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// Request describes the caller of an operation.
+type Request struct {
+	Operation string
+	Readonly  bool
+	// Token is the bearer token of the request.
+	Token string
+	// HasVerifiedCert indicates the client presented a certificate verified by the client CA.
+	HasVerifiedCert bool
+	// CertCommonName is the common name of the verified client certificate.
+	CertCommonName string
+}
+
+// TokenReviewer authenticates a ServiceAccount token and returns the username.
+type TokenReviewer func(ctx context.Context, token string) (string, error)
+
+type Authorizer struct {
+	config         Config
+	adminTokens    map[string]struct{}
+	readonlyTokens map[string]struct{}
+	tokenReviewer  TokenReviewer
+
+	lock        sync.Mutex
+	reviewCache map[string]reviewResult
+}
+
+type reviewResult struct {
+	permission Permission
+	username   string
+	expireAt   time.Time
+}
+
+// NewAuthorizer returns an authorizer for the config, it returns nil if the authentication is disabled.
+func NewAuthorizer(c Config) (*Authorizer, error) {
+	if !c.IsAuthEnabled() {
+		return nil, nil
+	}
+
+	a := &Authorizer{
+		config:      c,
+		reviewCache: map[string]reviewResult{},
+	}
+	var err error
+	if a.adminTokens, err = loadTokens(c.TokenFile); err != nil {
+		return nil, err
+	}
+	if a.readonlyTokens, err = loadTokens(c.ReadOnlyTokenFile); err != nil {
+		return nil, err
+	}
+	if c.ServiceAccountAuth {
+		a.tokenReviewer = reviewServiceAccountToken
+	}
+	return a, nil
+}
+
+func loadTokens(file string) (map[string]struct{}, error) {
+	tokens := map[string]struct{}{}
+	if file == "" {
+		return tokens, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open token file failed: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if token := strings.TrimSpace(scanner.Text()); token != "" {
+			tokens[token] = struct{}{}
+		}
+	}
+	return tokens, scanner.Err()
+}
+
+func reviewServiceAccountToken(ctx context.Context, token string) (string, error) {
+	clientSet, err := kubernetes.GetClientSet()
+	if err != nil {
+		return "", err
+	}
+
+	// only the tokens bound to the lorry audience are accepted, other tokens of the ServiceAccount can't be replayed here.
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{constant.LorryTokenAudience},
+		},
+	}
+	review, err = clientSet.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !review.Status.Authenticated || !slices.Contains(review.Status.Audiences, constant.LorryTokenAudience) {
+		return "", ErrUnauthorized
+	}
+	return review.Status.User.Username, nil
+}
+
+// Authorize checks whether the request is allowed to call the operation,
+// and returns the identity of the caller.
+func (a *Authorizer) Authorize(ctx context.Context, req Request) (string, error) {
+	if a == nil {
+		return "", nil
+	}
+
+	permission, identity := a.getPermission(ctx, req)
+	switch {
+	case slices.Contains(a.config.AnonymousOperations, strings.ToLower(req.Operation)):
+		return identity, nil
+	case permission == PermissionNone:
+		return identity, ErrUnauthorized
+	case permission == PermissionReadOnly && !req.Readonly:
+		logger.Info("read-only permission can't call the operation", "operation", req.Operation, "caller", identity)
+		return identity, ErrForbidden
+	default:
+		return identity, nil
+	}
+}
+
+func (a *Authorizer) getPermission(ctx context.Context, req Request) (Permission, string) {
+	if req.HasVerifiedCert {
+		return PermissionAdmin, "cert:" + req.CertCommonName
+	}
+	if req.Token == "" {
+		return PermissionNone, "anonymous"
+	}
+	if _, ok := a.adminTokens[req.Token]; ok {
+		return PermissionAdmin, "token:admin"
+	}
+	if _, ok := a.readonlyTokens[req.Token]; ok {
+		return PermissionReadOnly, "token:readonly"
+	}
+	if a.tokenReviewer == nil {
+		return PermissionNone, "token:unknown"
+	}
+	return a.reviewToken(ctx, req.Token)
+}
+
+func (a *Authorizer) reviewToken(ctx context.Context, token string) (Permission, string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if result, ok := a.reviewCache[token]; ok && time.Now().Before(result.expireAt) {
+		return result.permission, result.username
+	}
+
+	permission := PermissionNone
+	username, err := a.tokenReviewer(ctx, token)
+	switch {
+	case err != nil:
+		logger.Info("review ServiceAccount token failed", "error", err.Error())
+		return permission, "token:unknown"
+	case slices.Contains(a.config.AdminServiceAccounts, username):
+		permission = PermissionAdmin
+	case strings.HasPrefix(username, "system:serviceaccount:"):
+		permission = PermissionReadOnly
+	}
+
+	for key, result := range a.reviewCache {
+		if time.Now().After(result.expireAt) {
+			delete(a.reviewCache, key)
+		}
+	}
+	a.reviewCache[token] = reviewResult{permission: permission, username: username, expireAt: time.Now().Add(tokenReviewCacheTTL)}
+	return permission, username
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTokenFile(t *testing.T, name string, content string) string {
+	file := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(file, []byte(content), 0600))
+	return file
+}
+
+func TestNewAuthorizer(t *testing.T) {
+	authorizer, err := NewAuthorizer(Config{})
+	assert.Nil(t, err)
+	assert.Nil(t, authorizer)
+	// a nil authorizer allows all the requests
+	_, err = authorizer.Authorize(context.Background(), Request{Operation: "exec"})
+	assert.Nil(t, err)
+
+	_, err = NewAuthorizer(Config{TokenFile: "/not/exist"})
+	assert.NotNil(t, err)
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+	authorizer, err := NewAuthorizer(Config{
+		TokenFile:            writeTokenFile(t, "admin", "admin-token\n\n"),
+		ReadOnlyTokenFile:    writeTokenFile(t, "readonly", "readonly-token\n"),
+		AdminServiceAccounts: []string{"system:serviceaccount:kb-system:kubeblocks"},
+		AnonymousOperations:  []string{"checkrole"},
+	})
+	assert.Nil(t, err)
+	reviewed := 0
+	authorizer.tokenReviewer = func(ctx context.Context, token string) (string, error) {
+		reviewed++
+		switch token {
+		case "operator-token":
+			return "system:serviceaccount:kb-system:kubeblocks", nil
+		case "pod-token":
+			return "system:serviceaccount:default:default", nil
+		default:
+			return "", errors.New("invalid token")
+		}
+	}
+
+	operator := "system:serviceaccount:kb-system:kubeblocks"
+	testCases := []struct {
+		name             string
+		req              Request
+		expectedIdentity string
+		expectedErr      error
+	}{
+		{"anonymous operation", Request{Operation: "checkRole"}, "anonymous", nil},
+		{"no credentials", Request{Operation: "listusers", Readonly: true}, "anonymous", ErrUnauthorized},
+		{"verified cert", Request{Operation: "exec", HasVerifiedCert: true, CertCommonName: "kubeblocks"}, "cert:kubeblocks", nil},
+		{"admin token", Request{Operation: "exec", Token: "admin-token"}, "token:admin", nil},
+		{"readonly token on readonly operation", Request{Operation: "listusers", Readonly: true, Token: "readonly-token"}, "token:readonly", nil},
+		{"readonly token on admin operation", Request{Operation: "deleteuser", Token: "readonly-token"}, "token:readonly", ErrForbidden},
+		{"admin ServiceAccount", Request{Operation: "switchover", Token: "operator-token"}, operator, nil},
+		{"other ServiceAccount on readonly operation", Request{Operation: "getrole", Readonly: true, Token: "pod-token"}, "system:serviceaccount:default:default", nil},
+		{"other ServiceAccount on admin operation", Request{Operation: "deleteuser", Token: "pod-token"}, "system:serviceaccount:default:default", ErrForbidden},
+		{"invalid token", Request{Operation: "getrole", Readonly: true, Token: "invalid-token"}, "token:unknown", ErrUnauthorized},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := authorizer.Authorize(ctx, tc.req)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedIdentity, identity)
+		})
+	}
+
+	// the reviewed tokens are cached
+	reviewed = 0
+	identity, err := authorizer.Authorize(ctx, Request{Operation: "exec", Token: "operator-token"})
+	assert.Nil(t, err)
+	assert.Equal(t, operator, identity)
+	assert.Equal(t, 0, reviewed)
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	tlsConfig, err := ServerTLSConfig(Config{})
+	assert.Nil(t, err)
+	assert.Nil(t, tlsConfig)
+
+	_, err = ServerTLSConfig(Config{TLSCertFile: "/not/exist", TLSKeyFile: "/not/exist"})
+	assert.NotNil(t, err)
+}
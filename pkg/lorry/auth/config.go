@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type Config struct {
+	// TLSCertFile and TLSKeyFile enable TLS on the lorry listeners.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile verifies the client certificates, a client with a verified
+	// certificate is granted the admin permission.
+	TLSClientCAFile string
+
+	// TokenFile contains the bearer tokens granted the admin permission, one per line.
+	TokenFile string
+	// ReadOnlyTokenFile contains the bearer tokens granted the read-only permission, one per line.
+	ReadOnlyTokenFile string
+	// ServiceAccountAuth authenticates the bearer tokens as ServiceAccount tokens with the TokenReview API.
+	ServiceAccountAuth bool
+	// AdminServiceAccounts are the ServiceAccounts granted the admin permission,
+	// in the form of system:serviceaccount:<namespace>:<name>. Other authenticated
+	// ServiceAccounts are granted the read-only permission.
+	AdminServiceAccounts []string
+	// AnonymousOperations are the operations allowed without authentication,
+	// such as the ones called by the kubelet probes.
+	AnonymousOperations []string
+}
+
+var config Config
+var logger = ctrl.Log.WithName("Auth")
+
+func init() {
+	pflag.StringVar(&config.TLSCertFile, "tls-cert-file", "", "The TLS certificate file for Lorry service.")
+	pflag.StringVar(&config.TLSKeyFile, "tls-key-file", "", "The TLS private key file for Lorry service.")
+	pflag.StringVar(&config.TLSClientCAFile, "tls-client-ca-file", "", "The CA file to verify the client certificates of Lorry service.")
+	pflag.StringVar(&config.TokenFile, "auth-token-file", "", "The file contains the bearer tokens with admin permission.")
+	pflag.StringVar(&config.ReadOnlyTokenFile, "auth-readonly-token-file", "", "The file contains the bearer tokens with read-only permission.")
+	pflag.BoolVar(&config.ServiceAccountAuth, "auth-serviceaccount", false, "Authenticate the bearer tokens as ServiceAccount tokens.")
+	pflag.StringSliceVar(&config.AdminServiceAccounts, "auth-admin-serviceaccounts", nil, "The ServiceAccounts with admin permission.")
+	pflag.StringSliceVar(&config.AnonymousOperations, "auth-anonymous-operations", []string{"checkrole", "checkrunning", "volumeprotection"},
+		"The operations allowed without authentication.")
+}
+
+// GetConfig returns the auth config parsed from the command line flags.
+func GetConfig() Config {
+	return config
+}
+
+// IsTLSEnabled checks whether TLS is enabled.
+func (c Config) IsTLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// IsAuthEnabled checks whether the requests need to be authenticated.
+func (c Config) IsAuthEnabled() bool {
+	return c.TLSClientCAFile != "" || c.TokenFile != "" || c.ReadOnlyTokenFile != "" || c.ServiceAccountAuth
+}
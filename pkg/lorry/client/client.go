@@ -22,6 +22,7 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	corev1 "k8s.io/api/core/v1"
@@ -199,6 +200,30 @@ func (cli *lorryClient) ListSystemAccounts(ctx context.Context) ([]map[string]an
 	return convertToArrayOfMap(systemAccounts)
 }
 
+// UpdateUserPassword updates the password of the user, the old password keeps valid if retainOld is true
+// and the engine supports multiple passwords.
+func (cli *lorryClient) UpdateUserPassword(ctx context.Context, userName, password string, retainOld bool) error {
+	parameters := map[string]any{
+		"userName":          userName,
+		"password":          password,
+		"retainOldPassword": retainOld,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(UpdateUserPasswordOp), http.MethodPost, req)
+	return err
+}
+
+// DiscardUserOldPassword discards the old password retained by UpdateUserPassword.
+func (cli *lorryClient) DiscardUserOldPassword(ctx context.Context, userName, oldPassword string) error {
+	parameters := map[string]any{
+		"userName":    userName,
+		"oldPassword": oldPassword,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(DiscardUserOldPasswordOp), http.MethodPost, req)
+	return err
+}
+
 // JoinMember sends a join member operation request to Lorry, located on the target pod that is about to join.
 func (cli *lorryClient) JoinMember(ctx context.Context) error {
 	_, err := cli.Request(ctx, string(JoinMemberOperation), http.MethodPost, nil)
@@ -211,6 +236,49 @@ func (cli *lorryClient) LeaveMember(ctx context.Context) error {
 	return err
 }
 
+// CheckStatus sends a checkstatus operation request to Lorry, it returns error if the target replica is unhealthy.
+func (cli *lorryClient) CheckStatus(ctx context.Context) error {
+	resp, err := cli.Request(ctx, string(CheckStatusOperation), http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+	if event, ok := resp[RespFieldEvent]; ok && event == OperationFailed {
+		return fmt.Errorf("status check failed: %v", resp[RespFieldMessage])
+	}
+	return nil
+}
+
+// Exec sends an exec operation request to Lorry, which executes the statement in the database of the target replica.
+func (cli *lorryClient) Exec(ctx context.Context, statement string) error {
+	parameters := map[string]any{
+		"sql": statement,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(ExecOperation), http.MethodPost, req)
+	return err
+}
+
+// GetParameters returns the values of the parameters which are effective in the database.
+func (cli *lorryClient) GetParameters(ctx context.Context, names []string) (map[string]string, error) {
+	parameters := map[string]any{
+		"names": names,
+	}
+	req := map[string]any{"parameters": parameters}
+	resp, err := cli.Request(ctx, string(GetParametersOp), http.MethodGet, req)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := resp["parameters"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	result := make(map[string]string, len(values))
+	for name, value := range values {
+		result[name] = fmt.Sprint(value)
+	}
+	return result, nil
+}
+
 func (cli *lorryClient) Request(ctx context.Context, operation, method string, req map[string]any) (map[string]any, error) {
 	if cli.requester == nil {
 		return nil, errors.New("lorry client's requester must be set")
@@ -55,6 +55,20 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CheckStatus mocks base method.
+func (m *MockClient) CheckStatus(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckStatus indicates an expected call of CheckStatus.
+func (mr *MockClientMockRecorder) CheckStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckStatus", reflect.TypeOf((*MockClient)(nil).CheckStatus), arg0)
+}
+
 // CreateUser mocks base method.
 func (m *MockClient) CreateUser(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -98,6 +112,49 @@ func (mr *MockClientMockRecorder) DescribeUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeUser", reflect.TypeOf((*MockClient)(nil).DescribeUser), arg0, arg1)
 }
 
+// DiscardUserOldPassword mocks base method.
+func (m *MockClient) DiscardUserOldPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardUserOldPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardUserOldPassword indicates an expected call of DiscardUserOldPassword.
+func (mr *MockClientMockRecorder) DiscardUserOldPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardUserOldPassword", reflect.TypeOf((*MockClient)(nil).DiscardUserOldPassword), arg0, arg1, arg2)
+}
+
+// Exec mocks base method.
+func (m *MockClient) Exec(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockClientMockRecorder) Exec(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockClient)(nil).Exec), arg0, arg1)
+}
+
+// GetParameters mocks base method.
+func (m *MockClient) GetParameters(arg0 context.Context, arg1 []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParameters", arg0, arg1)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParameters indicates an expected call of GetParameters.
+func (mr *MockClientMockRecorder) GetParameters(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameters", reflect.TypeOf((*MockClient)(nil).GetParameters), arg0, arg1)
+}
+
 // GetRole mocks base method.
 func (m *MockClient) GetRole(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -212,3 +269,17 @@ func (mr *MockClientMockRecorder) Switchover(arg0, arg1, arg2, arg3 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Switchover", reflect.TypeOf((*MockClient)(nil).Switchover), arg0, arg1, arg2, arg3)
 }
+
+// UpdateUserPassword mocks base method.
+func (m *MockClient) UpdateUserPassword(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockClientMockRecorder) UpdateUserPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockClient)(nil).UpdateUserPassword), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// the environment variables to configure the credentials of the lorry client
+	lorryClientTokenFileEnv = "LORRY_CLIENT_TOKEN_FILE"
+	lorryClientCAFileEnv    = "LORRY_CLIENT_CA_FILE"
+	lorryClientCertFileEnv  = "LORRY_CLIENT_CERT_FILE"
+	lorryClientKeyFileEnv   = "LORRY_CLIENT_KEY_FILE"
+
+	lorryTLSCertFileFlag = "--tls-cert-file"
+	lorryAuthFlagPrefix  = "--auth-"
+
+	// the requested tokens are short-lived and refreshed before they expire.
+	lorryTokenExpirationSeconds = int64(600)
+	lorryTokenRefreshRatio      = 0.8
+)
+
+// tokenSource returns the bearer token sent to lorry.
+type tokenSource func(ctx context.Context) (string, error)
+
+// newFileTokenSource reads the token file every time, as the token may be rotated.
+func newFileTokenSource(tokenFile string) tokenSource {
+	return func(ctx context.Context) (string, error) {
+		token, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+}
+
+var (
+	serviceAccountTokenLock   sync.Mutex
+	serviceAccountToken       string
+	serviceAccountTokenExpire time.Time
+)
+
+// requestServiceAccountToken requests a short-lived token of the KubeBlocks ServiceAccount with the TokenRequest API,
+// the token is bound to the lorry audience, so it can't be used to access the API server or other services.
+func requestServiceAccountToken(ctx context.Context) (string, error) {
+	serviceAccountTokenLock.Lock()
+	defer serviceAccountTokenLock.Unlock()
+
+	if serviceAccountToken != "" && time.Now().Before(serviceAccountTokenExpire) {
+		return serviceAccountToken, nil
+	}
+
+	clientSet, err := getClientSet()
+	if err != nil {
+		return "", err
+	}
+	expirationSeconds := lorryTokenExpirationSeconds
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{constant.LorryTokenAudience},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+	name := viper.GetString(constant.KBServiceAccountName)
+	tokenRequest, err = clientSet.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("request token of ServiceAccount %s/%s failed: %v", namespace, name, err)
+	}
+
+	lifetime := time.Until(tokenRequest.Status.ExpirationTimestamp.Time)
+	serviceAccountToken = tokenRequest.Status.Token
+	serviceAccountTokenExpire = time.Now().Add(time.Duration(float64(lifetime) * lorryTokenRefreshRatio))
+	return serviceAccountToken, nil
+}
+
+// getTokenSource returns the source of the bearer token sent to lorry, it returns nil if no token is needed.
+// The token is only sent over TLS with the server verified, so it can't be intercepted by a spoofed lorry.
+func getTokenSource(tlsVerified, authEnabled, allowServiceAccountToken bool) tokenSource {
+	if !tlsVerified || !authEnabled {
+		return nil
+	}
+	if viper.IsSet(lorryClientTokenFileEnv) {
+		return newFileTokenSource(viper.GetString(lorryClientTokenFileEnv))
+	}
+	if allowServiceAccountToken {
+		return requestServiceAccountToken
+	}
+	return nil
+}
+
+// isLorryTLSEnabled checks whether the lorry in the pod serves with TLS.
+func isLorryTLSEnabled(pod *corev1.Pod) bool {
+	return hasLorryArgPrefix(pod, lorryTLSCertFileFlag)
+}
+
+// isLorryAuthEnabled checks whether the lorry in the pod authenticates the requests.
+func isLorryAuthEnabled(pod *corev1.Pod) bool {
+	return hasLorryArgPrefix(pod, lorryAuthFlagPrefix)
+}
+
+func hasLorryArgPrefix(pod *corev1.Pod, prefix string) bool {
+	container := intctrlutil.GetLorryContainer(pod.Spec.Containers)
+	if container == nil {
+		return false
+	}
+	for _, arg := range append(container.Command, container.Args...) {
+		if strings.HasPrefix(arg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// newPodTLSConfig returns the TLS config to verify the lorry in the pod. The certificate of lorry is issued for
+// the pod DNS name, and it's verified by the configured CA or the CA mounted to the pod.
+func newPodTLSConfig(ctx context.Context, pod *corev1.Pod) (*tls.Config, error) {
+	caPEM, err := readConfiguredCA()
+	if err != nil {
+		return nil, err
+	}
+	if caPEM == nil {
+		if caPEM, err = readPodCA(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+	tlsConfig, err := newClientTLSConfig(caPEM)
+	if err != nil {
+		return nil, err
+	}
+	headlessSvcName := constant.GenerateDefaultComponentHeadlessServiceName(pod.Labels[constant.AppInstanceLabelKey],
+		pod.Labels[constant.KBAppComponentLabelKey])
+	tlsConfig.ServerName = fmt.Sprintf("%s.%s.%s.svc", pod.Name, headlessSvcName, pod.Namespace)
+	return tlsConfig, nil
+}
+
+func readConfiguredCA() ([]byte, error) {
+	caFile := viper.GetString(lorryClientCAFileEnv)
+	if caFile == "" {
+		return nil, nil
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file failed: %v", err)
+	}
+	return caPEM, nil
+}
+
+// readPodCA reads the CA from the secret of the TLS volume, which the certificate of lorry is issued by.
+func readPodCA(ctx context.Context, pod *corev1.Pod) ([]byte, error) {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name != constant.VolumeName || volume.Secret == nil {
+			continue
+		}
+		key := constant.CAName
+		for _, item := range volume.Secret.Items {
+			if item.Path == constant.CAName {
+				key = item.Key
+			}
+		}
+		clientSet, err := getClientSet()
+		if err != nil {
+			return nil, err
+		}
+		secret, err := clientSet.CoreV1().Secrets(pod.Namespace).Get(ctx, volume.Secret.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("get the CA of lorry failed: %v", err)
+		}
+		if len(secret.Data[key]) == 0 {
+			break
+		}
+		return secret.Data[key], nil
+	}
+	return nil, fmt.Errorf("lorry of pod %s serves with TLS, but no CA is found to verify it", pod.Name)
+}
+
+// newClientTLSConfig returns the TLS config to verify lorry, it refuses to connect if no CA is given,
+// as the credentials can't be sent to an unverified server.
+func newClientTLSConfig(caPEM []byte) (*tls.Config, error) {
+	if caPEM == nil {
+		return nil, fmt.Errorf("lorry serves with TLS, but the CA to verify it is not configured by %s", lorryClientCAFileEnv)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no valid certificates in the CA of lorry")
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+	}
+
+	certFile := viper.GetString(lorryClientCertFileEnv)
+	keyFile := viper.GetString(lorryClientKeyFileEnv)
+	if certFile != "" && keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client key pair failed: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func getClientSet() (*kubernetes.Clientset, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
@@ -40,7 +40,7 @@ import (
 )
 
 const (
-	urlTemplate = "http://%s:%d/v1.0/"
+	urlTemplate = "%s://%s:%d/v1.0/"
 )
 
 var NotImplemented = errors.New("NotImplemented")
@@ -53,6 +53,7 @@ type HTTPClient struct {
 	CacheTTL         time.Duration
 	ReconcileTimeout time.Duration
 	RequestTimeout   time.Duration
+	tokenSource      tokenSource
 	logger           logr.Logger
 }
 
@@ -77,13 +78,15 @@ func NewHTTPClientWithPod(pod *corev1.Pod) (*HTTPClient, error) {
 		return nil, nil
 	}
 
-	// don't use default http-client
-	dialer := &net.Dialer{
-		Timeout: 5 * time.Second,
-	}
-	netTransport := &http.Transport{
-		Dial:                dialer.Dial,
-		TLSHandshakeTimeout: 5 * time.Second,
+	scheme := "http"
+	netTransport := newTransport()
+	if isLorryTLSEnabled(pod) {
+		tlsConfig, err := newPodTLSConfig(context.Background(), pod)
+		if err != nil {
+			return nil, err
+		}
+		netTransport.TLSClientConfig = tlsConfig
+		scheme = "https"
 	}
 	client := &http.Client{
 		Timeout:   time.Second * 30,
@@ -92,10 +95,11 @@ func NewHTTPClientWithPod(pod *corev1.Pod) (*HTTPClient, error) {
 
 	operationClient := &HTTPClient{
 		Client:           client,
-		URL:              fmt.Sprintf(urlTemplate, ip, port),
+		URL:              fmt.Sprintf(urlTemplate, scheme, ip, port),
 		CacheTTL:         60 * time.Second,
 		RequestTimeout:   30 * time.Second,
 		ReconcileTimeout: 500 * time.Millisecond,
+		tokenSource:      getTokenSource(netTransport.TLSClientConfig != nil, isLorryAuthEnabled(pod), true),
 		cache:            make(map[string]*OperationResult),
 		logger:           ctrl.Log.WithName("Lorry HTTP client"),
 	}
@@ -108,13 +112,15 @@ func NewHTTPClientWithURL(url string) (*HTTPClient, error) {
 		return nil, fmt.Errorf("no url")
 	}
 
-	// don't use default http-client
-	dialer := &net.Dialer{
-		Timeout: 5 * time.Second,
-	}
-	netTransport := &http.Transport{
-		Dial:                dialer.Dial,
-		TLSHandshakeTimeout: 5 * time.Second,
+	netTransport := newTransport()
+	if strings.HasPrefix(url, "https://") {
+		caPEM, err := readConfiguredCA()
+		if err != nil {
+			return nil, err
+		}
+		if netTransport.TLSClientConfig, err = newClientTLSConfig(caPEM); err != nil {
+			return nil, err
+		}
 	}
 	client := &http.Client{
 		Timeout:   time.Second * 30,
@@ -127,12 +133,25 @@ func NewHTTPClientWithURL(url string) (*HTTPClient, error) {
 		CacheTTL:         60 * time.Second,
 		RequestTimeout:   30 * time.Second,
 		ReconcileTimeout: 500 * time.Millisecond,
-		cache:            make(map[string]*OperationResult),
+		// the lorry serving the url is unknown, the configured token is sent if the server is verified.
+		tokenSource: getTokenSource(netTransport.TLSClientConfig != nil, true, false),
+		cache:       make(map[string]*OperationResult),
 	}
 	operationClient.lorryClient = lorryClient{requester: operationClient}
 	return operationClient, nil
 }
 
+// newTransport returns a transport instead of using the default http-client.
+func newTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+	}
+	return &http.Transport{
+		Dial:                dialer.Dial,
+		TLSHandshakeTimeout: 5 * time.Second,
+	}
+}
+
 func (cli *HTTPClient) Request(ctx context.Context, operation, method string, req map[string]any) (map[string]any, error) {
 	ctxWithReconcileTimeout, cancel := context.WithTimeout(ctx, cli.ReconcileTimeout)
 	defer cancel()
@@ -217,6 +236,20 @@ func (cli *HTTPClient) InvokeComponent(ctxWithReconcileTimeout context.Context,
 		}
 	}
 
+	// set the token after the cache key is computed, as the token may be rotated
+	if cli.tokenSource != nil {
+		token, err := cli.tokenSource(ctxWithRequestTimeout)
+		if err != nil {
+			ch <- &OperationResult{
+				response: nil,
+				err:      errors.Wrap(err, "get the token of lorry failed"),
+				respTime: time.Now(),
+			}
+			return
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
 	resp, err := cli.Client.Do(req)
 	operationRes = &OperationResult{
 		response: resp,
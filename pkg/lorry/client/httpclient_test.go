@@ -21,9 +21,12 @@ package client
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +40,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 const (
@@ -89,6 +93,47 @@ var _ = Describe("Lorry HTTP Client", func() {
 			lorryClient, err := NewHTTPClientWithPod(pod)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(lorryClient).ShouldNot(BeNil())
+			Expect(lorryClient.URL).Should(HavePrefix("http://"))
+			Expect(lorryClient.tokenSource).Should(BeNil())
+		})
+
+		It("with TLS but no CA, failed", func() {
+			podWithTLS := pod.DeepCopy()
+			podWithTLS.Spec.Containers[0].Command = append(podWithTLS.Spec.Containers[0].Command, "--tls-cert-file", "/etc/pki/tls/tls.crt")
+			_, err := NewHTTPClientWithPod(podWithTLS)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("with TLS, send the token only if the auth is enabled", func() {
+			tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+			defer tlsServer.Close()
+			tmpDir := GinkgoT().TempDir()
+			caFile := filepath.Join(tmpDir, "ca.crt")
+			caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+			Expect(os.WriteFile(caFile, caPEM, 0600)).Should(Succeed())
+			tokenFile := filepath.Join(tmpDir, "token")
+			Expect(os.WriteFile(tokenFile, []byte("lorry-token\n"), 0600)).Should(Succeed())
+			viper.Set(lorryClientCAFileEnv, caFile)
+			viper.Set(lorryClientTokenFileEnv, tokenFile)
+			defer func() {
+				viper.Set(lorryClientCAFileEnv, "")
+				viper.Set(lorryClientTokenFileEnv, nil)
+			}()
+
+			podWithTLS := pod.DeepCopy()
+			podWithTLS.Spec.Containers[0].Command = append(podWithTLS.Spec.Containers[0].Command, "--tls-cert-file", "/etc/pki/tls/tls.crt")
+			lorryClient, err := NewHTTPClientWithPod(podWithTLS)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lorryClient.URL).Should(HavePrefix("https://"))
+			Expect(lorryClient.tokenSource).Should(BeNil())
+
+			podWithTLS.Spec.Containers[0].Command = append(podWithTLS.Spec.Containers[0].Command, "--auth-serviceaccount")
+			lorryClient, err = NewHTTPClientWithPod(podWithTLS)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lorryClient.tokenSource).ShouldNot(BeNil())
+			token, err := lorryClient.tokenSource(context.Background())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(token).Should(Equal("lorry-token"))
 		})
 	})
 
@@ -33,6 +33,8 @@ type Client interface {
 	RevokeUserRole(ctx context.Context, userName, roleName string) error
 	ListUsers(ctx context.Context) ([]map[string]any, error)
 	ListSystemAccounts(ctx context.Context) ([]map[string]any, error)
+	UpdateUserPassword(ctx context.Context, userName, password string, retainOld bool) error
+	DiscardUserOldPassword(ctx context.Context, userName, oldPassword string) error
 
 	// JoinMember sends a join member operation request to Lorry, located on the target pod that is about to join.
 	JoinMember(ctx context.Context) error
@@ -41,4 +43,14 @@ type Client interface {
 	LeaveMember(ctx context.Context) error
 
 	Switchover(ctx context.Context, primary, candidate string, force bool) error
+
+	// CheckStatus sends a checkstatus operation request to Lorry, it returns error if the target replica is unhealthy.
+	CheckStatus(ctx context.Context) error
+
+	// Exec sends an exec operation request to Lorry, which executes the statement in the database of the target replica.
+	Exec(ctx context.Context, statement string) error
+
+	// GetParameters sends a getParameters operation request to Lorry, and returns the values of the parameters
+	// which are effective in the database of the target replica, the parameters not found are absent in the result.
+	GetParameters(ctx context.Context, names []string) (map[string]string, error)
 }
@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package dcs
 
 import (
+	"fmt"
+
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -58,12 +60,31 @@ type DCS interface {
 	GetLeader() (*Leader, error)
 }
 
+// the dcs store types, selected by the --dcs-type flag.
+const (
+	KubernetesStoreType = "kubernetes"
+	LeaseStoreType      = "lease"
+	EtcdStoreType       = "etcd"
+)
+
+// the flags to configure the dcs store.
+const (
+	TypeFlag          = "dcs-type"
+	EtcdEndpointsFlag = "dcs-etcd-endpoints"
+	EtcdPrefixFlag    = "dcs-etcd-prefix"
+	EtcdCAFileFlag    = "dcs-etcd-ca-file"
+	EtcdCertFileFlag  = "dcs-etcd-cert-file"
+	EtcdKeyFileFlag   = "dcs-etcd-key-file"
+)
+
 var dcs DCS
 
 func init() {
 	viper.SetDefault(constant.KBEnvTTL, 15)
 	viper.SetDefault(constant.KBEnvMaxLag, 10)
 	viper.SetDefault(constant.KubernetesClusterDomainEnv, constant.DefaultDNSDomain)
+	viper.SetDefault(TypeFlag, KubernetesStoreType)
+	viper.SetDefault(EtcdPrefixFlag, "/kubeblocks")
 }
 
 func SetStore(d DCS) {
@@ -75,7 +96,18 @@ func GetStore() DCS {
 }
 
 func InitStore() error {
-	store, err := NewKubernetesStore()
+	var store DCS
+	var err error
+	switch storeType := viper.GetString(TypeFlag); storeType {
+	case KubernetesStoreType:
+		store, err = NewKubernetesStore()
+	case LeaseStoreType:
+		store, err = NewKubernetesLeaseStore()
+	case EtcdStoreType:
+		store, err = NewEtcdStore()
+	default:
+		err = fmt.Errorf("unsupported dcs type: %s", storeType)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,475 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dcs
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	etcdDialTimeout    = 5 * time.Second
+	etcdRequestTimeout = 5 * time.Second
+)
+
+// EtcdStore keeps the leader lock, the ha config and the switchover in an etcd cluster,
+// which takes the load of the HA cycles off the API server. The cluster and its
+// members are still read from the Kubernetes API.
+type EtcdStore struct {
+	*KubernetesStore
+	etcdClient *clientv3.Client
+	prefix     string
+}
+
+var _ DCS = &EtcdStore{}
+
+type etcdLeader struct {
+	Leader      string   `json:"leader"`
+	AcquireTime int64    `json:"acquireTime"`
+	RenewTime   int64    `json:"renewTime"`
+	TTL         int      `json:"ttl"`
+	DBState     *DBState `json:"dbState,omitempty"`
+	// ClusterUID identifies the leader lock left by a previous cluster with the same name.
+	ClusterUID string `json:"clusterUID,omitempty"`
+}
+
+// etcdLeaderResource is the Leader.Resource of EtcdStore.
+type etcdLeaderResource struct {
+	leader      etcdLeader
+	modRevision int64
+}
+
+type etcdHaConfig struct {
+	TTL                int                       `json:"ttl"`
+	Enable             bool                      `json:"enable"`
+	MaxLagOnSwitchover int64                     `json:"maxLagOnSwitchover"`
+	DeleteMembers      map[string]MemberToDelete `json:"deleteMembers,omitempty"`
+}
+
+type etcdSwitchover struct {
+	Leader      string `json:"leader"`
+	Candidate   string `json:"candidate"`
+	ScheduledAt int64  `json:"scheduledAt,omitempty"`
+}
+
+func NewEtcdStore() (*EtcdStore, error) {
+	endpoints := viper.GetStringSlice(EtcdEndpointsFlag)
+	if len(endpoints) == 0 {
+		return nil, errors.Errorf("%s must be set for etcd dcs", EtcdEndpointsFlag)
+	}
+
+	var tlsConfig *tls.Config
+	tlsInfo := transport.TLSInfo{
+		CertFile:      viper.GetString(EtcdCertFileFlag),
+		KeyFile:       viper.GetString(EtcdKeyFileFlag),
+		TrustedCAFile: viper.GetString(EtcdCAFileFlag),
+	}
+	if !tlsInfo.Empty() || tlsInfo.TrustedCAFile != "" {
+		var err error
+		tlsConfig, err = tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, errors.Wrap(err, "etcd tls config init failed")
+		}
+	}
+
+	etcdClient, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: etcdDialTimeout,
+		TLS:         tlsConfig,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "etcd client init failed")
+	}
+
+	store, err := NewKubernetesStore()
+	if err != nil {
+		_ = etcdClient.Close()
+		return nil, err
+	}
+	return newEtcdStore(store, etcdClient, viper.GetString(EtcdPrefixFlag)), nil
+}
+
+func newEtcdStore(store *KubernetesStore, etcdClient *clientv3.Client, prefix string) *EtcdStore {
+	store.logger = ctrl.Log.WithName("DCS-ETCD")
+	return &EtcdStore{
+		KubernetesStore: store,
+		etcdClient:      etcdClient,
+		prefix:          prefix,
+	}
+}
+
+func (store *EtcdStore) Initialize() error {
+	store.logger.Info("etcd store initializing")
+	_, err := store.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	err = store.CreateHaConfig()
+	if err != nil {
+		store.logger.Error(err, "Create Ha config failed")
+	}
+
+	err = store.CreateLease()
+	if err != nil {
+		store.logger.Error(err, "Create Leader lock failed")
+	}
+	return err
+}
+
+func (store *EtcdStore) GetClusterFromCache() *Cluster {
+	if store.cluster != nil {
+		return store.cluster
+	}
+	cluster, _ := store.GetCluster()
+	return cluster
+}
+
+func (store *EtcdStore) GetCluster() (*Cluster, error) {
+	return store.getCluster(store)
+}
+
+// DeleteCluster deletes all the keys of the component.
+func (store *EtcdStore) DeleteCluster() {
+	ctx, cancel := context.WithTimeout(store.ctx, etcdRequestTimeout)
+	defer cancel()
+	_, err := store.etcdClient.Delete(ctx, store.getKey("")+"/", clientv3.WithPrefix())
+	if err != nil {
+		store.logger.Error(err, "Delete cluster keys failed")
+	}
+}
+
+func (store *EtcdStore) getEtcdLeader() (*etcdLeaderResource, error) {
+	record := &etcdLeader{}
+	modRevision, err := store.get(store.getKey("leader"), record)
+	if err != nil || modRevision == 0 {
+		return nil, err
+	}
+	return &etcdLeaderResource{leader: *record, modRevision: modRevision}, nil
+}
+
+func (store *EtcdStore) IsLeaseExist() (bool, error) {
+	resource, err := store.getEtcdLeader()
+	if err != nil || resource == nil {
+		return false, err
+	}
+
+	clusterUID := store.getClusterUID()
+	if resource.leader.ClusterUID != "" && clusterUID != "" && resource.leader.ClusterUID != clusterUID {
+		store.logger.Info("A previous leader lock exists, delete the keys of the previous cluster")
+		store.DeleteCluster()
+		return false, nil
+	}
+	return true, nil
+}
+
+func (store *EtcdStore) CreateLease() error {
+	isExist, err := store.IsLeaseExist()
+	if isExist || err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	record := etcdLeader{
+		Leader:      store.currentMemberName,
+		AcquireTime: now,
+		RenewTime:   now,
+		TTL:         viper.GetInt(constant.KBEnvTTL),
+		ClusterUID:  store.getClusterUID(),
+	}
+	store.logger.Info(fmt.Sprintf("Etcd store initializing, create leader lock: %s", store.getKey("leader")))
+	_, err = store.put(store.getKey("leader"), record, 0)
+	return err
+}
+
+func (store *EtcdStore) GetLeader() (*Leader, error) {
+	resource, err := store.getEtcdLeader()
+	if err != nil || resource == nil {
+		return nil, err
+	}
+
+	record := resource.leader
+	leader := record.Leader
+	if record.TTL > 0 && time.Now().Unix()-record.RenewTime > int64(record.TTL) {
+		store.logger.Info(fmt.Sprintf("lock expired: %v, now: %d", record, time.Now().Unix()))
+		leader = ""
+	}
+
+	return &Leader{
+		Index:       strconv.FormatInt(resource.modRevision, 10),
+		Name:        leader,
+		Holder:      record.Leader,
+		AcquireTime: record.AcquireTime,
+		RenewTime:   record.RenewTime,
+		TTL:         record.TTL,
+		Resource:    resource,
+		DBState:     record.DBState,
+	}, nil
+}
+
+func (store *EtcdStore) DeleteLeader() error {
+	ctx, cancel := context.WithTimeout(store.ctx, etcdRequestTimeout)
+	defer cancel()
+	_, err := store.etcdClient.Delete(ctx, store.getKey("leader"))
+	if err != nil {
+		store.logger.Error(err, "Delete leader lock failed")
+	}
+	return err
+}
+
+func (store *EtcdStore) AttemptAcquireLease() error {
+	now := time.Now().Unix()
+	resource := store.cluster.Leader.Resource.(*etcdLeaderResource)
+	record := resource.leader
+	record.Leader = store.currentMemberName
+	record.TTL = store.cluster.HaConfig.ttl
+	record.AcquireTime = now
+	record.RenewTime = now
+	record.DBState = store.cluster.Leader.DBState
+
+	modRevision, err := store.put(store.getKey("leader"), record, resource.modRevision)
+	if err != nil {
+		store.logger.Error(err, "Acquire lease failed")
+		return err
+	}
+
+	store.cluster.Leader.Resource = &etcdLeaderResource{leader: record, modRevision: modRevision}
+	store.cluster.Leader.AcquireTime = now
+	store.cluster.Leader.RenewTime = now
+	return nil
+}
+
+func (store *EtcdStore) UpdateLease() error {
+	resource := store.cluster.Leader.Resource.(*etcdLeaderResource)
+	record := resource.leader
+	if record.Leader != store.currentMemberName {
+		return errors.Errorf("lost lease")
+	}
+	record.TTL = store.cluster.HaConfig.ttl
+	record.RenewTime = time.Now().Unix()
+	if store.cluster.Leader.DBState != nil {
+		record.DBState = store.cluster.Leader.DBState
+	}
+
+	modRevision, err := store.put(store.getKey("leader"), record, resource.modRevision)
+	if err != nil {
+		return err
+	}
+	store.cluster.Leader.Resource = &etcdLeaderResource{leader: record, modRevision: modRevision}
+	store.cluster.Leader.RenewTime = record.RenewTime
+	return nil
+}
+
+func (store *EtcdStore) ReleaseLease() error {
+	store.logger.Info("release lease")
+	resource := store.cluster.Leader.Resource.(*etcdLeaderResource)
+	record := resource.leader
+	record.Leader = ""
+	store.cluster.Leader.Name = ""
+	if store.cluster.Leader.DBState != nil {
+		record.DBState = store.cluster.Leader.DBState
+	}
+
+	modRevision, err := store.put(store.getKey("leader"), record, resource.modRevision)
+	if err != nil {
+		store.logger.Error(err, "release lease failed")
+		return err
+	}
+	store.cluster.Leader.Resource = &etcdLeaderResource{leader: record, modRevision: modRevision}
+	return nil
+}
+
+func (store *EtcdStore) CreateHaConfig() error {
+	haConfig, _ := store.GetHaConfig()
+	if haConfig.resource != nil {
+		return nil
+	}
+
+	store.logger.Info(fmt.Sprintf("Create Ha config: %s", store.getKey("haconfig")))
+	enable := true
+	enableHA := viper.GetString(constant.KBEnvEnableHA)
+	if enableHA != "" {
+		enable, _ = strconv.ParseBool(enableHA)
+	}
+	record := etcdHaConfig{
+		TTL:                viper.GetInt(constant.KBEnvTTL),
+		Enable:             enable,
+		MaxLagOnSwitchover: int64(viper.GetInt(constant.KBEnvMaxLag)),
+	}
+	_, err := store.put(store.getKey("haconfig"), record, 0)
+	if err != nil {
+		store.logger.Error(err, "Create Ha config failed")
+	}
+	return err
+}
+
+func (store *EtcdStore) GetHaConfig() (*HaConfig, error) {
+	record := &etcdHaConfig{}
+	modRevision, err := store.get(store.getKey("haconfig"), record)
+	if err != nil || modRevision == 0 {
+		return &HaConfig{
+			index:              "",
+			ttl:                viper.GetInt(constant.KBEnvTTL),
+			maxLagOnSwitchover: 1048576,
+			DeleteMembers:      make(map[string]MemberToDelete),
+		}, err
+	}
+
+	if record.DeleteMembers == nil {
+		record.DeleteMembers = make(map[string]MemberToDelete)
+	}
+	return &HaConfig{
+		index:              strconv.FormatInt(modRevision, 10),
+		ttl:                record.TTL,
+		enable:             record.Enable,
+		maxLagOnSwitchover: record.MaxLagOnSwitchover,
+		DeleteMembers:      record.DeleteMembers,
+		resource:           modRevision,
+	}, nil
+}
+
+func (store *EtcdStore) UpdateHaConfig() error {
+	haConfig := store.cluster.HaConfig
+	if haConfig.resource == nil {
+		return errors.New("No HA config")
+	}
+
+	record := etcdHaConfig{
+		TTL:                haConfig.ttl,
+		Enable:             haConfig.enable,
+		MaxLagOnSwitchover: haConfig.maxLagOnSwitchover,
+		DeleteMembers:      haConfig.DeleteMembers,
+	}
+	modRevision, err := store.put(store.getKey("haconfig"), record, haConfig.resource.(int64))
+	if err != nil {
+		return err
+	}
+	haConfig.index = strconv.FormatInt(modRevision, 10)
+	haConfig.resource = modRevision
+	return nil
+}
+
+func (store *EtcdStore) GetSwitchover() (*Switchover, error) {
+	record := &etcdSwitchover{}
+	modRevision, err := store.get(store.getKey("switchover"), record)
+	if err != nil || modRevision == 0 {
+		return nil, err
+	}
+	return newSwitchover(strconv.FormatInt(modRevision, 10), record.Leader, record.Candidate, record.ScheduledAt), nil
+}
+
+func (store *EtcdStore) CreateSwitchover(leader, candidate string) error {
+	switchoverKey := store.getKey("switchover")
+	store.logger.Info(fmt.Sprintf("Create switchover %s", switchoverKey))
+	record := etcdSwitchover{
+		Leader:    leader,
+		Candidate: candidate,
+	}
+	_, err := store.put(switchoverKey, record, 0)
+	if err != nil {
+		store.logger.Error(err, "Create switchover failed")
+		return fmt.Errorf("there is another switchover %s unfinished", switchoverKey)
+	}
+	return nil
+}
+
+func (store *EtcdStore) DeleteSwitchover() error {
+	ctx, cancel := context.WithTimeout(store.ctx, etcdRequestTimeout)
+	defer cancel()
+	_, err := store.etcdClient.Delete(ctx, store.getKey("switchover"))
+	if err != nil {
+		store.logger.Error(err, "Delete switchover failed")
+	}
+	return err
+}
+
+func (store *EtcdStore) getKey(name string) string {
+	return path.Join(store.prefix, store.namespace, store.clusterCompName, name)
+}
+
+func (store *EtcdStore) getClusterUID() string {
+	if store.cluster == nil {
+		return ""
+	}
+	if appCluster, ok := store.cluster.resource.(*appsv1alpha1.Cluster); ok {
+		return string(appCluster.UID)
+	}
+	return ""
+}
+
+// get decodes the value of the key into the given object, and returns the mod
+// revision of the key, which is 0 if the key does not exist.
+func (store *EtcdStore) get(key string, obj any) (int64, error) {
+	ctx, cancel := context.WithTimeout(store.ctx, etcdRequestTimeout)
+	defer cancel()
+	resp, err := store.etcdClient.Get(ctx, key)
+	if err != nil {
+		store.logger.Error(err, fmt.Sprintf("Get key %s failed", key))
+		return 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+
+	kv := resp.Kvs[0]
+	if err = json.Unmarshal(kv.Value, obj); err != nil {
+		store.logger.Error(err, fmt.Sprintf("Decode key %s failed", key))
+		return 0, err
+	}
+	return kv.ModRevision, nil
+}
+
+// put stores the object to the key if its mod revision is still the given one,
+// a zero revision means the key must not exist. It returns the new mod revision.
+func (store *EtcdStore) put(key string, obj any, modRevision int64) (int64, error) {
+	value, err := json.Marshal(obj)
+	if err != nil {
+		return 0, err
+	}
+
+	cmp := clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)
+	if modRevision == 0 {
+		cmp = clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	}
+	ctx, cancel := context.WithTimeout(store.ctx, etcdRequestTimeout)
+	defer cancel()
+	resp, err := store.etcdClient.Txn(ctx).If(cmp).Then(clientv3.OpPut(key, string(value))).Commit()
+	if err != nil {
+		return 0, err
+	}
+	if !resp.Succeeded {
+		return 0, errors.Errorf("key %s has been modified", key)
+	}
+	return resp.Header.Revision, nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dcs
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func startEmbeddedEtcd(t *testing.T) *clientv3.Client {
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	localURL, _ := url.Parse("http://localhost:0")
+	cfg.ListenPeerUrls = []url.URL{*localURL}
+	cfg.ListenClientUrls = []url.URL{*localURL}
+	server, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(30 * time.Second):
+		server.Server.Stop()
+		t.Fatal("start embedded etcd server timeout")
+	}
+	return v3client.New(server.Server)
+}
+
+func newTestEtcdStore(etcdClient *clientv3.Client, memberName string, clusterUID types.UID) *EtcdStore {
+	store := &KubernetesStore{
+		ctx:               context.Background(),
+		clusterName:       "test",
+		componentName:     "mysql",
+		clusterCompName:   "test-mysql",
+		currentMemberName: memberName,
+		namespace:         "default",
+		logger:            ctrl.Log.WithName("DCS-ETCD"),
+		cluster: &Cluster{
+			ClusterCompName: "test-mysql",
+			Namespace:       "default",
+			resource: &appsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: clusterUID},
+			},
+		},
+	}
+	return newEtcdStore(store, etcdClient, "/kubeblocks")
+}
+
+func refreshEtcdCluster(t *testing.T, store *EtcdStore) {
+	leader, err := store.GetLeader()
+	require.NoError(t, err)
+	haConfig, err := store.GetHaConfig()
+	require.NoError(t, err)
+	switchover, err := store.GetSwitchover()
+	require.NoError(t, err)
+	store.cluster.Leader = leader
+	store.cluster.HaConfig = haConfig
+	store.cluster.Switchover = switchover
+}
+
+func TestEtcdStore(t *testing.T) {
+	etcdClient := startEmbeddedEtcd(t)
+	store0 := newTestEtcdStore(etcdClient, "test-mysql-0", "uid")
+	store1 := newTestEtcdStore(etcdClient, "test-mysql-1", "uid")
+
+	t.Run("create ha config and lease", func(t *testing.T) {
+		exist, err := store0.IsLeaseExist()
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		assert.NoError(t, store0.CreateHaConfig())
+		assert.NoError(t, store0.CreateLease())
+		assert.NoError(t, store1.CreateHaConfig())
+		assert.NoError(t, store1.CreateLease())
+
+		refreshEtcdCluster(t, store0)
+		refreshEtcdCluster(t, store1)
+		assert.True(t, store0.cluster.HaConfig.IsEnable())
+		assert.True(t, store0.HasLease())
+		assert.False(t, store1.HasLease())
+	})
+
+	t.Run("update lease", func(t *testing.T) {
+		store0.cluster.Leader.DBState = &DBState{OpTimestamp: 100}
+		assert.NoError(t, store0.UpdateLease())
+		assert.Error(t, store1.UpdateLease())
+
+		refreshEtcdCluster(t, store1)
+		assert.Equal(t, "test-mysql-0", store1.cluster.Leader.Name)
+		require.NotNil(t, store1.cluster.Leader.DBState)
+		assert.Equal(t, int64(100), store1.cluster.Leader.DBState.OpTimestamp)
+	})
+
+	t.Run("release and acquire lease", func(t *testing.T) {
+		assert.NoError(t, store0.ReleaseLease())
+		assert.False(t, store0.HasLease())
+
+		refreshEtcdCluster(t, store1)
+		assert.False(t, store1.cluster.IsLocked())
+		assert.NoError(t, store1.AttemptAcquireLease())
+
+		// the lock has been modified since store0 read it
+		assert.Error(t, store0.AttemptAcquireLease())
+
+		refreshEtcdCluster(t, store0)
+		assert.Equal(t, "test-mysql-1", store0.cluster.Leader.Name)
+	})
+
+	t.Run("update ha config", func(t *testing.T) {
+		store0.cluster.HaConfig.AddMemberToDelete(&Member{Name: "test-mysql-2", UID: "uid-2"})
+		assert.NoError(t, store0.UpdateHaConfig())
+
+		refreshEtcdCluster(t, store1)
+		assert.True(t, store1.cluster.HaConfig.IsDeleting(&Member{Name: "test-mysql-2", UID: "uid-2"}))
+	})
+
+	t.Run("switchover", func(t *testing.T) {
+		assert.NoError(t, store0.CreateSwitchover("test-mysql-1", "test-mysql-0"))
+		assert.Error(t, store1.CreateSwitchover("test-mysql-1", ""))
+
+		switchover, err := store1.GetSwitchover()
+		require.NoError(t, err)
+		require.NotNil(t, switchover)
+		assert.Equal(t, "test-mysql-1", switchover.GetLeader())
+		assert.Equal(t, "test-mysql-0", switchover.GetCandidate())
+
+		assert.NoError(t, store1.DeleteSwitchover())
+		switchover, err = store1.GetSwitchover()
+		assert.NoError(t, err)
+		assert.Nil(t, switchover)
+	})
+
+	t.Run("lease of previous cluster", func(t *testing.T) {
+		store := newTestEtcdStore(etcdClient, "test-mysql-0", "new-uid")
+		exist, err := store.IsLeaseExist()
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		haConfig, err := store.GetHaConfig()
+		assert.NoError(t, err)
+		assert.Nil(t, haConfig.resource)
+	})
+}
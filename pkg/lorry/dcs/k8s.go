@@ -55,7 +55,7 @@ type KubernetesStore struct {
 	namespace          string
 	cluster            *Cluster
 	client             *rest.RESTClient
-	clientset          *kubernetes.Clientset
+	clientset          kubernetes.Interface
 	LeaderObservedTime int64
 	logger             logr.Logger
 }
@@ -150,6 +150,13 @@ func (store *KubernetesStore) GetClusterFromCache() *Cluster {
 }
 
 func (store *KubernetesStore) GetCluster() (*Cluster, error) {
+	return store.getCluster(store)
+}
+
+// getCluster builds the cluster from the Cluster resource and the pods, while the
+// leader, switchover and ha config are read from the given store, which allows
+// stores embedding KubernetesStore to keep them in another backend.
+func (store *KubernetesStore) getCluster(dcs DCS) (*Cluster, error) {
 	clusterResource := &appsv1alpha1.Cluster{}
 	err := store.client.Get().
 		Namespace(store.namespace).
@@ -181,17 +188,17 @@ func (store *KubernetesStore) GetCluster() (*Cluster, error) {
 		}
 	}
 
-	leader, err := store.GetLeader()
+	leader, err := dcs.GetLeader()
 	if err != nil {
 		store.logger.Info("get leader failed", "error", err)
 	}
 
-	switchover, err := store.GetSwitchover()
+	switchover, err := dcs.GetSwitchover()
 	if err != nil {
 		store.logger.Info("get switchover failed", "error", err)
 	}
 
-	haConfig, err := store.GetHaConfig()
+	haConfig, err := dcs.GetHaConfig()
 	if err != nil {
 		store.logger.Info("get HaConfig failed", "error", err)
 	}
@@ -325,7 +332,8 @@ func (store *KubernetesStore) GetLeader() (*Leader, error) {
 	if err != nil {
 		ttl = viper.GetInt(constant.KBEnvTTL)
 	}
-	leader := annotations["leader"]
+	holder := annotations["leader"]
+	leader := holder
 	stateStr, ok := annotations["dbstate"]
 	var dbState *DBState
 	if ok {
@@ -344,6 +352,7 @@ func (store *KubernetesStore) GetLeader() (*Leader, error) {
 	return &Leader{
 		Index:       configmap.ResourceVersion,
 		Name:        leader,
+		Holder:      holder,
 		AcquireTime: acquireTime,
 		RenewTime:   renewTime,
 		TTL:         ttl,
@@ -611,13 +620,7 @@ func (store *KubernetesStore) getSwitchoverName() string {
 }
 
 func (store *KubernetesStore) createConfigMap(configMap *corev1.ConfigMap) error {
-	labelsMap := map[string]string{
-		constant.AppInstanceLabelKey:    store.clusterName,
-		constant.AppManagedByLabelKey:   "kubeblocks",
-		constant.KBAppComponentLabelKey: store.componentName,
-	}
-
-	configMap.Labels = labelsMap
+	configMap.Labels = store.getLabels()
 	configMap.Namespace = store.namespace
 	configMap.OwnerReferences = []metav1.OwnerReference{getOwnerRef(store.cluster)}
 	_, err := store.clientset.CoreV1().ConfigMaps(store.namespace).Create(store.ctx, configMap, metav1.CreateOptions{})
@@ -627,6 +630,14 @@ func (store *KubernetesStore) createConfigMap(configMap *corev1.ConfigMap) error
 	return nil
 }
 
+func (store *KubernetesStore) getLabels() map[string]string {
+	return map[string]string{
+		constant.AppInstanceLabelKey:    store.clusterName,
+		constant.AppManagedByLabelKey:   "kubeblocks",
+		constant.KBAppComponentLabelKey: store.componentName,
+	}
+}
+
 func (store *KubernetesStore) AddCurrentMember() error {
 	return nil
 }
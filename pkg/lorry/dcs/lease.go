@@ -0,0 +1,290 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const dbStateAnnotationKey = "dbstate"
+
+// KubernetesLeaseStore keeps the leader lock in a coordination.k8s.io/v1 Lease, which
+// is cheaper to renew than the leader ConfigMap. The ha config and the switchover
+// are still kept in ConfigMaps.
+type KubernetesLeaseStore struct {
+	*KubernetesStore
+}
+
+var _ DCS = &KubernetesLeaseStore{}
+
+func NewKubernetesLeaseStore() (*KubernetesLeaseStore, error) {
+	store, err := NewKubernetesStore()
+	if err != nil {
+		return nil, err
+	}
+	store.logger = ctrl.Log.WithName("DCS-LEASE")
+	return &KubernetesLeaseStore{KubernetesStore: store}, nil
+}
+
+func (store *KubernetesLeaseStore) Initialize() error {
+	store.logger.Info("lease store initializing")
+	_, err := store.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	err = store.CreateHaConfig()
+	if err != nil {
+		store.logger.Error(err, "Create Ha ConfigMap failed")
+	}
+
+	err = store.CreateLease()
+	if err != nil {
+		store.logger.Error(err, "Create Leader Lease failed")
+	}
+	return err
+}
+
+func (store *KubernetesLeaseStore) GetClusterFromCache() *Cluster {
+	if store.cluster != nil {
+		return store.cluster
+	}
+	cluster, _ := store.GetCluster()
+	return cluster
+}
+
+func (store *KubernetesLeaseStore) GetCluster() (*Cluster, error) {
+	return store.getCluster(store)
+}
+
+func (store *KubernetesLeaseStore) GetLeaderLease() (*coordinationv1.Lease, error) {
+	leaderName := store.getLeaderName()
+	lease, err := store.clientset.CoordinationV1().Leases(store.namespace).Get(store.ctx, leaderName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			store.logger.Info("Leader lease is not found", "lease", leaderName)
+			return nil, nil
+		}
+		store.logger.Error(err, "Get Leader lease failed")
+		return nil, err
+	}
+	return lease, nil
+}
+
+func (store *KubernetesLeaseStore) IsLeaseExist() (bool, error) {
+	lease, err := store.GetLeaderLease()
+	if lease != nil && store.cluster != nil {
+		appCluster, ok := store.cluster.resource.(*appsv1alpha1.Cluster)
+		if ok && lease.CreationTimestamp.Before(&appCluster.CreationTimestamp) {
+			store.logger.Info("A previous leader lease exists, delete it", "name", lease.Name)
+			_ = store.DeleteLeader()
+			return false, nil
+		}
+	}
+	return lease != nil, err
+}
+
+func (store *KubernetesLeaseStore) CreateLease() error {
+	isExist, err := store.IsLeaseExist()
+	if isExist || err != nil {
+		return err
+	}
+
+	leaseName := store.getLeaderName()
+	holder := store.currentMemberName
+	now := metav1.NewMicroTime(time.Now())
+	ttl := int32(viper.GetInt(constant.KBEnvTTL))
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: store.namespace,
+			Labels:    store.getLabels(),
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &ttl,
+			AcquireTime:          &now,
+			RenewTime:            &now,
+		},
+	}
+	if store.cluster != nil && store.cluster.resource != nil {
+		lease.OwnerReferences = []metav1.OwnerReference{getOwnerRef(store.cluster)}
+	}
+
+	store.logger.Info(fmt.Sprintf("Lease store initializing, create leader Lease: %s", leaseName))
+	_, err = store.clientset.CoordinationV1().Leases(store.namespace).Create(store.ctx, lease, metav1.CreateOptions{})
+	if err != nil {
+		store.logger.Error(err, "Create Leader Lease failed")
+		return err
+	}
+	return nil
+}
+
+func (store *KubernetesLeaseStore) GetLeader() (*Leader, error) {
+	lease, err := store.GetLeaderLease()
+	if err != nil {
+		return nil, err
+	}
+
+	if lease == nil {
+		return nil, nil
+	}
+
+	var holder string
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	leader := holder
+	var acquireTime, renewTime int64
+	if lease.Spec.AcquireTime != nil {
+		acquireTime = lease.Spec.AcquireTime.Unix()
+	}
+	if lease.Spec.RenewTime != nil {
+		renewTime = lease.Spec.RenewTime.Unix()
+	}
+	ttl := viper.GetInt(constant.KBEnvTTL)
+	if lease.Spec.LeaseDurationSeconds != nil {
+		ttl = int(*lease.Spec.LeaseDurationSeconds)
+	}
+
+	var dbState *DBState
+	if stateStr, ok := lease.Annotations[dbStateAnnotationKey]; ok {
+		dbState = new(DBState)
+		err = json.Unmarshal([]byte(stateStr), &dbState)
+		if err != nil {
+			store.logger.Error(err, fmt.Sprintf("get leader dbstate failed, annotations: %v", lease.Annotations))
+		}
+	}
+
+	if ttl > 0 && time.Now().Unix()-renewTime > int64(ttl) {
+		store.logger.Info(fmt.Sprintf("lock expired: %s, renew time: %d, now: %d", leader, renewTime, time.Now().Unix()))
+		leader = ""
+	}
+
+	return &Leader{
+		Index:       lease.ResourceVersion,
+		Name:        leader,
+		Holder:      holder,
+		AcquireTime: acquireTime,
+		RenewTime:   renewTime,
+		TTL:         ttl,
+		Resource:    lease,
+		DBState:     dbState,
+	}, nil
+}
+
+func (store *KubernetesLeaseStore) DeleteLeader() error {
+	leaderName := store.getLeaderName()
+	err := store.clientset.CoordinationV1().Leases(store.namespace).Delete(store.ctx, leaderName, metav1.DeleteOptions{})
+	if err != nil {
+		store.logger.Error(err, "Delete leader lease failed")
+	}
+	return err
+}
+
+func (store *KubernetesLeaseStore) AttemptAcquireLease() error {
+	now := metav1.NewMicroTime(time.Now())
+	ttl := int32(store.cluster.HaConfig.ttl)
+	holder := store.currentMemberName
+
+	lease := store.cluster.Leader.Resource.(*coordinationv1.Lease)
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != holder {
+		transitions := int32(1)
+		if lease.Spec.LeaseTransitions != nil {
+			transitions = *lease.Spec.LeaseTransitions + 1
+		}
+		lease.Spec.LeaseTransitions = &transitions
+	}
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.LeaseDurationSeconds = &ttl
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	store.setDBState(lease)
+
+	newLease, err := store.clientset.CoordinationV1().Leases(store.namespace).Update(store.ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		store.logger.Error(err, "Acquire lease failed")
+		return err
+	}
+
+	store.cluster.Leader.Resource = newLease
+	store.cluster.Leader.AcquireTime = now.Unix()
+	store.cluster.Leader.RenewTime = now.Unix()
+	return nil
+}
+
+func (store *KubernetesLeaseStore) UpdateLease() error {
+	lease := store.cluster.Leader.Resource.(*coordinationv1.Lease)
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != store.currentMemberName {
+		return errors.Errorf("lost lease")
+	}
+
+	now := metav1.NewMicroTime(time.Now())
+	ttl := int32(store.cluster.HaConfig.ttl)
+	lease.Spec.LeaseDurationSeconds = &ttl
+	lease.Spec.RenewTime = &now
+	store.setDBState(lease)
+
+	newLease, err := store.clientset.CoordinationV1().Leases(store.namespace).Update(store.ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	store.cluster.Leader.Resource = newLease
+	store.cluster.Leader.RenewTime = now.Unix()
+	return nil
+}
+
+func (store *KubernetesLeaseStore) ReleaseLease() error {
+	store.logger.Info("release lease")
+	lease := store.cluster.Leader.Resource.(*coordinationv1.Lease)
+	holder := ""
+	lease.Spec.HolderIdentity = &holder
+	store.cluster.Leader.Name = ""
+	store.setDBState(lease)
+
+	_, err := store.clientset.CoordinationV1().Leases(store.namespace).Update(store.ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		store.logger.Error(err, "release lease failed")
+	}
+	return err
+}
+
+func (store *KubernetesLeaseStore) setDBState(lease *coordinationv1.Lease) {
+	if store.cluster.Leader.DBState == nil {
+		return
+	}
+	str, _ := json.Marshal(store.cluster.Leader.DBState)
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[dbStateAnnotationKey] = string(str)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func newTestLeaseStore(clientset kubernetes.Interface, memberName string) *KubernetesLeaseStore {
+	store := &KubernetesStore{
+		ctx:               context.Background(),
+		clusterName:       "test",
+		componentName:     "mysql",
+		clusterCompName:   "test-mysql",
+		currentMemberName: memberName,
+		namespace:         "default",
+		clientset:         clientset,
+		logger:            ctrl.Log.WithName("DCS-LEASE"),
+		cluster: &Cluster{
+			ClusterCompName: "test-mysql",
+			Namespace:       "default",
+			HaConfig:        &HaConfig{ttl: 15, enable: true},
+			resource: &appsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					UID:       "uid",
+				},
+			},
+		},
+	}
+	return &KubernetesLeaseStore{KubernetesStore: store}
+}
+
+func refreshLeader(t *testing.T, store *KubernetesLeaseStore) {
+	leader, err := store.GetLeader()
+	require.NoError(t, err)
+	store.cluster.Leader = leader
+}
+
+func TestLeaseStore(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	store0 := newTestLeaseStore(clientset, "test-mysql-0")
+	store1 := newTestLeaseStore(clientset, "test-mysql-1")
+
+	t.Run("create lease", func(t *testing.T) {
+		exist, err := store0.IsLeaseExist()
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		assert.NoError(t, store0.CreateLease())
+		// creating again is a no-op
+		assert.NoError(t, store1.CreateLease())
+
+		lease, err := clientset.CoordinationV1().Leases("default").Get(context.Background(), "test-mysql-leader", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "test-mysql-0", *lease.Spec.HolderIdentity)
+
+		refreshLeader(t, store0)
+		refreshLeader(t, store1)
+		assert.True(t, store0.HasLease())
+		assert.False(t, store1.HasLease())
+	})
+
+	t.Run("update lease", func(t *testing.T) {
+		store0.cluster.Leader.DBState = &DBState{OpTimestamp: 100}
+		assert.NoError(t, store0.UpdateLease())
+		assert.Error(t, store1.UpdateLease())
+
+		refreshLeader(t, store1)
+		assert.Equal(t, "test-mysql-0", store1.cluster.Leader.Name)
+		require.NotNil(t, store1.cluster.Leader.DBState)
+		assert.Equal(t, int64(100), store1.cluster.Leader.DBState.OpTimestamp)
+	})
+
+	t.Run("release and acquire lease", func(t *testing.T) {
+		refreshLeader(t, store0)
+		assert.NoError(t, store0.ReleaseLease())
+		assert.False(t, store0.HasLease())
+
+		refreshLeader(t, store1)
+		assert.False(t, store1.cluster.IsLocked())
+		assert.NoError(t, store1.AttemptAcquireLease())
+
+		refreshLeader(t, store0)
+		assert.Equal(t, "test-mysql-1", store0.cluster.Leader.Name)
+		lease := store0.cluster.Leader.Resource.(*coordinationv1.Lease)
+		assert.Equal(t, int32(1), *lease.Spec.LeaseTransitions)
+	})
+
+	t.Run("expired lease", func(t *testing.T) {
+		lease := store0.cluster.Leader.Resource.(*coordinationv1.Lease)
+		renewTime := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+		lease.Spec.RenewTime = &renewTime
+		_, err := clientset.CoordinationV1().Leases("default").Update(context.Background(), lease, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		refreshLeader(t, store0)
+		assert.False(t, store0.cluster.IsLocked())
+	})
+
+	t.Run("lease of previous cluster", func(t *testing.T) {
+		// the fake clientset leaves the creation timestamp of the lease empty
+		store0.cluster.resource.(*appsv1alpha1.Cluster).CreationTimestamp = metav1.Now()
+		exist, err := store0.IsLeaseExist()
+		assert.NoError(t, err)
+		assert.False(t, exist)
+
+		lease, err := store0.GetLeaderLease()
+		assert.NoError(t, err)
+		assert.Nil(t, lease)
+	})
+}
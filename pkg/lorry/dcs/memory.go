@@ -0,0 +1,317 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dcs
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// MemoryStore is an in-memory DCS, which is intended for the unit tests of HA.
+// The stores created by WithMember share the same data, so that they act as
+// the members of one cluster.
+type MemoryStore struct {
+	data              *memoryData
+	currentMemberName string
+	cluster           *Cluster
+}
+
+type memoryData struct {
+	sync.Mutex
+	clusterName     string
+	clusterCompName string
+	namespace       string
+	members         []Member
+	leader          *Leader
+	haConfig        *HaConfig
+	switchover      *Switchover
+	revision        int64
+}
+
+var _ DCS = &MemoryStore{}
+
+// NewMemoryStore creates a store for the cluster with the given members, the HA is
+// enabled and the leader lock does not exist until Initialize or CreateLease is called.
+func NewMemoryStore(clusterName, clusterCompName, namespace, currentMemberName string, members []Member) *MemoryStore {
+	data := &memoryData{
+		clusterName:     clusterName,
+		clusterCompName: clusterCompName,
+		namespace:       namespace,
+		members:         append([]Member{}, members...),
+		haConfig: &HaConfig{
+			index:              "1",
+			ttl:                viper.GetInt(constant.KBEnvTTL),
+			enable:             true,
+			maxLagOnSwitchover: 1048576,
+			DeleteMembers:      make(map[string]MemberToDelete),
+			resource:           "memory",
+		},
+		revision: 1,
+	}
+	return &MemoryStore{
+		data:              data,
+		currentMemberName: currentMemberName,
+	}
+}
+
+// WithMember returns a store of another member sharing the same data.
+func (store *MemoryStore) WithMember(memberName string) *MemoryStore {
+	return &MemoryStore{
+		data:              store.data,
+		currentMemberName: memberName,
+	}
+}
+
+func (store *MemoryStore) Initialize() error {
+	_, err := store.GetCluster()
+	if err != nil {
+		return err
+	}
+	return store.CreateLease()
+}
+
+func (store *MemoryStore) GetClusterName() string {
+	return store.data.clusterName
+}
+
+func (store *MemoryStore) GetCluster() (*Cluster, error) {
+	members, _ := store.GetMembers()
+	leader, _ := store.GetLeader()
+	switchover, _ := store.GetSwitchover()
+	haConfig, _ := store.GetHaConfig()
+
+	store.cluster = &Cluster{
+		ClusterCompName: store.data.clusterCompName,
+		Namespace:       store.data.namespace,
+		Replicas:        int32(len(members)),
+		Members:         members,
+		Leader:          leader,
+		Switchover:      switchover,
+		HaConfig:        haConfig,
+	}
+	return store.cluster, nil
+}
+
+func (store *MemoryStore) GetClusterFromCache() *Cluster {
+	if store.cluster != nil {
+		return store.cluster
+	}
+	cluster, _ := store.GetCluster()
+	return cluster
+}
+
+func (store *MemoryStore) ResetCluster() {}
+
+func (store *MemoryStore) DeleteCluster() {
+	store.data.Lock()
+	defer store.data.Unlock()
+	store.data.leader = nil
+	store.data.switchover = nil
+}
+
+func (store *MemoryStore) GetHaConfig() (*HaConfig, error) {
+	store.data.Lock()
+	defer store.data.Unlock()
+	haConfig := *store.data.haConfig
+	haConfig.DeleteMembers = make(map[string]MemberToDelete, len(store.data.haConfig.DeleteMembers))
+	for name, member := range store.data.haConfig.DeleteMembers {
+		haConfig.DeleteMembers[name] = member
+	}
+	return &haConfig, nil
+}
+
+func (store *MemoryStore) UpdateHaConfig() error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	haConfig := *store.cluster.HaConfig
+	haConfig.index = store.nextIndex()
+	store.data.haConfig = &haConfig
+	return nil
+}
+
+func (store *MemoryStore) GetMembers() ([]Member, error) {
+	store.data.Lock()
+	defer store.data.Unlock()
+	return append([]Member{}, store.data.members...), nil
+}
+
+func (store *MemoryStore) AddCurrentMember() error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	for _, member := range store.data.members {
+		if member.Name == store.currentMemberName {
+			return nil
+		}
+	}
+	store.data.members = append(store.data.members, Member{Name: store.currentMemberName})
+	return nil
+}
+
+func (store *MemoryStore) GetSwitchover() (*Switchover, error) {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.data.switchover == nil {
+		return nil, nil
+	}
+	switchover := *store.data.switchover
+	return &switchover, nil
+}
+
+func (store *MemoryStore) CreateSwitchover(leader, candidate string) error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.data.switchover != nil {
+		return fmt.Errorf("there is another switchover unfinished")
+	}
+	store.data.switchover = newSwitchover(store.nextIndex(), leader, candidate, time.Now().Unix())
+	return nil
+}
+
+func (store *MemoryStore) DeleteSwitchover() error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	store.data.switchover = nil
+	return nil
+}
+
+func (store *MemoryStore) IsLeaseExist() (bool, error) {
+	store.data.Lock()
+	defer store.data.Unlock()
+	return store.data.leader != nil, nil
+}
+
+func (store *MemoryStore) CreateLease() error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.data.leader != nil {
+		return nil
+	}
+	now := time.Now().Unix()
+	store.data.leader = &Leader{
+		Index:       store.nextIndex(),
+		Name:        store.currentMemberName,
+		AcquireTime: now,
+		RenewTime:   now,
+		TTL:         store.data.haConfig.ttl,
+	}
+	return nil
+}
+
+func (store *MemoryStore) GetLeader() (*Leader, error) {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.data.leader == nil {
+		return nil, nil
+	}
+	leader := *store.data.leader
+	leader.Holder = leader.Name
+	if leader.TTL > 0 && time.Now().Unix()-leader.RenewTime > int64(leader.TTL) {
+		leader.Name = ""
+	}
+	return &leader, nil
+}
+
+// ExpireLease makes the leader lock expire, as if the leader has stopped renewing it.
+func (store *MemoryStore) ExpireLease() {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.data.leader != nil {
+		store.data.leader.RenewTime = time.Now().Unix() - int64(store.data.leader.TTL) - 1
+	}
+}
+
+func (store *MemoryStore) AttemptAcquireLease() error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.data.leader == nil || store.data.leader.Index != store.cluster.Leader.Index {
+		return errors.New("lease was modified")
+	}
+
+	now := time.Now().Unix()
+	store.data.leader = &Leader{
+		Index:       store.nextIndex(),
+		Name:        store.currentMemberName,
+		AcquireTime: now,
+		RenewTime:   now,
+		TTL:         store.cluster.HaConfig.ttl,
+		DBState:     store.cluster.Leader.DBState,
+	}
+	store.cluster.Leader.Index = store.data.leader.Index
+	store.cluster.Leader.AcquireTime = now
+	store.cluster.Leader.RenewTime = now
+	return nil
+}
+
+func (store *MemoryStore) HasLease() bool {
+	return store.cluster != nil && store.cluster.Leader != nil && store.cluster.Leader.Name == store.currentMemberName
+}
+
+func (store *MemoryStore) UpdateLease() error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.data.leader == nil || store.data.leader.Name != store.currentMemberName {
+		return errors.Errorf("lost lease")
+	}
+
+	leader := *store.data.leader
+	leader.Index = store.nextIndex()
+	leader.RenewTime = time.Now().Unix()
+	leader.TTL = store.cluster.HaConfig.ttl
+	if store.cluster.Leader.DBState != nil {
+		leader.DBState = store.cluster.Leader.DBState
+	}
+	store.data.leader = &leader
+	store.cluster.Leader.Index = leader.Index
+	store.cluster.Leader.RenewTime = leader.RenewTime
+	return nil
+}
+
+func (store *MemoryStore) ReleaseLease() error {
+	store.data.Lock()
+	defer store.data.Unlock()
+	if store.cluster != nil && store.cluster.Leader != nil {
+		store.cluster.Leader.Name = ""
+	}
+	if store.data.leader == nil || store.data.leader.Name != store.currentMemberName {
+		return nil
+	}
+
+	leader := *store.data.leader
+	leader.Index = store.nextIndex()
+	leader.Name = ""
+	store.data.leader = &leader
+	if store.cluster != nil && store.cluster.Leader != nil {
+		store.cluster.Leader.Index = leader.Index
+	}
+	return nil
+}
+
+// nextIndex must be called with the data locked.
+func (store *MemoryStore) nextIndex() string {
+	store.data.revision++
+	return strconv.FormatInt(store.data.revision, 10)
+}
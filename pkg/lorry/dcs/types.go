@@ -180,9 +180,12 @@ func (c *HaConfig) AddMemberToDelete(member *Member) {
 }
 
 type Leader struct {
-	DBState     *DBState
-	Index       string
-	Name        string
+	DBState *DBState
+	Index   string
+	Name    string
+	// Holder is the member holding the lock, which is kept after the lock expires,
+	// while Name is empty once the lock expires.
+	Holder      string
 	AcquireTime int64
 	RenewTime   int64
 	TTL         int
@@ -180,6 +180,10 @@ func (mgr *DBManagerBase) Query(context.Context, string) ([]byte, error) {
 	return []byte{}, errors.New("not implemented")
 }
 
+func (mgr *DBManagerBase) GetParameters(context.Context, []string) (map[string]string, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (mgr *DBManagerBase) GetPort() (int, error) {
 	return 0, errors.New("not implemented")
 }
@@ -216,6 +220,14 @@ func (mgr *DBManagerBase) RevokeUserRole(context.Context, string, string) error
 	return errors.New("not implemented")
 }
 
+func (mgr *DBManagerBase) UpdateUserPassword(context.Context, string, string, bool) error {
+	return errors.New("not implemented")
+}
+
+func (mgr *DBManagerBase) DiscardUserOldPassword(context.Context, string, string) error {
+	return errors.New("not implemented")
+}
+
 func (mgr *DBManagerBase) IsRunning() bool {
 	return false
 }
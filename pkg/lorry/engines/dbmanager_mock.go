@@ -130,6 +130,20 @@ func (mr *MockDBManagerMockRecorder) DescribeUser(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeUser", reflect.TypeOf((*MockDBManager)(nil).DescribeUser), arg0, arg1)
 }
 
+// DiscardUserOldPassword mocks base method.
+func (m *MockDBManager) DiscardUserOldPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardUserOldPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardUserOldPassword indicates an expected call of DiscardUserOldPassword.
+func (mr *MockDBManagerMockRecorder) DiscardUserOldPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardUserOldPassword", reflect.TypeOf((*MockDBManager)(nil).DiscardUserOldPassword), arg0, arg1, arg2)
+}
+
 // Exec mocks base method.
 func (m *MockDBManager) Exec(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -244,6 +258,21 @@ func (mr *MockDBManagerMockRecorder) GetMemberAddrs(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberAddrs", reflect.TypeOf((*MockDBManager)(nil).GetMemberAddrs), arg0, arg1)
 }
 
+// GetParameters mocks base method.
+func (m *MockDBManager) GetParameters(arg0 context.Context, arg1 []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParameters", arg0, arg1)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParameters indicates an expected call of GetParameters.
+func (mr *MockDBManagerMockRecorder) GetParameters(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameters", reflect.TypeOf((*MockDBManager)(nil).GetParameters), arg0, arg1)
+}
+
 // GetPort mocks base method.
 func (m *MockDBManager) GetPort() (int, error) {
 	m.ctrl.T.Helper()
@@ -713,3 +742,17 @@ func (mr *MockDBManagerMockRecorder) Unlock(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockDBManager)(nil).Unlock), arg0)
 }
+
+// UpdateUserPassword mocks base method.
+func (m *MockDBManager) UpdateUserPassword(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockDBManagerMockRecorder) UpdateUserPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockDBManager)(nil).UpdateUserPassword), arg0, arg1, arg2, arg3)
+}
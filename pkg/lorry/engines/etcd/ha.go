@@ -0,0 +1,375 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+)
+
+// GetMemberName returns the name of an etcd member. A learner that has not
+// started yet has no name, so it is derived from the host of its peer url.
+func GetMemberName(member *pb.Member) string {
+	if member.Name != "" {
+		return member.Name
+	}
+	for _, peerURL := range member.PeerURLs {
+		u, err := url.Parse(peerURL)
+		if err != nil {
+			continue
+		}
+		return strings.Split(u.Hostname(), ".")[0]
+	}
+	return ""
+}
+
+func findMember(members []*pb.Member, memberName string) *pb.Member {
+	for _, member := range members {
+		if GetMemberName(member) == memberName {
+			return member
+		}
+	}
+	return nil
+}
+
+func (mgr *Manager) getScheme() string {
+	if strings.HasPrefix(mgr.endpoint, "https://") {
+		return "https"
+	}
+	return "http"
+}
+
+func (mgr *Manager) GetPeerURL(cluster *dcs.Cluster, member *dcs.Member) string {
+	return fmt.Sprintf("%s://%s:%d", mgr.getScheme(), cluster.GetMemberAddr(*member), mgr.peerPort)
+}
+
+func (mgr *Manager) GetClientURL(cluster *dcs.Cluster, member *dcs.Member) string {
+	return fmt.Sprintf("%s://%s", mgr.getScheme(), cluster.GetMemberAddrWithPort(*member))
+}
+
+var errNoOtherMembers = errors.New("no other members to connect")
+
+// GetClusterClient returns a client connected to the members other than the current one,
+// which is used to manage the membership of the current member.
+func (mgr *Manager) GetClusterClient(cluster *dcs.Cluster) (*v3.Client, error) {
+	var endpoints []string
+	for i := range cluster.Members {
+		if cluster.Members[i].Name == mgr.CurrentMemberName {
+			continue
+		}
+		endpoints = append(endpoints, mgr.GetClientURL(cluster, &cluster.Members[i]))
+	}
+	if len(endpoints) == 0 {
+		return nil, errNoOtherMembers
+	}
+
+	return v3.New(v3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: defaultDialTimeout,
+	})
+}
+
+func (mgr *Manager) GetMemberList(ctx context.Context, client *v3.Client) ([]*pb.Member, error) {
+	resp, err := client.MemberList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Members, nil
+}
+
+// listClusterMembers lists the members through the peers, since a member that has not joined
+// or has been removed only knows its own stale membership. The local member is queried only
+// if it is the single member of the cluster.
+func (mgr *Manager) listClusterMembers(ctx context.Context, cluster *dcs.Cluster) ([]*pb.Member, error) {
+	client, err := mgr.GetClusterClient(cluster)
+	switch {
+	case errors.Is(err, errNoOtherMembers):
+		client = mgr.etcd
+	case err != nil:
+		return nil, err
+	default:
+		defer client.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultDialTimeout)
+	defer cancel()
+	return mgr.GetMemberList(ctx, client)
+}
+
+func (mgr *Manager) IsLeader(ctx context.Context, cluster *dcs.Cluster) (bool, error) {
+	return mgr.IsLeaderMember(ctx, cluster, nil)
+}
+
+func (mgr *Manager) IsLeaderMember(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) (bool, error) {
+	endpoint := mgr.endpoint
+	if member != nil && member.Name != mgr.CurrentMemberName {
+		endpoint = mgr.GetClientURL(cluster, member)
+	}
+
+	status, err := mgr.etcd.Status(ctx, endpoint)
+	if err != nil {
+		return false, err
+	}
+	return status.Leader == status.Header.MemberId, nil
+}
+
+// GetMemberAddrs returns the addresses of the voting members, learners are excluded.
+func (mgr *Manager) GetMemberAddrs(ctx context.Context, cluster *dcs.Cluster) []string {
+	members, err := mgr.listClusterMembers(ctx, cluster)
+	if err != nil {
+		mgr.Logger.Info("list members failed", "error", err.Error())
+		return nil
+	}
+
+	addrs := make([]string, 0, len(members))
+	for _, member := range members {
+		if member.IsLearner {
+			continue
+		}
+		for _, clientURL := range member.ClientURLs {
+			if u, err := url.Parse(clientURL); err == nil {
+				addrs = append(addrs, u.Host)
+				break
+			}
+		}
+	}
+	return addrs
+}
+
+// IsCurrentMemberInCluster returns true only if the current member is a voting member,
+// so that a learner keeps joining until it is promoted.
+func (mgr *Manager) IsCurrentMemberInCluster(ctx context.Context, cluster *dcs.Cluster) bool {
+	members, err := mgr.listClusterMembers(ctx, cluster)
+	if err != nil {
+		mgr.Logger.Info("list members failed", "error", err.Error())
+		return false
+	}
+
+	member := findMember(members, mgr.CurrentMemberName)
+	return member != nil && !member.IsLearner
+}
+
+func (mgr *Manager) JoinCurrentMemberToCluster(ctx context.Context, cluster *dcs.Cluster) error {
+	currentMember := cluster.GetMemberWithName(mgr.CurrentMemberName)
+	if currentMember == nil {
+		return fmt.Errorf("member %s not found", mgr.CurrentMemberName)
+	}
+
+	client, err := mgr.GetClusterClient(cluster)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, defaultDialTimeout)
+	defer cancel()
+	return mgr.joinMember(ctx, client, mgr.CurrentMemberName, mgr.GetPeerURL(cluster, currentMember))
+}
+
+// joinMember adds the member as a learner first, and promotes it to a voting member
+// once it has caught up with the leader.
+func (mgr *Manager) joinMember(ctx context.Context, client *v3.Client, memberName, peerURL string) error {
+	members, err := mgr.GetMemberList(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	member := findMember(members, memberName)
+	switch {
+	case member == nil:
+		mgr.Logger.Info("add member as learner", "member", memberName, "peerURL", peerURL)
+		_, err = client.MemberAddAsLearner(ctx, []string{peerURL})
+	case member.IsLearner:
+		mgr.Logger.Info("promote learner", "member", memberName)
+		_, err = client.MemberPromote(ctx, member.ID)
+	}
+	return err
+}
+
+func (mgr *Manager) LeaveMemberFromCluster(ctx context.Context, _ *dcs.Cluster, memberName string) error {
+	members, err := mgr.GetMemberList(ctx, mgr.etcd)
+	if err != nil {
+		return err
+	}
+
+	member := findMember(members, memberName)
+	if member == nil {
+		mgr.Logger.Info("member is already deleted", "member", memberName)
+		return nil
+	}
+
+	mgr.Logger.Info(fmt.Sprintf("Delete member: %s", memberName))
+	_, err = mgr.etcd.MemberRemove(ctx, member.ID)
+	return err
+}
+
+// IsClusterHealthy returns true if any of the peers sees a leader, the current member is
+// queried only if it is the single member of the cluster.
+func (mgr *Manager) IsClusterHealthy(ctx context.Context, cluster *dcs.Cluster) bool {
+	client, err := mgr.GetClusterClient(cluster)
+	var endpoints []string
+	switch {
+	case errors.Is(err, errNoOtherMembers):
+		client, endpoints = mgr.etcd, []string{mgr.endpoint}
+	case err != nil:
+		mgr.Logger.Info("connect to the cluster failed", "error", err.Error())
+		return false
+	default:
+		defer client.Close()
+		endpoints = client.Endpoints()
+	}
+	for _, endpoint := range endpoints {
+		statusCtx, cancel := context.WithTimeout(ctx, defaultDialTimeout)
+		status, err := client.Status(statusCtx, endpoint)
+		cancel()
+		if err != nil {
+			mgr.Logger.Info("get etcd status failed", "endpoint", endpoint, "error", err.Error())
+			continue
+		}
+		if status.Leader != 0 {
+			return true
+		}
+	}
+	mgr.Logger.Info("cluster has no leader")
+	return false
+}
+
+func (mgr *Manager) IsCurrentMemberHealthy(ctx context.Context, cluster *dcs.Cluster) bool {
+	return mgr.IsMemberHealthy(ctx, cluster, nil)
+}
+
+func (mgr *Manager) IsMemberHealthy(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) bool {
+	endpoint := mgr.endpoint
+	if member != nil && member.Name != mgr.CurrentMemberName {
+		endpoint = mgr.GetClientURL(cluster, member)
+	}
+
+	status, err := mgr.etcd.Status(ctx, endpoint)
+	if err != nil {
+		mgr.Logger.Info("get etcd status failed", "endpoint", endpoint, "error", err.Error())
+		return false
+	}
+	if len(status.Errors) > 0 {
+		mgr.Logger.Info("member is unhealthy", "endpoint", endpoint, "errors", status.Errors)
+		return false
+	}
+	return status.Leader != 0
+}
+
+func (mgr *Manager) IsPromoted(ctx context.Context) bool {
+	isLeader, err := mgr.IsLeader(ctx, nil)
+	if err != nil {
+		mgr.Logger.Info("Is leader check failed", "error", err.Error())
+		return false
+	}
+	return isLeader
+}
+
+// Promote transfers the raft leadership to the current member.
+func (mgr *Manager) Promote(ctx context.Context, cluster *dcs.Cluster) error {
+	status, err := mgr.etcd.Status(ctx, mgr.endpoint)
+	if err != nil {
+		return err
+	}
+	if status.Leader == status.Header.MemberId {
+		mgr.Logger.Info("Current member is already the leader")
+		return nil
+	}
+
+	members, err := mgr.GetMemberList(ctx, mgr.etcd)
+	if err != nil {
+		return err
+	}
+	var leaderURLs []string
+	for _, member := range members {
+		if member.ID == status.Leader {
+			leaderURLs = member.ClientURLs
+		}
+	}
+	if len(leaderURLs) == 0 {
+		return errors.New("leader not found")
+	}
+
+	// leadership can only be transferred by the current leader
+	client, err := v3.New(v3.Config{
+		Endpoints:   leaderURLs,
+		DialTimeout: defaultDialTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	mgr.Logger.Info("move leader", "from", status.Leader, "to", status.Header.MemberId)
+	_, err = client.MoveLeader(ctx, status.Header.MemberId)
+	return err
+}
+
+func (mgr *Manager) Demote(context.Context) error {
+	// etcd transfers the leadership in one action in promote, here do nothing.
+	return nil
+}
+
+func (mgr *Manager) Follow(context.Context, *dcs.Cluster) error {
+	return nil
+}
+
+func (mgr *Manager) HasOtherHealthyLeader(ctx context.Context, cluster *dcs.Cluster) *dcs.Member {
+	status, err := mgr.etcd.Status(ctx, mgr.endpoint)
+	if err != nil || status.Leader == 0 || status.Leader == status.Header.MemberId {
+		return nil
+	}
+
+	members, err := mgr.GetMemberList(ctx, mgr.etcd)
+	if err != nil {
+		return nil
+	}
+	for _, member := range members {
+		if member.ID == status.Leader {
+			return cluster.GetMemberWithName(GetMemberName(member))
+		}
+	}
+	return nil
+}
+
+// HasOtherHealthyMembers Are there any healthy members other than the leader?
+func (mgr *Manager) HasOtherHealthyMembers(ctx context.Context, cluster *dcs.Cluster, leader string) []*dcs.Member {
+	members := make([]*dcs.Member, 0)
+	for i := range cluster.Members {
+		member := &cluster.Members[i]
+		if member.Name == leader {
+			continue
+		}
+		if !mgr.IsMemberHealthy(ctx, cluster, member) {
+			continue
+		}
+		members = append(members, member)
+	}
+
+	return members
+}
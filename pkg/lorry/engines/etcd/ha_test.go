@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
+)
+
+var _ = Describe("ETCD HA", func() {
+	var (
+		server  *EmbeddedETCD
+		manager *Manager
+		cluster *dcs.Cluster
+		ctx     = context.Background()
+	)
+
+	BeforeEach(func() {
+		var err error
+		server, err = StartEtcdServer()
+		Expect(err).Should(BeNil())
+		manager = &Manager{
+			DBManagerBase: engines.DBManagerBase{
+				CurrentMemberName: server.ETCD.Config().Name,
+				Logger:            ctrl.Log.WithName("ETCD"),
+			},
+			etcd:     server.client,
+			endpoint: fmt.Sprintf("http://%s", server.ETCD.Clients[0].Addr().(*net.TCPAddr).String()),
+			peerPort: defaultPeerPort,
+		}
+		cluster = &dcs.Cluster{
+			ClusterCompName: "cluster-etcd",
+			Namespace:       "default",
+			Members:         []dcs.Member{{Name: manager.CurrentMemberName}},
+		}
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	Context("member name", func() {
+		It("get member name", func() {
+			Expect(GetMemberName(&pb.Member{Name: "etcd-0"})).Should(Equal("etcd-0"))
+			Expect(GetMemberName(&pb.Member{PeerURLs: []string{"http://etcd-1.etcd-headless.default.svc:2380"}})).Should(Equal("etcd-1"))
+			Expect(GetMemberName(&pb.Member{})).Should(BeEmpty())
+		})
+	})
+
+	Context("membership", func() {
+		It("join and leave", func() {
+			Expect(manager.IsCurrentMemberInCluster(ctx, cluster)).Should(BeTrue())
+			Expect(manager.GetMemberAddrs(ctx, cluster)).Should(HaveLen(1))
+			Expect(manager.joinMember(ctx, manager.etcd, manager.CurrentMemberName, "")).Should(Succeed())
+
+			newMember := dcs.Member{Name: "etcd-1"}
+			peerURL := manager.GetPeerURL(cluster, &newMember)
+			Expect(peerURL).Should(HavePrefix("http://etcd-1.cluster-etcd-headless.default.svc"))
+			Expect(peerURL).Should(HaveSuffix(":2380"))
+			Expect(manager.joinMember(ctx, manager.etcd, newMember.Name, peerURL)).Should(Succeed())
+
+			members, err := manager.GetMemberList(ctx, manager.etcd)
+			Expect(err).Should(Succeed())
+			Expect(members).Should(HaveLen(2))
+			learner := findMember(members, newMember.Name)
+			Expect(learner).ShouldNot(BeNil())
+			Expect(learner.IsLearner).Should(BeTrue())
+			// learners are not counted as members
+			Expect(manager.GetMemberAddrs(ctx, cluster)).Should(HaveLen(1))
+
+			// the learner never started, so it can't be promoted
+			Expect(manager.joinMember(ctx, manager.etcd, newMember.Name, peerURL)).ShouldNot(Succeed())
+
+			Expect(manager.LeaveMemberFromCluster(ctx, cluster, newMember.Name)).Should(Succeed())
+			members, err = manager.GetMemberList(ctx, manager.etcd)
+			Expect(err).Should(Succeed())
+			Expect(members).Should(HaveLen(1))
+			Expect(manager.LeaveMemberFromCluster(ctx, cluster, newMember.Name)).Should(Succeed())
+		})
+	})
+
+	Context("membership with a member not started", func() {
+		It("is not in the cluster and can't join", func() {
+			cluster.Members = append(cluster.Members, dcs.Member{Name: "etcd-1"})
+			Expect(manager.IsCurrentMemberInCluster(ctx, cluster)).Should(BeFalse())
+			Expect(manager.GetMemberAddrs(ctx, cluster)).Should(BeEmpty())
+			Expect(manager.IsClusterHealthy(ctx, cluster)).Should(BeFalse())
+			Expect(manager.JoinCurrentMemberToCluster(ctx, cluster)).ShouldNot(Succeed())
+		})
+	})
+
+	Context("health and leadership", func() {
+		It("single member cluster", func() {
+			Expect(manager.IsClusterHealthy(ctx, cluster)).Should(BeTrue())
+			Expect(manager.IsCurrentMemberHealthy(ctx, cluster)).Should(BeTrue())
+			Expect(manager.IsPromoted(ctx)).Should(BeTrue())
+			Expect(manager.Promote(ctx, cluster)).Should(Succeed())
+			Expect(manager.HasOtherHealthyLeader(ctx, cluster)).Should(BeNil())
+			Expect(manager.HasOtherHealthyMembers(ctx, cluster, manager.CurrentMemberName)).Should(BeEmpty())
+		})
+	})
+})
@@ -33,8 +33,10 @@ import (
 
 const (
 	endpoint = "endpoint"
+	peerPort = "peerPort"
 
 	defaultPort        = 2379
+	defaultPeerPort    = 2380
 	defaultDialTimeout = 600 * time.Millisecond
 )
 
@@ -42,6 +44,7 @@ type Manager struct {
 	engines.DBManagerBase
 	etcd     *v3.Client
 	endpoint string
+	peerPort int
 }
 
 var _ engines.DBManager = &Manager{}
@@ -56,6 +59,7 @@ func NewManager(properties engines.Properties) (engines.DBManager, error) {
 
 	mgr := &Manager{
 		DBManagerBase: *managerBase,
+		peerPort:      defaultPeerPort,
 	}
 
 	var endpoints []string
@@ -65,6 +69,13 @@ func NewManager(properties engines.Properties) (engines.DBManager, error) {
 		endpoints = []string{endpoint}
 	}
 
+	if port, ok := properties[peerPort]; ok {
+		mgr.peerPort, err = strconv.Atoi(port)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	cli, err := v3.New(v3.Config{
 		Endpoints:   endpoints,
 		DialTimeout: defaultDialTimeout,
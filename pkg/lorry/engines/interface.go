@@ -106,6 +106,9 @@ type DBManager interface {
 	Exec(context.Context, string) (int64, error)
 	Query(context.Context, string) ([]byte, error)
 
+	// GetParameters returns the values of the parameters which are effective in the running database
+	GetParameters(context.Context, []string) (map[string]string, error)
+
 	// user management
 	ListUsers(context.Context) ([]models.UserInfo, error)
 	ListSystemAccounts(context.Context) ([]models.UserInfo, error)
@@ -114,6 +117,8 @@ type DBManager interface {
 	DescribeUser(context.Context, string) (*models.UserInfo, error)
 	GrantUserRole(context.Context, string, string) error
 	RevokeUserRole(context.Context, string, string) error
+	UpdateUserPassword(context.Context, string, string, bool) error
+	DiscardUserOldPassword(context.Context, string, string) error
 
 	GetPort() (int, error)
 
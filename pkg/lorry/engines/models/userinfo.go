@@ -30,6 +30,11 @@ type UserInfo struct {
 	Expired  string        `json:"expired,omitempty"`
 	ExpireAt time.Duration `json:"expireAt,omitempty"`
 	RoleName string        `json:"roleName,omitempty"`
+
+	// OldPassword is the password to be discarded after the password is rotated.
+	OldPassword string `json:"oldPassword,omitempty"`
+	// RetainOldPassword indicates to keep the old password valid when the password is updated.
+	RetainOldPassword bool `json:"retainOldPassword,omitempty"`
 }
 
 func (user *UserInfo) UserNameValidator() error {
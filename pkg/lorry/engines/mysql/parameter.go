@@ -0,0 +1,59 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const showGlobalVariablesSQL = "SHOW GLOBAL VARIABLES;"
+
+// GetParameters returns the values of the global variables, the dashes and underscores in the names are interchangeable
+// as they are in the option files.
+func (mgr *Manager) GetParameters(ctx context.Context, names []string) (map[string]string, error) {
+	data, err := mgr.Query(ctx, showGlobalVariablesSQL)
+	if err != nil {
+		mgr.Logger.Error(err, "query global variables failed")
+		return nil, err
+	}
+	variables := make([]map[string]any, 0)
+	if err = json.Unmarshal(data, &variables); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		values[normalizeVariableName(fmt.Sprint(variable["Variable_name"]))] = fmt.Sprint(variable["Value"])
+	}
+	result := make(map[string]string, len(names))
+	for _, name := range names {
+		if value, ok := values[normalizeVariableName(name)]; ok {
+			result[name] = value
+		}
+	}
+	return result, nil
+}
+
+func normalizeVariableName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+}
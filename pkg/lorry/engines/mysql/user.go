@@ -46,6 +46,9 @@ const (
 	deleteUserSQL         = "DROP USER IF EXISTS '%s'@'%%';"
 	grantSQL              = "GRANT %s TO '%s'@'%%';"
 	revokeSQL             = "REVOKE %s FROM '%s'@'%%';"
+	updatePasswordSQL     = "ALTER USER '%s'@'%%' IDENTIFIED BY '%s';"
+	retainPasswordSQL     = "ALTER USER '%s'@'%%' IDENTIFIED BY '%s' RETAIN CURRENT PASSWORD;"
+	discardPasswordSQL    = "ALTER USER '%s'@'%%' DISCARD OLD PASSWORD;"
 	listSystemAccountsSQL = "SELECT user AS userName FROM mysql.user WHERE host = '%' and user like 'kb%';"
 )
 
@@ -166,6 +169,35 @@ func (mgr *Manager) RevokeUserRole(ctx context.Context, userName, roleName strin
 	return nil
 }
 
+// UpdateUserPassword updates the password of the user, the current password is kept as the secondary password
+// if retainOld is true, which requires MySQL 8.0.14 or later.
+func (mgr *Manager) UpdateUserPassword(ctx context.Context, userName, password string, retainOld bool) error {
+	sqlTpl := updatePasswordSQL
+	if retainOld {
+		sqlTpl = retainPasswordSQL
+	}
+	sql := fmt.Sprintf(sqlTpl, userName, password)
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
+// DiscardUserOldPassword discards the secondary password of the user.
+func (mgr *Manager) DiscardUserOldPassword(ctx context.Context, userName, _ string) error {
+	sql := fmt.Sprintf(discardPasswordSQL, userName)
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+
+	return nil
+}
+
 func role2Priv(roleName string) (string, error) {
 	roleType := models.String2RoleType(roleName)
 	switch roleType {
@@ -0,0 +1,52 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// the values are formatted with the units as they are in postgresql.conf, e.g. 128MB.
+const showSettingsSQL = "SELECT name, current_setting(name) AS value FROM pg_settings;"
+
+// GetParameters returns the current values of the settings.
+func (mgr *Manager) GetParameters(ctx context.Context, names []string) (map[string]string, error) {
+	data, err := mgr.Query(ctx, showSettingsSQL)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		values[fmt.Sprint(setting["name"])] = fmt.Sprint(setting["value"])
+	}
+	result := make(map[string]string, len(names))
+	for _, name := range names {
+		if value, ok := values[name]; ok {
+			result[name] = value
+		}
+	}
+	return result, nil
+}
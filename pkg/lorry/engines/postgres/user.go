@@ -63,6 +63,7 @@ const (
 	dropUserTpl           = "DROP USER IF EXISTS %s;"
 	grantTpl              = "GRANT %s TO %s;"
 	revokeTpl             = "REVOKE %s FROM %s;"
+	updatePasswordTpl     = "ALTER USER %s WITH PASSWORD '%s';"
 	listSystemAccountsTpl = "SELECT rolname FROM pg_catalog.pg_roles WHERE pg_roles.rolname LIKE 'kb%'"
 )
 
@@ -181,6 +182,24 @@ func (mgr *Manager) RevokeUserRole(ctx context.Context, userName, roleName strin
 	return nil
 }
 
+// UpdateUserPassword updates the password of the user, PostgreSQL does not support multiple passwords for a user,
+// so the old password is invalid immediately even if retainOld is true.
+func (mgr *Manager) UpdateUserPassword(ctx context.Context, userName, password string, _ bool) error {
+	sql := fmt.Sprintf(updatePasswordTpl, userName, password)
+	_, err := mgr.Exec(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
+// DiscardUserOldPassword does nothing since the old password is invalid once the password is updated.
+func (mgr *Manager) DiscardUserOldPassword(context.Context, string, string) error {
+	return nil
+}
+
 // post-processing
 func pgUserRolesProcessor(data interface{}) ([]models.UserInfo, error) {
 	type pgUserInfo struct {
@@ -21,7 +21,6 @@ package redis
 
 import (
 	"context"
-	"strings"
 
 	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
@@ -30,22 +29,12 @@ import (
 func (mgr *Manager) GetReplicaRole(ctx context.Context, cluster *dcs.Cluster) (string, error) {
 	section := "Replication"
 
-	var role string
 	result, err := mgr.client.Info(ctx, section).Result()
 	if err != nil {
 		mgr.Logger.Error(err, "Role query error")
-		return role, err
-	} else {
-		// split the result into lines
-		lines := strings.Split(result, "\r\n")
-		// find the line with role
-		for _, line := range lines {
-			if strings.HasPrefix(line, "role:") {
-				role = strings.Split(line, ":")[1]
-				break
-			}
-		}
+		return "", err
 	}
+	role := parseReplicationInfo(result)["role"]
 	if role == models.MASTER {
 		return models.PRIMARY, nil
 	}
@@ -0,0 +1,426 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+)
+
+const (
+	healthCheckKey = "kb_health_check"
+
+	// demotePauseTimeout bounds how long a demoted primary rejects writes
+	// before it starts following the new leader. It is longer than the HA
+	// cycle interval, so the pause is renewed by the demote of the next cycle
+	// without a gap while the lease can not be renewed.
+	demotePauseTimeout = 30 * time.Second
+)
+
+// parseReplicationInfo parses the output of `INFO replication` into a key-value map.
+func parseReplicationInfo(info string) map[string]string {
+	result := map[string]string{}
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		result[kv[0]] = kv[1]
+	}
+	return result
+}
+
+// getReplicationOffset returns the replication offset reported by the server.
+// A replica reports the offset it has processed from its primary as master_repl_offset,
+// so the value is comparable across all members of a replication group.
+func getReplicationOffset(replicationInfo map[string]string) (int64, error) {
+	offset, ok := replicationInfo["master_repl_offset"]
+	if !ok {
+		return 0, errors.New("master_repl_offset not found in replication info")
+	}
+	return strconv.ParseInt(offset, 10, 64)
+}
+
+func (mgr *Manager) GetMemberClient(cluster *dcs.Cluster, member *dcs.Member) redis.UniversalClient {
+	if member == nil || member.Name == mgr.CurrentMemberName {
+		return mgr.client
+	}
+
+	addr := cluster.GetMemberAddrWithPort(*member)
+	mgr.memberClientsLock.Lock()
+	defer mgr.memberClientsLock.Unlock()
+	if client, ok := mgr.memberClients[addr]; ok {
+		return client
+	}
+
+	settings := *mgr.clientSettings
+	settings.Host = addr
+	settings.RedisType = NodeType
+	client := newClient(&settings)
+	mgr.memberClients[addr] = client
+	return client
+}
+
+func (mgr *Manager) GetReplicationInfo(ctx context.Context, client redis.UniversalClient) (map[string]string, error) {
+	result, err := client.Info(ctx, "replication").Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseReplicationInfo(result), nil
+}
+
+func (mgr *Manager) IsLeader(ctx context.Context, cluster *dcs.Cluster) (bool, error) {
+	return mgr.IsLeaderMember(ctx, cluster, nil)
+}
+
+func (mgr *Manager) IsLeaderMember(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) (bool, error) {
+	replicationInfo, err := mgr.GetReplicationInfo(ctx, mgr.GetMemberClient(cluster, member))
+	if err != nil {
+		mgr.Logger.Info("Get replication info failed", "error", err.Error())
+		return false, err
+	}
+
+	return replicationInfo["role"] == models.MASTER, nil
+}
+
+func (mgr *Manager) GetMemberAddrs(_ context.Context, cluster *dcs.Cluster) []string {
+	return cluster.GetMemberAddrs()
+}
+
+func (mgr *Manager) IsCurrentMemberInCluster(context.Context, *dcs.Cluster) bool {
+	return true
+}
+
+func (mgr *Manager) IsClusterInitialized(context.Context, *dcs.Cluster) (bool, error) {
+	return true, nil
+}
+
+func (mgr *Manager) IsCurrentMemberHealthy(ctx context.Context, cluster *dcs.Cluster) bool {
+	member := cluster.GetMemberWithName(mgr.CurrentMemberName)
+
+	return mgr.IsMemberHealthy(ctx, cluster, member)
+}
+
+func (mgr *Manager) IsMemberHealthy(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) bool {
+	client := mgr.GetMemberClient(cluster, member)
+
+	if member != nil && cluster.Leader != nil && cluster.Leader.Name == member.Name {
+		if !mgr.WriteCheck(ctx, client) {
+			return false
+		}
+	}
+
+	return mgr.ReadCheck(ctx, client)
+}
+
+func (mgr *Manager) WriteCheck(ctx context.Context, client redis.UniversalClient) bool {
+	err := client.Set(ctx, healthCheckKey, time.Now().Unix(), 0).Err()
+	if err != nil {
+		mgr.Logger.Info("Write check failed", "error", err.Error())
+		return false
+	}
+	return true
+}
+
+func (mgr *Manager) ReadCheck(ctx context.Context, client redis.UniversalClient) bool {
+	err := client.Ping(ctx).Err()
+	if err != nil {
+		mgr.Logger.Info("Read check failed", "error", err.Error())
+		return false
+	}
+	return true
+}
+
+func (mgr *Manager) IsMemberLagging(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) (bool, int64) {
+	if cluster.Leader == nil || cluster.Leader.DBState == nil {
+		mgr.Logger.Info("No leader DBState info")
+		return true, 0
+	}
+	leaderDBState := cluster.Leader.DBState
+
+	replicationInfo, err := mgr.GetReplicationInfo(ctx, mgr.GetMemberClient(cluster, member))
+	if err != nil {
+		mgr.Logger.Info("Get replication info failed", "error", err.Error())
+		return true, 0
+	}
+
+	offset, err := getReplicationOffset(replicationInfo)
+	if err != nil {
+		mgr.Logger.Info("Get replication offset failed", "error", err.Error())
+		return true, 0
+	}
+	lag := leaderDBState.OpTimestamp - offset
+	if lag <= cluster.HaConfig.GetMaxLagOnSwitchover() {
+		return false, lag
+	}
+	mgr.Logger.Info(fmt.Sprintf("The member %s has lag: %d", member.Name, lag))
+	return true, lag
+}
+
+func (mgr *Manager) GetLag(ctx context.Context, cluster *dcs.Cluster) (int64, error) {
+	if cluster.Leader == nil || cluster.Leader.DBState == nil {
+		return 0, errors.New("no leader DBState info")
+	}
+
+	replicationInfo, err := mgr.GetReplicationInfo(ctx, mgr.client)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := getReplicationOffset(replicationInfo)
+	if err != nil {
+		return 0, err
+	}
+	return cluster.Leader.DBState.OpTimestamp - offset, nil
+}
+
+func (mgr *Manager) GetDBState(ctx context.Context, cluster *dcs.Cluster) *dcs.DBState {
+	mgr.DBState = nil
+
+	replicationInfo, err := mgr.GetReplicationInfo(ctx, mgr.client)
+	if err != nil {
+		mgr.Logger.Info("Get replication info failed", "error", err.Error())
+		return nil
+	}
+
+	offset, err := getReplicationOffset(replicationInfo)
+	if err != nil {
+		mgr.Logger.Info("Get replication offset failed", "error", err.Error())
+		return nil
+	}
+
+	dbState := &dcs.DBState{
+		OpTimestamp: offset,
+		Extra: map[string]string{
+			"role":          replicationInfo["role"],
+			"master_replid": replicationInfo["master_replid"],
+		},
+	}
+	if replicationInfo["role"] == models.SLAVE {
+		dbState.Extra["master_host"] = replicationInfo["master_host"]
+		dbState.Extra["master_port"] = replicationInfo["master_port"]
+		dbState.Extra["master_link_status"] = replicationInfo["master_link_status"]
+	} else {
+		dbState.Extra["connected_slaves"] = replicationInfo["connected_slaves"]
+	}
+
+	mgr.replicationInfo = replicationInfo
+	mgr.DBState = dbState
+
+	return dbState
+}
+
+func (mgr *Manager) Promote(ctx context.Context, cluster *dcs.Cluster) error {
+	if mgr.replicationInfo["role"] == models.MASTER {
+		return mgr.unpauseWrites(ctx)
+	}
+
+	err := mgr.client.Do(ctx, "REPLICAOF", "NO", "ONE").Err()
+	if err != nil {
+		mgr.Logger.Info("promote failed", "error", err.Error())
+		return err
+	}
+	if err = mgr.unpauseWrites(ctx); err != nil {
+		return err
+	}
+
+	// fresh db state
+	mgr.GetDBState(ctx, cluster)
+	mgr.Logger.Info("promote success")
+	return nil
+}
+
+// Demote pauses writes on a primary until it follows the new leader.
+// A replica rejects writes already, so nothing needs to be done.
+func (mgr *Manager) Demote(ctx context.Context) error {
+	isLeader, err := mgr.IsLeader(ctx, nil)
+	if err != nil || !isLeader {
+		return err
+	}
+
+	err = mgr.client.Do(ctx, "CLIENT", "PAUSE", demotePauseTimeout.Milliseconds(), "WRITE").Err()
+	if err != nil {
+		mgr.Logger.Info("demote failed", "error", err.Error())
+		return err
+	}
+	mgr.writesPaused = true
+	return nil
+}
+
+func (mgr *Manager) unpauseWrites(ctx context.Context) error {
+	if !mgr.writesPaused {
+		return nil
+	}
+	err := mgr.client.Do(ctx, "CLIENT", "UNPAUSE").Err()
+	if err != nil {
+		mgr.Logger.Info("unpause writes failed", "error", err.Error())
+		return err
+	}
+	mgr.writesPaused = false
+	return nil
+}
+
+func (mgr *Manager) Follow(ctx context.Context, cluster *dcs.Cluster) error {
+	leaderMember := cluster.GetLeaderMember()
+	if leaderMember == nil {
+		return fmt.Errorf("cluster has no leader")
+	}
+
+	if mgr.CurrentMemberName == cluster.Leader.Name {
+		mgr.Logger.Info("i get the leader key, don't need to follow")
+		return nil
+	}
+
+	if !mgr.isRecoveryConfOutdated(cluster.Leader.Name) {
+		return nil
+	}
+
+	if mgr.clientSettings.Password != "" {
+		err := mgr.client.ConfigSet(ctx, "masteruser", mgr.clientSettings.Username).Err()
+		if err != nil {
+			mgr.Logger.Info("set masteruser failed", "error", err.Error())
+			return err
+		}
+		err = mgr.client.ConfigSet(ctx, "masterauth", mgr.clientSettings.Password).Err()
+		if err != nil {
+			mgr.Logger.Info("set masterauth failed", "error", err.Error())
+			return err
+		}
+	}
+
+	masterHost := cluster.GetMemberAddr(*leaderMember)
+	mgr.Logger.Info("follow new leader", "host", masterHost, "port", leaderMember.DBPort)
+	err := mgr.client.Do(ctx, "REPLICAOF", masterHost, leaderMember.DBPort).Err()
+	if err != nil {
+		mgr.Logger.Info("Follow master failed", "error", err.Error())
+		return err
+	}
+
+	// writes paused by demote are rejected by the replica from now on
+	_ = mgr.unpauseWrites(ctx)
+
+	// fresh db state
+	mgr.GetDBState(ctx, cluster)
+	mgr.Logger.Info("successfully follow new leader", "leader-name", leaderMember.Name)
+	return nil
+}
+
+func (mgr *Manager) isRecoveryConfOutdated(leader string) bool {
+	replicationInfo := mgr.replicationInfo
+	if len(replicationInfo) == 0 || replicationInfo["role"] != models.SLAVE {
+		return true
+	}
+
+	masterHost := replicationInfo["master_host"]
+	return !strings.HasPrefix(masterHost, leader)
+}
+
+// GetHealthiestMember returns the healthy member with the largest replication offset,
+// the candidate is preferred if it is healthy.
+func (mgr *Manager) GetHealthiestMember(cluster *dcs.Cluster, candidate string) *dcs.Member {
+	ctx := context.TODO()
+	if candidate != "" {
+		member := cluster.GetMemberWithName(candidate)
+		if member != nil && mgr.IsMemberHealthy(ctx, cluster, member) {
+			return member
+		}
+	}
+
+	var healthiest *dcs.Member
+	var maxOffset int64 = -1
+	for i := range cluster.Members {
+		member := &cluster.Members[i]
+		if !mgr.IsMemberHealthy(ctx, cluster, member) {
+			continue
+		}
+		replicationInfo, err := mgr.GetReplicationInfo(ctx, mgr.GetMemberClient(cluster, member))
+		if err != nil {
+			continue
+		}
+		offset, err := getReplicationOffset(replicationInfo)
+		if err != nil {
+			continue
+		}
+		if offset > maxOffset {
+			healthiest = member
+			maxOffset = offset
+		}
+	}
+	return healthiest
+}
+
+func (mgr *Manager) HasOtherHealthyLeader(ctx context.Context, cluster *dcs.Cluster) *dcs.Member {
+	isLeader, err := mgr.IsLeader(ctx, cluster)
+	if err == nil && isLeader {
+		// if current member is leader, just return
+		return nil
+	}
+
+	for _, member := range cluster.Members {
+		if member.Name == mgr.CurrentMemberName {
+			continue
+		}
+
+		isLeader, err := mgr.IsLeaderMember(ctx, cluster, &member)
+		if err == nil && isLeader {
+			return &member
+		}
+	}
+
+	return nil
+}
+
+func (mgr *Manager) HasOtherHealthyMembers(ctx context.Context, cluster *dcs.Cluster, leader string) []*dcs.Member {
+	members := make([]*dcs.Member, 0)
+	for i := range cluster.Members {
+		member := &cluster.Members[i]
+		if member.Name == leader {
+			continue
+		}
+		if !mgr.IsMemberHealthy(ctx, cluster, member) {
+			continue
+		}
+		members = append(members, member)
+	}
+
+	return members
+}
+
+func (mgr *Manager) ShutDownWithWait() {
+	mgr.memberClientsLock.Lock()
+	defer mgr.memberClientsLock.Unlock()
+	for _, client := range mgr.memberClients {
+		_ = client.Close()
+	}
+	mgr.memberClients = make(map[string]redis.UniversalClient)
+}
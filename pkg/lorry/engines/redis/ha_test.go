@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package redis
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	masterReplicationInfo = "# Replication\r\nrole:master\r\nconnected_slaves:1\r\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=1024,lag=0\r\n" +
+		"master_replid:8e2bd5b4c1b4c4d6e6bb3e0c31d7a1a0c9d3f5e7\r\nmaster_repl_offset:1024\r\n"
+	slaveReplicationInfo = "# Replication\r\nrole:slave\r\n" +
+		"master_host:redis-redis-0.redis-redis-headless.default.svc.cluster.local\r\nmaster_port:6379\r\n" +
+		"master_link_status:up\r\nslave_repl_offset:1000\r\nmaster_repl_offset:1000\r\n"
+)
+
+var _ = Describe("Redis HA", func() {
+	Context("parse replication info", func() {
+		It("master", func() {
+			info := parseReplicationInfo(masterReplicationInfo)
+			Expect(info["role"]).Should(Equal("master"))
+			Expect(info["connected_slaves"]).Should(Equal("1"))
+			Expect(info["slave0"]).Should(Equal("ip=10.0.0.2,port=6379,state=online,offset=1024,lag=0"))
+			offset, err := getReplicationOffset(info)
+			Expect(err).Should(Succeed())
+			Expect(offset).Should(BeEquivalentTo(1024))
+		})
+
+		It("slave", func() {
+			info := parseReplicationInfo(slaveReplicationInfo)
+			Expect(info["role"]).Should(Equal("slave"))
+			Expect(info["master_link_status"]).Should(Equal("up"))
+			offset, err := getReplicationOffset(info)
+			Expect(err).Should(Succeed())
+			Expect(offset).Should(BeEquivalentTo(1000))
+		})
+
+		It("without offset", func() {
+			_, err := getReplicationOffset(parseReplicationInfo("# Replication\r\nrole:master\r\n"))
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("recovery conf", func() {
+		It("is outdated", func() {
+			mgr := &Manager{}
+			Expect(mgr.isRecoveryConfOutdated("redis-redis-0")).Should(BeTrue())
+
+			mgr.replicationInfo = parseReplicationInfo(masterReplicationInfo)
+			Expect(mgr.isRecoveryConfOutdated("redis-redis-0")).Should(BeTrue())
+
+			mgr.replicationInfo = parseReplicationInfo(slaveReplicationInfo)
+			Expect(mgr.isRecoveryConfOutdated("redis-redis-1")).Should(BeTrue())
+		})
+
+		It("is up to date", func() {
+			mgr := &Manager{replicationInfo: parseReplicationInfo(slaveReplicationInfo)}
+			Expect(mgr.isRecoveryConfOutdated("redis-redis-0")).Should(BeFalse())
+		})
+	})
+})
@@ -22,6 +22,7 @@ package redis
 import (
 	"context"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -42,6 +43,11 @@ type Manager struct {
 	client         redis.UniversalClient
 	clientSettings *Settings
 
+	memberClients     map[string]redis.UniversalClient
+	memberClientsLock sync.Mutex
+	replicationInfo   map[string]string
+	writesPaused      bool
+
 	ctx     context.Context
 	cancel  context.CancelFunc
 	startAt time.Time
@@ -66,6 +72,7 @@ func NewManager(properties engines.Properties) (engines.DBManager, error) {
 	}
 	mgr := &Manager{
 		DBManagerBase: *managerBase,
+		memberClients: make(map[string]redis.UniversalClient),
 	}
 
 	mgr.startAt = time.Now()
@@ -0,0 +1,40 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package redis
+
+import (
+	"context"
+)
+
+// GetParameters returns the values of the parameters by CONFIG GET.
+func (mgr *Manager) GetParameters(ctx context.Context, names []string) (map[string]string, error) {
+	result := make(map[string]string, len(names))
+	for _, name := range names {
+		values, err := mgr.client.ConfigGet(ctx, name).Result()
+		if err != nil {
+			mgr.Logger.Error(err, "config get failed", "parameter", name)
+			return nil, err
+		}
+		if value, ok := values[name]; ok {
+			result[name] = value
+		}
+	}
+	return result, nil
+}
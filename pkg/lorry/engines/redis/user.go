@@ -37,6 +37,12 @@ const (
 	dropUserTpl   = "ACL DELUSER %s"
 	grantTpl      = "ACL SETUSER %s %s"
 	revokeTpl     = "ACL SETUSER %s %s"
+	// the passwords of the user are reset before adding the new password if the old password is not retained.
+	updatePasswordTpl = "ACL SETUSER %s resetpass >%s"
+	retainPasswordTpl = "ACL SETUSER %s >%s"
+	removePasswordTpl = "ACL SETUSER %s <%s"
+	// the error message returned by redis if the password to remove does not exist.
+	passwordNotExistErrMsg = "password you are trying to remove from the user does not exist"
 )
 
 var (
@@ -176,6 +182,33 @@ func (mgr *Manager) RevokeUserRole(ctx context.Context, userName, roleName strin
 	return nil
 }
 
+// UpdateUserPassword adds the new password to the user, the old passwords are removed unless retainOld is true.
+func (mgr *Manager) UpdateUserPassword(ctx context.Context, userName, password string, retainOld bool) error {
+	sqlTpl := updatePasswordTpl
+	if retainOld {
+		sqlTpl = retainPasswordTpl
+	}
+	_, err := mgr.Exec(ctx, fmt.Sprintf(sqlTpl, userName, password))
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
+// DiscardUserOldPassword removes the old password from the user, it succeeds if the password has been removed,
+// since the rotation may be retried on the replicas which have discarded the password.
+func (mgr *Manager) DiscardUserOldPassword(ctx context.Context, userName, oldPassword string) error {
+	_, err := mgr.Exec(ctx, fmt.Sprintf(removePasswordTpl, userName, oldPassword))
+	if err != nil && !strings.Contains(err.Error(), passwordNotExistErrMsg) {
+		mgr.Logger.Error(err, "execute sql failed", "user", userName)
+		return err
+	}
+
+	return nil
+}
+
 func role2Priv(prefix, roleName string) string {
 	var command string
 
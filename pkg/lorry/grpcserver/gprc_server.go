@@ -31,10 +31,12 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	health "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/apecloud/kubeblocks/pkg/lorry/auth"
 	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
 	"github.com/apecloud/kubeblocks/pkg/lorry/util"
 )
@@ -86,7 +88,15 @@ func (s *GRPCServer) StartNonBlocking() error {
 	if err != nil {
 		return errors.Wrap(err, "grpc server listen failed")
 	}
-	server := grpc.NewServer()
+	tlsConfig, err := auth.ServerTLSConfig(auth.GetConfig())
+	if err != nil {
+		return err
+	}
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := grpc.NewServer(opts...)
 	health.RegisterHealthServer(server, s)
 
 	go func() {
@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package highavailability
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+	k8s "github.com/apecloud/kubeblocks/pkg/lorry/util/kubernetes"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// the flags to configure the fencing of the previous leader before promotion.
+const (
+	FencingActionFlag  = "fencing-action"
+	FencingCommandFlag = "fencing-command"
+	FencingTimeoutFlag = "fencing-timeout"
+)
+
+// the fencing actions, selected by the --fencing-action flag.
+const (
+	NoneFencingAction    = "none"
+	LabelFencingAction   = "label"
+	CommandFencingAction = "command"
+)
+
+// the env variables passed to the fencing command.
+const (
+	fencingMemberEnv     = "KB_FENCING_MEMBER"
+	fencingMemberAddrEnv = "KB_FENCING_MEMBER_ADDR"
+)
+
+func init() {
+	viper.SetDefault(FencingActionFlag, NoneFencingAction)
+	viper.SetDefault(FencingTimeoutFlag, "10s")
+}
+
+// Fencer isolates a member which may still serve as the leader, so that it can not
+// accept writes after a new leader is promoted.
+type Fencer interface {
+	Fence(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) error
+}
+
+// NewFencer creates the fencer of the configured fencing action, it returns nil
+// if no fencing action is configured.
+func NewFencer() (Fencer, error) {
+	switch action := viper.GetString(FencingActionFlag); action {
+	case "", NoneFencingAction:
+		return nil, nil
+	case LabelFencingAction:
+		clientset, err := k8s.GetClientSet()
+		if err != nil {
+			return nil, errors.Wrap(err, "clientset init failed")
+		}
+		return &labelFencer{clientset: clientset}, nil
+	case CommandFencingAction:
+		command := viper.GetString(FencingCommandFlag)
+		if command == "" {
+			return nil, errors.Errorf("%s must be set for the command fencing action", FencingCommandFlag)
+		}
+		return &commandFencer{command: command}, nil
+	default:
+		return nil, errors.Errorf("unsupported fencing action: %s", action)
+	}
+}
+
+// labelFencer removes the role label from the pod of the member, which removes the
+// pod from the endpoints of the Services selecting the leader by role.
+type labelFencer struct {
+	clientset kubernetes.Interface
+}
+
+func (f *labelFencer) Fence(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) error {
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:null}}}`, constant.RoleLabelKey)
+	_, err := f.clientset.CoreV1().Pods(cluster.Namespace).Patch(ctx, member.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		// the pod of the member is gone, it can't accept writes any more.
+		return nil
+	}
+	return err
+}
+
+// commandFencer runs a user defined command, the member to fence is passed by the
+// KB_FENCING_MEMBER and KB_FENCING_MEMBER_ADDR env variables.
+type commandFencer struct {
+	command string
+}
+
+func (f *commandFencer) Fence(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", f.command)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("%s=%s", fencingMemberEnv, member.Name),
+		fmt.Sprintf("%s=%s", fencingMemberAddrEnv, cluster.GetMemberAddr(*member)))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "fencing command failed, output: %s", string(output))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package highavailability
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestNewFencer(t *testing.T) {
+	defer viper.Set(FencingActionFlag, NoneFencingAction)
+
+	fencer, err := NewFencer()
+	assert.NoError(t, err)
+	assert.Nil(t, fencer)
+
+	viper.Set(FencingActionFlag, CommandFencingAction)
+	_, err = NewFencer()
+	assert.Error(t, err)
+
+	viper.Set(FencingCommandFlag, "true")
+	fencer, err = NewFencer()
+	assert.NoError(t, err)
+	assert.IsType(t, &commandFencer{}, fencer)
+
+	viper.Set(FencingActionFlag, "unknown")
+	_, err = NewFencer()
+	assert.Error(t, err)
+}
+
+func TestLabelFencer(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-mysql-0",
+			Namespace: "default",
+			Labels: map[string]string{
+				constant.RoleLabelKey:        "primary",
+				constant.AppInstanceLabelKey: "test",
+			},
+		},
+	}
+	clientset := fake.NewSimpleClientset(pod)
+	fencer := &labelFencer{clientset: clientset}
+	cluster := &dcs.Cluster{ClusterCompName: "test-mysql", Namespace: "default"}
+
+	require.NoError(t, fencer.Fence(context.Background(), cluster, &dcs.Member{Name: "test-mysql-0"}))
+	pod, err := clientset.CoreV1().Pods("default").Get(context.Background(), "test-mysql-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, pod.Labels, constant.RoleLabelKey)
+	assert.Equal(t, "test", pod.Labels[constant.AppInstanceLabelKey])
+
+	// the member is fenced already if its pod is not found
+	assert.NoError(t, fencer.Fence(context.Background(), cluster, &dcs.Member{Name: "test-mysql-1"}))
+
+	clientset.PrependReactor("patch", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	assert.Error(t, fencer.Fence(context.Background(), cluster, &dcs.Member{Name: "test-mysql-0"}))
+}
+
+func TestCommandFencer(t *testing.T) {
+	cluster := &dcs.Cluster{ClusterCompName: "test-mysql", Namespace: "default"}
+	member := &dcs.Member{Name: "test-mysql-0"}
+
+	fencer := &commandFencer{command: `test "$KB_FENCING_MEMBER" = test-mysql-0 && test -n "$KB_FENCING_MEMBER_ADDR"`}
+	assert.NoError(t, fencer.Fence(context.Background(), cluster, member))
+
+	fencer = &commandFencer{command: "echo unreachable; exit 1"}
+	err := fencer.Fence(context.Background(), cluster, member)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unreachable")
+}
@@ -35,15 +35,24 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+// cycleInterval is the interval of the HA cycles.
+const cycleInterval = 10 * time.Second
+
 type Ha struct {
 	ctx               context.Context
 	dbManager         engines.DBManager
 	dcs               dcs3.DCS
+	fencer            Fencer
 	logger            logr.Logger
 	deleteLock        sync.Mutex
 	disableDNSChecker bool
+	// leaseRenewedAt is the last time the current member acquired or renewed the
+	// leader lease, it is zero if the current member does not hold the lease.
+	leaseRenewedAt time.Time
+	leaseTTL       time.Duration
 }
 
 var ha *Ha
@@ -58,9 +67,16 @@ func NewHa(disableDNSChecker bool) *Ha {
 		return nil
 	}
 
+	fencer, err := NewFencer()
+	if err != nil {
+		logger.Error(err, "Fencer init failed")
+		return nil
+	}
+
 	ha = &Ha{
 		ctx:               context.Background(),
 		dcs:               dcs,
+		fencer:            fencer,
 		logger:            logger,
 		dbManager:         manager,
 		disableDNSChecker: disableDNSChecker,
@@ -76,6 +92,7 @@ func (ha *Ha) RunCycle() {
 	cluster, err := ha.dcs.GetCluster()
 	if err != nil {
 		ha.logger.Error(err, "Get Cluster failed")
+		ha.demoteIfLeaseExpiring()
 		return
 	}
 
@@ -94,7 +111,7 @@ func (ha *Ha) RunCycle() {
 	if !ha.dbManager.IsRunning() {
 		ha.logger.Info("DB Service is not running,  wait for hypervisor to start it")
 		if ha.dcs.HasLease() {
-			_ = ha.dcs.ReleaseLease()
+			ha.releaseLease()
 		}
 		_ = ha.dbManager.Start(ha.ctx, cluster)
 		return
@@ -120,7 +137,9 @@ func (ha *Ha) RunCycle() {
 	case !ha.dbManager.IsCurrentMemberHealthy(ha.ctx, cluster):
 		ha.logger.Info("DB Service is not healthy,  do some recover")
 		if ha.dcs.HasLease() {
-			_ = ha.dcs.ReleaseLease()
+			// demote before releasing the lease, in case the member still accepts writes
+			_ = ha.dbManager.Demote(ha.ctx)
+			ha.releaseLease()
 		}
 	//	dbManager.Recover()
 
@@ -129,16 +148,31 @@ func (ha *Ha) RunCycle() {
 		if !ha.IsHealthiestMember(ha.ctx, cluster) {
 			break
 		}
+		previousLeader := *cluster.Leader
+		_, lag := ha.dbManager.IsMemberLagging(ha.ctx, cluster, currentMember)
+
+		// the previous leader may be partitioned rather than down, it must be fenced
+		// before the lease is taken, so that the lease keeps its holder for a retry
+		// if the fencing fails.
+		if err := ha.fence(ha.ctx, cluster, cluster.Leader.Holder); err != nil {
+			ha.logger.Error(err, "Fence the previous leader failed", "leader", cluster.Leader.Holder)
+			break
+		}
 
 		cluster.Leader.DBState = DBState
 		if ha.dcs.AttemptAcquireLease() != nil {
 			break
 		}
+		ha.markLeaseRenewed(cluster)
 
+		// record the promotion before promoting, the role probe may report the new role
+		// before Promote returns.
+		recordPromotion(cluster, &previousLeader, lag)
 		err := ha.dbManager.Promote(ha.ctx, cluster)
 		if err != nil {
 			ha.logger.Error(err, "Take the leader failed")
-			_ = ha.dcs.ReleaseLease()
+			clearPromotion()
+			ha.releaseLease()
 			break
 		}
 		cluster.Leader.Name = ha.dbManager.GetCurrentMemberName()
@@ -153,7 +187,7 @@ func (ha *Ha) RunCycle() {
 				(cluster.Switchover.Candidate != "" && cluster.Switchover.Candidate != ha.dbManager.GetCurrentMemberName()) {
 				if ha.HasOtherHealthyMember(cluster) {
 					_ = ha.dbManager.Demote(ha.ctx)
-					_ = ha.dcs.ReleaseLease()
+					ha.releaseLease()
 					break
 				}
 
@@ -171,7 +205,7 @@ func (ha *Ha) RunCycle() {
 			// role services as the source of truth.
 			// for replicationSet cluster,  HasOtherHealthyLeader will always be false.
 			ha.logger.Info("Release leader")
-			_ = ha.dcs.ReleaseLease()
+			ha.releaseLease()
 			break
 		}
 		err = ha.dbManager.Promote(ha.ctx, cluster)
@@ -181,13 +215,19 @@ func (ha *Ha) RunCycle() {
 		}
 
 		ha.logger.Info("Refresh leader ttl")
-		_ = ha.dcs.UpdateLease()
+		if err = ha.dcs.UpdateLease(); err != nil {
+			ha.logger.Error(err, "Refresh leader ttl failed")
+			ha.demoteIfLeaseExpiring()
+			break
+		}
+		ha.markLeaseRenewed(cluster)
 
 		if int(cluster.Replicas) < len(ha.dbManager.GetMemberAddrs(ha.ctx, cluster)) && cluster.Replicas != 0 {
 			ha.DecreaseClusterReplicas(cluster)
 		}
 
 	case !ha.dcs.HasLease():
+		ha.leaseRenewedAt = time.Time{}
 		if cluster.Switchover != nil {
 			break
 		}
@@ -278,8 +318,8 @@ func (ha *Ha) Start() {
 		startAt := time.Now()
 		ha.RunCycle()
 		duration := time.Since(startAt)
-		if duration < 10*time.Second {
-			time.Sleep(10*time.Second - duration)
+		if duration < cycleInterval {
+			time.Sleep(cycleInterval - duration)
 		}
 	}
 }
@@ -296,7 +336,7 @@ func (ha *Ha) DecreaseClusterReplicas(cluster *dcs3.Cluster) {
 		ha.logger.Info(fmt.Sprintf("The last pod %s is the primary member and cannot be deleted. waiting "+
 			"for The controller to perform a switchover to a new primary member before this pod can be removed. ", deleteHost))
 		_ = ha.dbManager.Demote(ha.ctx)
-		_ = ha.dcs.ReleaseLease()
+		ha.releaseLease()
 		return
 	}
 	memberName := strings.Split(deleteHost, ".")[0]
@@ -438,6 +478,50 @@ func (ha *Ha) isMinimumLag(ctx context.Context, cluster *dcs3.Cluster, member *d
 	return true
 }
 
+func (ha *Ha) releaseLease() {
+	ha.leaseRenewedAt = time.Time{}
+	_ = ha.dcs.ReleaseLease()
+}
+
+func (ha *Ha) markLeaseRenewed(cluster *dcs3.Cluster) {
+	ha.leaseRenewedAt = time.Now()
+	ha.leaseTTL = time.Duration(cluster.HaConfig.GetTTL()) * time.Second
+}
+
+// demoteIfLeaseExpiring demotes the current member if it fails to renew the lease
+// and the lease would expire before the next cycle, since another member may take
+// over the leader once the lease expires. The demotion is repeated in every cycle
+// until the lease is renewed or found to be held by others, as the demotion of
+// some engines is not persistent, e.g. the write pause of redis expires.
+func (ha *Ha) demoteIfLeaseExpiring() {
+	if ha.leaseRenewedAt.IsZero() || time.Since(ha.leaseRenewedAt)+cycleInterval < ha.leaseTTL {
+		return
+	}
+
+	ha.logger.Info("The lease can not be renewed before it expires, demote the current member")
+	if err := ha.dbManager.Demote(ha.ctx); err != nil {
+		ha.logger.Error(err, "Demote failed")
+	}
+}
+
+// fence fences the previous leader before the current member is promoted,
+// it is a no-op if no fencing action is configured.
+func (ha *Ha) fence(ctx context.Context, cluster *dcs3.Cluster, leaderName string) error {
+	if ha.fencer == nil || leaderName == "" || leaderName == ha.dbManager.GetCurrentMemberName() {
+		return nil
+	}
+
+	member := cluster.GetMemberWithName(leaderName)
+	if member == nil {
+		member = &dcs3.Member{Name: leaderName}
+	}
+
+	ha.logger.Info("Fence the previous leader", "leader", leaderName)
+	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration(FencingTimeoutFlag))
+	defer cancel()
+	return ha.fencer.Fence(ctx, cluster, member)
+}
+
 func (ha *Ha) ShutdownWithWait() {
 	ha.dbManager.ShutDownWithWait()
 }
@@ -0,0 +1,253 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package highavailability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
+)
+
+func newTestHa(mockCtrl *gomock.Controller, store *dcs.MemoryStore, memberName string) (*Ha, *engines.MockDBManager) {
+	manager := engines.NewMockDBManager(mockCtrl)
+	manager.EXPECT().GetCurrentMemberName().Return(memberName).AnyTimes()
+	manager.EXPECT().IsRunning().Return(true).AnyTimes()
+	manager.EXPECT().GetDBState(gomock.Any(), gomock.Any()).Return(&dcs.DBState{}).AnyTimes()
+	manager.EXPECT().IsClusterHealthy(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	manager.EXPECT().IsCurrentMemberInCluster(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	manager.EXPECT().GetMemberAddrs(gomock.Any(), gomock.Any()).Return([]string{"test-mysql-0", "test-mysql-1"}).AnyTimes()
+	manager.EXPECT().IsCurrentMemberHealthy(gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	manager.EXPECT().IsMemberHealthy(gomock.Any(), gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	manager.EXPECT().IsMemberLagging(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, int64(0)).AnyTimes()
+	manager.EXPECT().HasOtherHealthyLeader(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	manager.EXPECT().IsPromoted(gomock.Any()).Return(true).AnyTimes()
+
+	return &Ha{
+		ctx:       context.Background(),
+		dbManager: manager,
+		dcs:       store,
+		logger:    ctrl.Log.WithName("HA"),
+	}, manager
+}
+
+func getLeaderName(t *testing.T, store *dcs.MemoryStore) string {
+	leader, err := store.GetLeader()
+	require.NoError(t, err)
+	require.NotNil(t, leader)
+	return leader.Name
+}
+
+func TestRunCycle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	members := []dcs.Member{{Name: "test-mysql-0"}, {Name: "test-mysql-1"}}
+	store0 := dcs.NewMemoryStore("test", "test-mysql", "default", "test-mysql-0", members)
+	store1 := store0.WithMember("test-mysql-1")
+	require.NoError(t, store0.Initialize())
+
+	ha0, manager0 := newTestHa(mockCtrl, store0, "test-mysql-0")
+	ha1, manager1 := newTestHa(mockCtrl, store1, "test-mysql-1")
+
+	t.Run("leader renews the lease and follower follows", func(t *testing.T) {
+		manager0.EXPECT().Promote(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		ha0.RunCycle()
+		assert.True(t, store0.HasLease())
+
+		manager1.EXPECT().Demote(gomock.Any()).Return(nil).Times(1)
+		manager1.EXPECT().Follow(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		ha1.RunCycle()
+		assert.False(t, store1.HasLease())
+		assert.Equal(t, "test-mysql-0", getLeaderName(t, store1))
+	})
+
+	t.Run("failover when the lease expires", func(t *testing.T) {
+		store0.ExpireLease()
+
+		// take the leader and then refresh it in the same cycle, the promotion is
+		// recorded before the db is promoted
+		manager1.EXPECT().Promote(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *dcs.Cluster) error {
+			assert.NotNil(t, lastPromotion.Load())
+			return nil
+		}).Times(2)
+		ha1.RunCycle()
+		assert.True(t, store1.HasLease())
+		assert.Equal(t, "test-mysql-1", getLeaderName(t, store0))
+		promotion := TakeLastPromotion()
+		require.NotNil(t, promotion)
+		assert.Equal(t, "test-mysql-0", promotion.PreviousLeader)
+
+		manager0.EXPECT().Demote(gomock.Any()).Return(nil).Times(1)
+		manager0.EXPECT().Follow(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		ha0.RunCycle()
+		assert.False(t, store0.HasLease())
+	})
+
+	t.Run("switchover to the candidate", func(t *testing.T) {
+		require.NoError(t, store0.CreateSwitchover("test-mysql-1", "test-mysql-0"))
+
+		manager1.EXPECT().Demote(gomock.Any()).Return(nil).Times(1)
+		ha1.RunCycle()
+		assert.False(t, store1.HasLease())
+		assert.Equal(t, "", getLeaderName(t, store0))
+
+		manager0.EXPECT().Promote(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		ha0.RunCycle()
+		assert.True(t, store0.HasLease())
+		assert.Equal(t, "test-mysql-0", getLeaderName(t, store1))
+
+		switchover, err := store0.GetSwitchover()
+		assert.NoError(t, err)
+		assert.Nil(t, switchover)
+	})
+
+	t.Run("ha disabled", func(t *testing.T) {
+		cluster, err := store0.GetCluster()
+		require.NoError(t, err)
+		cluster.HaConfig.SetEnable(false)
+		require.NoError(t, store0.UpdateHaConfig())
+
+		// no more calls on the db managers are expected
+		ha0.RunCycle()
+		ha1.RunCycle()
+	})
+}
+
+type fakeFencer struct {
+	fenced []string
+	err    error
+}
+
+func (f *fakeFencer) Fence(_ context.Context, _ *dcs.Cluster, member *dcs.Member) error {
+	f.fenced = append(f.fenced, member.Name)
+	return f.err
+}
+
+func TestRunCycleWithFencing(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	members := []dcs.Member{{Name: "test-mysql-0"}, {Name: "test-mysql-1"}}
+	store0 := dcs.NewMemoryStore("test", "test-mysql", "default", "test-mysql-0", members)
+	store1 := store0.WithMember("test-mysql-1")
+	require.NoError(t, store0.Initialize())
+	store0.ExpireLease()
+
+	ha1, manager1 := newTestHa(mockCtrl, store1, "test-mysql-1")
+	fencer := &fakeFencer{err: errors.New("fencing failed")}
+	ha1.fencer = fencer
+
+	t.Run("no promotion if fencing fails", func(t *testing.T) {
+		ha1.RunCycle()
+		assert.Equal(t, []string{"test-mysql-0"}, fencer.fenced)
+		assert.False(t, store1.HasLease())
+
+		leader, err := store1.GetLeader()
+		require.NoError(t, err)
+		assert.Equal(t, "", leader.Name)
+		assert.Equal(t, "test-mysql-0", leader.Holder)
+	})
+
+	t.Run("promotion after fencing", func(t *testing.T) {
+		fencer.err = nil
+		manager1.EXPECT().Promote(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		ha1.RunCycle()
+		assert.Equal(t, []string{"test-mysql-0", "test-mysql-0"}, fencer.fenced)
+		assert.True(t, store1.HasLease())
+		assert.Equal(t, "test-mysql-1", getLeaderName(t, store0))
+	})
+
+	t.Run("no fencing after the lease is released", func(t *testing.T) {
+		require.NoError(t, store1.ReleaseLease())
+		fencer.fenced = nil
+
+		ha0, manager0 := newTestHa(mockCtrl, store0, "test-mysql-0")
+		ha0.fencer = fencer
+		manager0.EXPECT().Promote(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		ha0.RunCycle()
+		assert.Empty(t, fencer.fenced)
+		assert.True(t, store0.HasLease())
+	})
+}
+
+func TestRunCycleWithPromotionFailure(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	members := []dcs.Member{{Name: "test-mysql-0"}, {Name: "test-mysql-1"}}
+	store0 := dcs.NewMemoryStore("test", "test-mysql", "default", "test-mysql-0", members)
+	store1 := store0.WithMember("test-mysql-1")
+	require.NoError(t, store0.Initialize())
+	store0.ExpireLease()
+
+	ha1, manager1 := newTestHa(mockCtrl, store1, "test-mysql-1")
+	manager1.EXPECT().Promote(gomock.Any(), gomock.Any()).Return(errors.New("promote failed")).Times(1)
+	ha1.RunCycle()
+	assert.False(t, store1.HasLease())
+	assert.Nil(t, TakeLastPromotion())
+}
+
+func TestDemoteIfLeaseExpiring(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	store := dcs.NewMockDCS(mockCtrl)
+	store.EXPECT().GetCluster().Return(nil, errors.New("dcs is unreachable")).AnyTimes()
+	manager := engines.NewMockDBManager(mockCtrl)
+	ha := &Ha{
+		ctx:       context.Background(),
+		dbManager: manager,
+		dcs:       store,
+		logger:    ctrl.Log.WithName("HA"),
+		leaseTTL:  30 * time.Second,
+	}
+
+	t.Run("not the leader", func(t *testing.T) {
+		ha.RunCycle()
+	})
+
+	t.Run("lease is not expiring", func(t *testing.T) {
+		ha.leaseRenewedAt = time.Now()
+		ha.RunCycle()
+		assert.False(t, ha.leaseRenewedAt.IsZero())
+	})
+
+	t.Run("lease is expiring", func(t *testing.T) {
+		ha.leaseRenewedAt = time.Now().Add(-25 * time.Second)
+		manager.EXPECT().Demote(gomock.Any()).Return(errors.New("demote failed")).Times(1)
+		ha.RunCycle()
+		assert.False(t, ha.leaseRenewedAt.IsZero())
+
+		// demote again in every cycle until the lease is renewed
+		manager.EXPECT().Demote(gomock.Any()).Return(nil).Times(2)
+		ha.RunCycle()
+		ha.RunCycle()
+		assert.False(t, ha.leaseRenewedAt.IsZero())
+	})
+}
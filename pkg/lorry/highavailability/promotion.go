@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package highavailability
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+)
+
+// the reasons of a promotion.
+const (
+	FailoverReason   = "Failover"
+	SwitchoverReason = "Switchover"
+)
+
+// promotionReportWindow is how long a promotion is reported with the role change.
+const promotionReportWindow = time.Minute
+
+// Promotion records how the current member was promoted to the leader by HA,
+// which is reported along with the role change by the role probe.
+type Promotion struct {
+	PreviousLeader string
+	Reason         string
+	// Lag is the replication lag of the current member when it was promoted.
+	Lag int64
+	// UnavailableSeconds is the time since the previous leader renewed the lease for the last time.
+	UnavailableSeconds int64
+	Time               time.Time
+}
+
+var lastPromotion atomic.Pointer[Promotion]
+
+func recordPromotion(cluster *dcs.Cluster, previousLeader *dcs.Leader, lag int64) {
+	now := time.Now()
+	promotion := &Promotion{
+		PreviousLeader: previousLeader.Holder,
+		Reason:         FailoverReason,
+		Lag:            lag,
+		Time:           now,
+	}
+	if cluster.Switchover != nil {
+		promotion.Reason = SwitchoverReason
+		if promotion.PreviousLeader == "" {
+			promotion.PreviousLeader = cluster.Switchover.Leader
+		}
+	}
+	if previousLeader.RenewTime > 0 {
+		promotion.UnavailableSeconds = now.Unix() - previousLeader.RenewTime
+	}
+	lastPromotion.Store(promotion)
+}
+
+// clearPromotion drops the recorded promotion if the promotion failed.
+func clearPromotion() {
+	lastPromotion.Store(nil)
+}
+
+// TakeLastPromotion returns the promotion of the current member if it happened recently,
+// the promotion is cleared once it is taken.
+func TakeLastPromotion() *Promotion {
+	promotion := lastPromotion.Swap(nil)
+	if promotion == nil || time.Since(promotion.Time) > promotionReportWindow {
+		return nil
+	}
+	return promotion
+}
@@ -0,0 +1,68 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package highavailability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+)
+
+func TestRecordPromotion(t *testing.T) {
+	t.Run("failover", func(t *testing.T) {
+		previousLeader := &dcs.Leader{Holder: "pod-0", RenewTime: time.Now().Add(-5 * time.Second).Unix()}
+		recordPromotion(&dcs.Cluster{}, previousLeader, 10)
+
+		promotion := TakeLastPromotion()
+		require.NotNil(t, promotion)
+		assert.Equal(t, "pod-0", promotion.PreviousLeader)
+		assert.Equal(t, FailoverReason, promotion.Reason)
+		assert.Equal(t, int64(10), promotion.Lag)
+		assert.GreaterOrEqual(t, promotion.UnavailableSeconds, int64(5))
+		assert.Nil(t, TakeLastPromotion())
+	})
+
+	t.Run("switchover", func(t *testing.T) {
+		cluster := &dcs.Cluster{Switchover: &dcs.Switchover{Leader: "pod-0", Candidate: "pod-1"}}
+		recordPromotion(cluster, &dcs.Leader{}, 0)
+
+		promotion := TakeLastPromotion()
+		require.NotNil(t, promotion)
+		assert.Equal(t, "pod-0", promotion.PreviousLeader)
+		assert.Equal(t, SwitchoverReason, promotion.Reason)
+		assert.Zero(t, promotion.UnavailableSeconds)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		recordPromotion(&dcs.Cluster{}, &dcs.Leader{Holder: "pod-0"}, 0)
+		lastPromotion.Load().Time = time.Now().Add(-2 * promotionReportWindow)
+		assert.Nil(t, TakeLastPromotion())
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		recordPromotion(&dcs.Cluster{}, &dcs.Leader{Holder: "pod-0"}, 0)
+		clearPromotion()
+		assert.Nil(t, TakeLastPromotion())
+	})
+}
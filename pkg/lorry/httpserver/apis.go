@@ -64,10 +64,11 @@ func (a *api) RegisterOperations(ops map[string]operations.Operation) {
 		}
 
 		endpoint := Endpoint{
-			Version: version,
+			Version:  version,
+			Readonly: op.IsReadonly(context.Background()),
 		}
 
-		if op.IsReadonly(context.Background()) {
+		if endpoint.Readonly {
 			endpoint.Method = fasthttp.MethodGet
 		} else {
 			endpoint.Method = fasthttp.MethodPost
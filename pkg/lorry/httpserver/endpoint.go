@@ -28,6 +28,7 @@ type Endpoint struct {
 	Route     string
 	Version   string
 	Duplicate string
+	Readonly  bool
 	Handler   fasthttp.RequestHandler
 }
 
@@ -20,18 +20,26 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package httpserver
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
+	"strings"
 	"time"
 
 	fasthttprouter "github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
 
+	"github.com/apecloud/kubeblocks/pkg/lorry/audit"
+	"github.com/apecloud/kubeblocks/pkg/lorry/auth"
 	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
 )
 
+const callerKey = "lorry.caller"
+
 // Server is an interface for the Lorry HTTP server.
 type Server interface {
 	io.Closer
@@ -40,9 +48,11 @@ type Server interface {
 }
 
 type server struct {
-	config  Config
-	api     OperationAPI
-	servers []*fasthttp.Server
+	config     Config
+	api        OperationAPI
+	authorizer *auth.Authorizer
+	auditor    *audit.Auditor
+	servers    []*fasthttp.Server
 }
 
 // NewServer returns a new HTTP server.
@@ -58,6 +68,17 @@ func NewServer(ops map[string]operations.Operation) Server {
 // StartNonBlocking starts a new server in a goroutine.
 func (s *server) StartNonBlocking() error {
 	logger.Info("Starting HTTP Server")
+	authConfig := auth.GetConfig()
+	authorizer, err := auth.NewAuthorizer(authConfig)
+	if err != nil {
+		return err
+	}
+	s.authorizer = authorizer
+	s.auditor = audit.NewAuditor(audit.GetConfig())
+	tlsConfig, err := auth.ServerTLSConfig(authConfig)
+	if err != nil {
+		return err
+	}
 	handler := s.Router()
 
 	APILogging := s.config.APILogging
@@ -78,6 +99,8 @@ func (s *server) StartNonBlocking() error {
 		l, err := net.Listen("tcp", fmt.Sprintf("%s:%v", apiListenAddress, s.config.Port))
 		if err != nil {
 			logger.Error(err, "listen address", apiListenAddress, "port", s.config.Port)
+		} else if tlsConfig != nil {
+			listeners = append(listeners, tls.NewListener(l, tlsConfig))
 		} else {
 			listeners = append(listeners, l)
 		}
@@ -128,6 +151,75 @@ func (s *server) apiLogger(next fasthttp.RequestHandler) fasthttp.RequestHandler
 	}
 }
 
+// authorize checks the credentials of the request before calling the operation.
+func (s *server) authorize(e Endpoint, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	if s.authorizer == nil {
+		return next
+	}
+	return func(ctx *fasthttp.RequestCtx) {
+		req := auth.Request{
+			Operation: e.Route,
+			Readonly:  e.Readonly,
+		}
+		if authorization := string(ctx.Request.Header.Peek(fasthttp.HeaderAuthorization)); strings.HasPrefix(authorization, "Bearer ") {
+			req.Token = strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+		}
+		if state := ctx.TLSConnectionState(); state != nil && len(state.VerifiedChains) > 0 {
+			req.HasVerifiedCert = true
+			req.CertCommonName = state.PeerCertificates[0].Subject.CommonName
+		}
+
+		caller, err := s.authorizer.Authorize(context.Background(), req)
+		ctx.SetUserValue(callerKey, caller)
+		switch {
+		case errors.Is(err, auth.ErrUnauthorized):
+			msg := NewErrorResponse("ERR_UNAUTHORIZED", fmt.Sprintf("operation %s is unauthorized", e.Route))
+			respond(ctx, withError(fasthttp.StatusUnauthorized, msg))
+		case errors.Is(err, auth.ErrForbidden):
+			msg := NewErrorResponse("ERR_FORBIDDEN", fmt.Sprintf("operation %s is forbidden", e.Route))
+			respond(ctx, withError(fasthttp.StatusForbidden, msg))
+		default:
+			next(ctx)
+		}
+	}
+}
+
+// audit records the caller, parameters and result of the operation.
+func (s *server) audit(e Endpoint, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	if !s.auditor.IsAudited(e.Route, e.Readonly) {
+		return next
+	}
+	return func(ctx *fasthttp.RequestCtx) {
+		start := time.Now()
+		next(ctx)
+
+		record := &audit.Record{
+			Time:       start,
+			Caller:     "unauthenticated",
+			RemoteAddr: ctx.RemoteAddr().String(),
+			Operation:  e.Route,
+			Result:     audit.ResultSuccess,
+			StatusCode: ctx.Response.StatusCode(),
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if caller, ok := ctx.UserValue(callerKey).(string); ok && caller != "" {
+			record.Caller = caller
+		}
+		var req Request
+		if err := json.Unmarshal(ctx.PostBody(), &req); err == nil {
+			record.Parameters = req.Parameters
+		}
+		if record.StatusCode >= fasthttp.StatusBadRequest {
+			record.Result = audit.ResultFailed
+			var resp ErrorResponse
+			if err := json.Unmarshal(ctx.Response.Body(), &resp); err == nil {
+				record.Message = resp.Message
+			}
+		}
+		s.auditor.Log(context.Background(), record)
+	}
+}
+
 func (s *server) Router() fasthttp.RequestHandler {
 	endpoints := s.api.Endpoints()
 	router := s.getRouter(endpoints)
@@ -139,11 +231,12 @@ func (s *server) getRouter(endpoints []Endpoint) *fasthttprouter.Router {
 	router := fasthttprouter.New()
 	for _, e := range endpoints {
 		path := fmt.Sprintf("/%s/%s", e.Version, e.Route)
-		router.Handle(e.Method, path, e.Handler)
+		handler := s.audit(e, s.authorize(e, e.Handler))
+		router.Handle(e.Method, path, handler)
 
 		if e.Duplicate != "" {
 			path := fmt.Sprintf("/%s/%s", e.Version, e.Duplicate)
-			router.Handle(e.Method, path, e.Handler)
+			router.Handle(e.Method, path, handler)
 		}
 	}
 
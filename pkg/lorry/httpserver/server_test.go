@@ -23,11 +23,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
 
+	"github.com/apecloud/kubeblocks/pkg/lorry/audit"
+	"github.com/apecloud/kubeblocks/pkg/lorry/auth"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
 	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
 	"github.com/apecloud/kubeblocks/pkg/lorry/util"
@@ -151,6 +156,86 @@ func TestRouter(t *testing.T) {
 	})
 }
 
+func TestRouterWithAuth(t *testing.T) {
+	fakeServer := mockServer(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.Nil(t, os.WriteFile(tokenFile, []byte("admin-token"), 0600))
+	authorizer, err := auth.NewAuthorizer(auth.Config{
+		TokenFile:           tokenFile,
+		AnonymousOperations: []string{"fake-1"},
+	})
+	assert.Nil(t, err)
+	fakeServer.authorizer = authorizer
+	handler := fakeServer.Router()
+
+	t.Run("anonymous operation", func(t *testing.T) {
+		ctx := mockHTTPRequest("/v1.0/fake-1", fasthttp.MethodPost, `{"data": "test"}`)
+		handler(ctx)
+
+		assert.Equal(t, fasthttp.StatusNoContent, ctx.Response.StatusCode())
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		ctx := mockHTTPRequest("/v1.0/fake-6", fasthttp.MethodPost, `{"data": "test"}`)
+		ctx.Request.Header.Set(fasthttp.HeaderAuthorization, "Bearer wrong-token")
+		handler(ctx)
+
+		response := parseErrorResponse(t, ctx.Response.Body())
+		assert.Equal(t, fasthttp.StatusUnauthorized, ctx.Response.StatusCode())
+		assert.Equal(t, "ERR_UNAUTHORIZED", response.ErrorCode)
+	})
+
+	t.Run("authorized", func(t *testing.T) {
+		ctx := mockHTTPRequest("/v1.0/fake-6", fasthttp.MethodPost, `{"data": "test"}`)
+		ctx.Request.Header.Set(fasthttp.HeaderAuthorization, "Bearer admin-token")
+		handler(ctx)
+
+		assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+	})
+}
+
+func TestRouterWithAudit(t *testing.T) {
+	fakeServer := mockServer(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.Nil(t, os.WriteFile(tokenFile, []byte("admin-token"), 0600))
+	authorizer, err := auth.NewAuthorizer(auth.Config{TokenFile: tokenFile})
+	assert.Nil(t, err)
+	fakeServer.authorizer = authorizer
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	fakeServer.auditor = audit.NewAuditor(audit.Config{LogPath: logPath, Operations: []string{"fake-5", "fake-6"}})
+	handler := fakeServer.Router()
+
+	ctx := mockHTTPRequest("/v1.0/fake-6", fasthttp.MethodPost, `{"parameters": {"userName": "test", "password": "secret"}}`)
+	ctx.Request.Header.Set(fasthttp.HeaderAuthorization, "Bearer admin-token")
+	handler(ctx)
+	ctx = mockHTTPRequest("/v1.0/fake-5", fasthttp.MethodPost, `{"data": "test"}`)
+	handler(ctx)
+	// fake-1 is not audited
+	ctx = mockHTTPRequest("/v1.0/fake-1", fasthttp.MethodPost, `{"data": "test"}`)
+	handler(ctx)
+
+	data, err := os.ReadFile(logPath)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+
+	record := &audit.Record{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), record))
+	assert.Equal(t, "fake-6", record.Operation)
+	assert.Equal(t, "token:admin", record.Caller)
+	assert.Equal(t, audit.ResultSuccess, record.Result)
+	assert.Equal(t, "test", record.Parameters["userName"])
+	assert.Equal(t, "******", record.Parameters["password"])
+
+	record = &audit.Record{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), record))
+	assert.Equal(t, "fake-5", record.Operation)
+	assert.Equal(t, "anonymous", record.Caller)
+	assert.Equal(t, audit.ResultFailed, record.Result)
+	assert.Equal(t, fasthttp.StatusUnauthorized, record.StatusCode)
+	assert.Equal(t, "operation fake-5 is unauthorized", record.Message)
+}
+
 func TestStartNonBlocking(t *testing.T) {
 	fakeServer := mockServer(t)
 
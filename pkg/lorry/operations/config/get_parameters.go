@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+type GetParameters struct {
+	operations.Base
+	dbManager engines.DBManager
+	logger    logr.Logger
+}
+
+var getParameters operations.Operation = &GetParameters{}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.GetParametersOp)), getParameters)
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *GetParameters) Init(context.Context) error {
+	dbManager, err := register.GetDBManager()
+	if err != nil {
+		return errors.Wrap(err, "get manager failed")
+	}
+	s.dbManager = dbManager
+	s.logger = ctrl.Log.WithName("GetParameters")
+	return nil
+}
+
+func (s *GetParameters) IsReadonly(context.Context) bool {
+	return true
+}
+
+func (s *GetParameters) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	if len(parseParameterNames(req)) == 0 {
+		return errors.New("no parameter names provided")
+	}
+	return nil
+}
+
+func (s *GetParameters) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	resp := operations.NewOpsResponse(util.GetParametersOp)
+
+	parameters, err := s.dbManager.GetParameters(ctx, parseParameterNames(req))
+	if err != nil {
+		s.logger.Info("executing GetParameters error", "error", err)
+		return resp, err
+	}
+
+	resp.Data["parameters"] = parameters
+	return resp.WithSuccess("")
+}
+
+// parseParameterNames parses the names from the request, which are decoded as []any from the JSON body.
+func parseParameterNames(req *operations.OpsRequest) []string {
+	var names []string
+	switch values := req.Parameters["names"].(type) {
+	case []string:
+		names = values
+	case []any:
+		for _, value := range values {
+			names = append(names, fmt.Sprint(value))
+		}
+	}
+	return names
+}
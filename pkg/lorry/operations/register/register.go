@@ -21,6 +21,7 @@ package register
 
 import (
 	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	_ "github.com/apecloud/kubeblocks/pkg/lorry/operations/config"
 	_ "github.com/apecloud/kubeblocks/pkg/lorry/operations/replica"
 	_ "github.com/apecloud/kubeblocks/pkg/lorry/operations/sql"
 	_ "github.com/apecloud/kubeblocks/pkg/lorry/operations/user"
@@ -34,6 +34,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/highavailability"
 	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
 	"github.com/apecloud/kubeblocks/pkg/lorry/util"
 )
@@ -144,6 +145,12 @@ func (s *CheckRole) Do(ctx context.Context, req *operations.OpsRequest) (*operat
 	}
 	resp.Data["event"] = util.OperationSuccess
 	s.OriRole = role
+	if promotion := highavailability.TakeLastPromotion(); promotion != nil {
+		resp.Data["previousLeader"] = promotion.PreviousLeader
+		resp.Data["reason"] = promotion.Reason
+		resp.Data["lag"] = promotion.Lag
+		resp.Data["unavailableSeconds"] = promotion.UnavailableSeconds
+	}
 	err = util.SentEventForProbe(ctx, resp.Data)
 	return resp, err
 }
@@ -86,8 +86,7 @@ func (s *CheckStatus) Do(ctx context.Context, req *operations.OpsRequest) (*oper
 	resp.Data["operation"] = util.CheckStatusOperation
 	var message string
 
-	k8sStore := s.dcsStore.(*dcs.KubernetesStore)
-	cluster := k8sStore.GetClusterFromCache()
+	cluster := s.dcsStore.GetClusterFromCache()
 	isHealthy := s.dbManager.IsCurrentMemberHealthy(ctx, cluster)
 	if !isHealthy {
 		message = "status check failed"
@@ -78,8 +78,7 @@ func (s *GetLag) Do(ctx context.Context, req *operations.OpsRequest) (*operation
 		Data: map[string]any{},
 	}
 	resp.Data["operation"] = util.ExecOperation
-	k8sStore := s.dcsStore.(*dcs.KubernetesStore)
-	cluster := k8sStore.GetClusterFromCache()
+	cluster := s.dcsStore.GetClusterFromCache()
 
 	lag, err := s.dbManager.GetLag(ctx, cluster)
 	if err != nil {
@@ -30,6 +30,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -54,8 +55,12 @@ const (
 	certFile  = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
 	tokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 
-	reasonLock   = "HighVolumeWatermark"
-	reasonUnlock = "LowVolumeWatermark" // TODO
+	reasonLock   = util.HighVolumeWatermarkReason
+	reasonUnlock = util.LowVolumeWatermarkReason
+
+	// lockEventResendInterval is the interval to send the lock event again if the instance is still locked,
+	// which makes the volume be expanded again if the last expansion is not enough.
+	lockEventResendInterval = 5 * time.Minute
 )
 
 type volumeStatsRequester interface {
@@ -87,6 +92,7 @@ type Protection struct {
 	Volumes       map[string]volumeExt
 	Readonly      bool
 	SendEvent     bool // to disable event for testing
+	lastLockEvent time.Time
 	Logger        logr.Logger
 }
 
@@ -225,6 +231,9 @@ func (p *Protection) checkUsage(ctx context.Context) (map[string]any, error) {
 	}
 
 	volumeUsages := p.buildVolumesMsg()
+	if len(higher) > 0 {
+		volumeUsages[util.VolumeHighWatermarkField] = higher
+	}
 	readonly := p.Readonly
 	// the instance is running normally and there have volume(s) over the space usage threshold.
 	if !readonly && len(higher) > 0 {
@@ -232,6 +241,12 @@ func (p *Protection) checkUsage(ctx context.Context) (map[string]any, error) {
 			return volumeUsages, err
 		}
 	}
+	// the instance is still protected in RO mode and the volume(s) are not expanded enough.
+	if readonly && len(higher) > 0 && time.Since(p.lastLockEvent) >= lockEventResendInterval {
+		if err := p.sendLockEvent(ctx, volumeUsages); err != nil {
+			return volumeUsages, err
+		}
+	}
 	// the instance is protected in RO mode, and all volumes' space usage are under the threshold.
 	if readonly && len(lower) == len(p.Volumes) {
 		if err := p.lowWatermark(ctx, volumeUsages); err != nil {
@@ -271,10 +286,15 @@ func (p *Protection) highWatermark(ctx context.Context, volumeUsages map[string]
 	p.Logger.Info("set instance to read-only OK", "msg", volumeUsages)
 	p.Readonly = true
 
+	return p.sendLockEvent(ctx, volumeUsages)
+}
+
+func (p *Protection) sendLockEvent(ctx context.Context, volumeUsages map[string]any) error {
 	if err := p.sendEvent(ctx, reasonLock, volumeUsages); err != nil {
 		p.Logger.Error(err, "send volume protection (lock) event error", "volumes", volumeUsages)
 		return err
 	}
+	p.lastLockEvent = time.Now()
 	return nil
 }
 
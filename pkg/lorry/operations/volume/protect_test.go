@@ -35,6 +35,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
 )
 
 type mockVolumeStatsRequester struct {
@@ -340,6 +341,54 @@ var _ = Describe("Volume Protection Operation", func() {
 			Expect(obj.Readonly).Should(BeTrue())
 		})
 
+		It("volume keeps over high watermark", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockDBManager := engines.NewMockDBManager(ctrl)
+			mockDBManager.EXPECT().Lock(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			register.SetDBManager(mockDBManager)
+
+			obj := newProtection()
+			mock := obj.Requester.(*mockVolumeStatsRequester)
+			stats := statsv1alpha1.Summary{
+				Pods: []statsv1alpha1.PodStats{
+					{
+						PodRef: statsv1alpha1.PodReference{
+							Name: podName,
+						},
+						VolumeStats: []statsv1alpha1.VolumeStats{
+							{
+								Name: volumeName,
+								FsStats: statsv1alpha1.FsStats{
+									CapacityBytes: &capacityBytes,
+									UsedBytes:     &usedBytesOverThreshold,
+								},
+							},
+						},
+					},
+				},
+			}
+			mock.summary, _ = json.Marshal(stats)
+
+			rsp, err := obj.Do(context.Background(), nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(obj.Readonly).Should(BeTrue())
+			Expect(rsp.Data["protect"]).Should(HaveKeyWithValue(util.VolumeHighWatermarkField, []string{volumeName}))
+			lastLockEvent := obj.lastLockEvent
+			Expect(lastLockEvent.IsZero()).Should(BeFalse())
+
+			// the lock event is not sent again within the interval
+			_, err = obj.Do(context.Background(), nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(obj.lastLockEvent).Should(Equal(lastLockEvent))
+
+			// the lock event is sent again after the interval
+			obj.lastLockEvent = lastLockEvent.Add(-lockEventResendInterval)
+			_, err = obj.Do(context.Background(), nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(obj.Readonly).Should(BeTrue())
+			Expect(obj.lastLockEvent.After(lastLockEvent)).Should(BeTrue())
+		})
+
 		It("volume under high watermark", func() {
 			ctrl := gomock.NewController(GinkgoT())
 			mockDBManager := engines.NewMockDBManager(ctrl)
@@ -60,6 +60,12 @@ const (
 	RevokeUserRoleOp     OperationKind = "revokeUserRole"
 	ListSystemAccountsOp OperationKind = "listSystemAccounts"
 
+	UpdateUserPasswordOp     OperationKind = "updateUserPassword"
+	DiscardUserOldPasswordOp OperationKind = "discardUserOldPassword"
+
+	// actions for configuration
+	GetParametersOp OperationKind = "getParameters"
+
 	JoinMemberOperation  OperationKind = "joinMember"
 	LeaveMemberOperation OperationKind = "leaveMember"
 
@@ -84,6 +90,15 @@ const (
 			`
 )
 
+// the reasons of the events sent by the volume protection.
+const (
+	HighVolumeWatermarkReason = "HighVolumeWatermark"
+	LowVolumeWatermarkReason  = "LowVolumeWatermark"
+)
+
+// VolumeHighWatermarkField is the field of the volume usages that lists the volumes over the high watermark.
+const VolumeHighWatermarkField = "highVolumes"
+
 type RoleType string
 
 func (r RoleType) EqualTo(role string) bool {